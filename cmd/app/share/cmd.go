@@ -42,6 +42,7 @@ func run(cmd *cobra.Command, args []string) error {
 		AppDir:  cmdArgs.appDir,
 		AppSlug: cmdArgs.appIdent.AppSlug,
 		OrgSlug: cmdArgs.appIdent.OrganizationSlug,
+		Env:     cmdArgs.appIdent.Environment,
 	}
 
 	err := shareApp(cmd.Context(), service, input)
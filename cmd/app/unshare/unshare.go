@@ -12,6 +12,7 @@ type Input struct {
 	AppDir  string
 	AppSlug string
 	OrgSlug string
+	Env     string
 }
 
 type AppService interface {
@@ -19,7 +20,7 @@ type AppService interface {
 }
 
 func unshareApp(ctx context.Context, apps AppService, input Input) error {
-	ai, err := appident.GetAppIdentifier(input.AppDir, nil, input.OrgSlug, input.AppSlug)
+	ai, err := appident.GetAppIdentifier(input.AppDir, nil, input.Env, input.OrgSlug, input.AppSlug)
 	if err != nil {
 		appident.PrintGetAppIdentifierError(err, input.AppDir, ai)
 		return err
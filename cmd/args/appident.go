@@ -7,9 +7,11 @@ import (
 type AppIdentifierArg struct {
 	OrganizationSlug string
 	AppSlug          string
+	Environment      string
 }
 
 func (a *AppIdentifierArg) AddAppIdentifierFlags(flags *pflag.FlagSet, action string) {
 	flags.StringVarP(&a.OrganizationSlug, "organization", "o", "", "The organization slug identifier of the app "+action+". List available organizations with 'numerous organization list'.")
 	flags.StringVarP(&a.AppSlug, "app", "a", "", "An app slug identifier of the app "+action+".")
+	flags.StringVar(&a.Environment, "env", "", "The deploy environment of the app "+action+", defined in a \"deploy.<environment>\" table in the app manifest.")
 }
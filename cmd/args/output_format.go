@@ -0,0 +1,64 @@
+package args
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type OutputFormat string
+
+const (
+	OutputFormatText OutputFormat = "text"
+	OutputFormatJSON OutputFormat = "json"
+	OutputFormatYAML OutputFormat = "yaml"
+	OutputFormatTOML OutputFormat = "toml"
+)
+
+// OutputFormatArg is the value of an output format flag, which accepts one of
+// the allowed formats.
+type OutputFormatArg struct {
+	Format  OutputFormat
+	allowed []OutputFormat
+}
+
+// NewOutputFormatArg returns an output format flag value, which accepts the
+// given formats. The first format is the default.
+func NewOutputFormatArg(allowed ...OutputFormat) OutputFormatArg {
+	return OutputFormatArg{Format: allowed[0], allowed: allowed}
+}
+
+func (a *OutputFormatArg) String() string {
+	return string(a.Format)
+}
+
+func (a *OutputFormatArg) Set(v string) error {
+	f := OutputFormat(strings.ToLower(v))
+	if !slices.Contains(a.allowed, f) {
+		return fmt.Errorf("must be one of %s", a.allowedList())
+	}
+
+	a.Format = f
+
+	return nil
+}
+
+func (a *OutputFormatArg) Type() string {
+	return "Output format"
+}
+
+// allowedList returns the quoted allowed formats, e.g. `"text", "json" or
+// "yaml"`.
+func (a *OutputFormatArg) allowedList() string {
+	quoted := make([]string, len(a.allowed))
+	for i, f := range a.allowed {
+		quoted[i] = strconv.Quote(string(f))
+	}
+
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
@@ -0,0 +1,34 @@
+package args
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputFormatArg(t *testing.T) {
+	t.Run("defaults to the first format", func(t *testing.T) {
+		a := NewOutputFormatArg(OutputFormatText, OutputFormatJSON)
+
+		assert.Equal(t, OutputFormatText, a.Format)
+		assert.Equal(t, "text", a.String())
+	})
+
+	t.Run("sets allowed format", func(t *testing.T) {
+		a := NewOutputFormatArg(OutputFormatText, OutputFormatJSON)
+
+		err := a.Set("JSON")
+
+		assert.NoError(t, err)
+		assert.Equal(t, OutputFormatJSON, a.Format)
+	})
+
+	t.Run("returns error for format which is not allowed", func(t *testing.T) {
+		a := NewOutputFormatArg(OutputFormatText, OutputFormatJSON, OutputFormatYAML)
+
+		err := a.Set("toml")
+
+		assert.EqualError(t, err, `must be one of "text", "json" or "yaml"`)
+		assert.Equal(t, OutputFormatText, a.Format)
+	})
+}
@@ -1,4 +1,4 @@
-package status
+package args
 
 import (
 	"errors"
@@ -7,10 +7,16 @@ import (
 	"time"
 )
 
+// Since is a flag value for a point in time, given as an RFC3339 timestamp, a
+// date, or a duration before now, like "5h" or "2d".
 type Since time.Time
 
 func (s *Since) String() string {
 	t := time.Time(*s)
+	if t.IsZero() {
+		return ""
+	}
+
 	return t.Format(time.RFC3339)
 }
 
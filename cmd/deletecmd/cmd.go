@@ -57,7 +57,7 @@ func run(cmd *cobra.Command, _ []string) error {
 	}
 
 	service := app.New(gql.NewClient(), nil, http.DefaultClient)
-	err := deleteApp(cmd.Context(), service, cmdArgs.appDir, cmdArgs.appIdent.OrganizationSlug, cmdArgs.appIdent.AppSlug)
+	err := deleteApp(cmd.Context(), service, cmdArgs.appDir, cmdArgs.appIdent.Environment, cmdArgs.appIdent.OrganizationSlug, cmdArgs.appIdent.AppSlug)
 
 	return errorhandling.ErrorAlreadyPrinted(err)
 }
@@ -12,8 +12,8 @@ type appDeleter interface {
 	Delete(ctx context.Context, input app.DeleteAppInput) error
 }
 
-func deleteApp(ctx context.Context, apps appDeleter, appDir, orgSlug, appSlug string) error {
-	ai, err := appident.GetAppIdentifier(appDir, nil, orgSlug, appSlug)
+func deleteApp(ctx context.Context, apps appDeleter, appDir, env, orgSlug, appSlug string) error {
+	ai, err := appident.GetAppIdentifier(appDir, nil, env, orgSlug, appSlug)
 	if err != nil {
 		appident.PrintGetAppIdentifierError(err, appDir, ai)
 		return err
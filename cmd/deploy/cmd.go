@@ -41,6 +41,11 @@ be pushed to the organization "organization-slug-a2ecf59b", and the app slug
 "my-app", the following command can be used:
 
 	numerous deploy --organization "organization-slug-a2ecf59b" --app "my-app"
+
+To redeploy the app automatically whenever its files change, while following
+the app logs:
+
+	numerous deploy --watch
 	`,
 	Args: args.OptionalAppDir(&cmdArgs.appDir),
 }
@@ -53,30 +58,54 @@ var cmdArgs struct {
 	message    string
 	version    string
 	follow     bool
+	dryRun     bool
+	watch      bool
 }
 
 func run(cmd *cobra.Command, args []string) error {
-	sc := gql.NewSubscriptionClient().WithSyncMode(true)
-	service := app.New(gql.NewClient(), sc, http.DefaultClient)
 	input := deployInput{
 		appDir:     cmdArgs.appDir,
 		projectDir: cmdArgs.projectDir,
 		orgSlug:    cmdArgs.appIdent.OrganizationSlug,
+		env:        cmdArgs.appIdent.Environment,
 		appSlug:    cmdArgs.appIdent.AppSlug,
 		message:    cmdArgs.message,
 		version:    cmdArgs.version,
 		verbose:    cmdArgs.verbose,
 		follow:     cmdArgs.follow,
+		dryRun:     cmdArgs.dryRun,
+	}
+
+	var err error
+	if cmdArgs.watch {
+		err = deployWatch(cmd.Context(), newService, newReconnectingService, input)
+	} else {
+		err = deploy(cmd.Context(), newService(), input)
 	}
-	err := deploy(cmd.Context(), service, input)
 
 	return errorhandling.ErrorAlreadyPrinted(err)
 }
 
+func newService() appService {
+	sc := gql.NewSubscriptionClient().WithSyncMode(true)
+	return app.New(gql.NewClient(), sc, http.DefaultClient)
+}
+
+// newReconnectingService returns a service, which resubscribes to followed
+// logs when the connection is lost.
+func newReconnectingService(reconnect app.ReconnectOptions) appService {
+	sc := gql.NewSubscriptionClient().WithSyncMode(true)
+	return app.New(gql.NewClient(), sc, http.DefaultClient).WithReconnect(reconnect)
+}
+
 func init() {
 	flags := Cmd.Flags()
 	cmdArgs.appIdent.AddAppIdentifierFlags(flags, cmdActionText)
 	flags.BoolVarP(&cmdArgs.verbose, "verbose", "v", false, "Display detailed information about the app deployment.")
 	flags.BoolVarP(&cmdArgs.follow, "follow", "f", false, "Follow app deployment logs after deployment has succeeded.")
+	flags.BoolVar(&cmdArgs.dryRun, "dry-run", false, "Display the deployment target and environment variables, with secrets masked, without deploying the app.")
+	flags.BoolVarP(&cmdArgs.watch, "watch", "w", false, "Watch the app source, and redeploy the app when files change, cancelling any deployment in progress. Logs are followed between deployments.")
 	flags.StringVarP(&cmdArgs.projectDir, "project-dir", "p", "", "The project directory, which is the build context if using a custom Dockerfile.")
+
+	Cmd.MarkFlagsMutuallyExclusive("watch", "dry-run")
 }
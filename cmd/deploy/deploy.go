@@ -6,16 +6,17 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"numerous.com/cli/cmd/logs"
 	"numerous.com/cli/internal/app"
 	"numerous.com/cli/internal/appident"
 	"numerous.com/cli/internal/archive"
-	"numerous.com/cli/internal/dotenv"
 	"numerous.com/cli/internal/links"
 	"numerous.com/cli/internal/manifest"
 	"numerous.com/cli/internal/output"
@@ -23,6 +24,9 @@ import (
 
 const maxUploadBytes int64 = 5368709120
 
+// The temporary app archive is created in the source directory.
+const appArchiveFileName = ".tmp_app_archive.tar"
+
 var (
 	errArchiveTooLarge   = fmt.Errorf("archive exceeds maximum size %d bytes", maxUploadBytes)
 	errAppDirOutOfBounds = errors.New("app directory out of bounds of project directory")
@@ -41,7 +45,7 @@ type appService interface {
 	Create(ctx context.Context, input app.CreateAppInput) (app.CreateAppOutput, error)
 	CreateVersion(ctx context.Context, input app.CreateAppVersionInput) (app.CreateAppVersionOutput, error)
 	AppVersionUploadURL(ctx context.Context, input app.AppVersionUploadURLInput) (app.AppVersionUploadURLOutput, error)
-	UploadAppSource(uploadURL string, archive app.UploadArchive) error
+	UploadAppSource(ctx context.Context, uploadURL string, archive app.UploadArchive) error
 	DeployApp(ctx context.Context, input app.DeployAppInput) (app.DeployAppOutput, error)
 	DeployEvents(ctx context.Context, input app.DeployEventsInput) error
 	AppDeployLogs(appident.AppIdentifier, *int, bool) (chan app.AppDeployLogEntry, error)
@@ -51,39 +55,95 @@ type deployInput struct {
 	appDir     string
 	projectDir string
 	orgSlug    string
+	env        string
 	appSlug    string
 	version    string
 	message    string
 	verbose    bool
 	follow     bool
+	dryRun     bool
 }
 
 func deploy(ctx context.Context, apps appService, input deployInput) error {
+	if input.dryRun {
+		return dryRun(input)
+	}
+
+	orgSlug, appSlug, err := deployVersion(ctx, apps, input)
+	if err != nil {
+		return err
+	}
+
+	if input.follow {
+		output.Notify("Following logs of %s/%s:", "", orgSlug, appSlug)
+		if err := followLogs(ctx, apps, orgSlug, appSlug); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println()
+		fmt.Println("To read the logs from your app you can:")
+		fmt.Println("  " + output.Highlight("numerous logs --organization="+orgSlug+" --app="+appSlug))
+		fmt.Println("Or you can use the " + output.Highlight("--follow") + " flag:")
+
+		projectDirArg := ""
+		if input.projectDir != "" {
+			projectDirArg = " --project-dir=" + input.projectDir
+		}
+
+		appDirArg := ""
+		if input.appDir != "" {
+			appDirArg = " " + input.appDir
+		}
+
+		fmt.Println("  " + output.Highlight("numerous deploy --follow --organization="+orgSlug+" --app="+appSlug+projectDirArg+appDirArg))
+	}
+
+	return nil
+}
+
+// Deploys a new version of the app, and returns the organization and app
+// slugs it is deployed to.
+func deployVersion(ctx context.Context, apps appService, input deployInput) (string, string, error) {
 	appRelativePath, err := findAppRelativePath(input)
 	if err != nil {
 		output.PrintError("Project directory %q must be a parent of app directory %q", "", input.projectDir, input.appDir)
-		return err
+		return "", "", err
 	}
 
 	manifest, secrets, err := loadAppConfiguration(input)
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
+	variables := mergeEnvironmentVariables(manifest.Env, secrets)
+
 	appVersionOutput, orgSlug, appSlug, err := registerAppVersion(ctx, apps, input, manifest)
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
 	archive, err := createAppArchive(input, manifest)
 	if err != nil {
-		return err
+		return "", "", err
 	}
+	defer removeAppArchive(archive)
 
 	if err := uploadAppArchive(ctx, apps, archive, appVersionOutput.AppVersionID); err != nil {
-		return err
+		return "", "", err
 	}
 
+	if err := deployApp(ctx, appVersionOutput, variables, apps, input, appRelativePath); err != nil {
+		return "", "", err
+	}
+
+	output.PrintlnOK("Access your app at: " + links.GetAppURL(orgSlug, appSlug))
+
+	return orgSlug, appSlug, nil
+}
+
+// removeAppArchive closes and removes the temporary app archive, also when
+// the deployment failed or was cancelled.
+func removeAppArchive(archive *os.File) {
 	if err := archive.Close(); err != nil {
 		slog.Error("Error closing temporary app archive", slog.String("error", err.Error()))
 	}
@@ -91,38 +151,22 @@ func deploy(ctx context.Context, apps appService, input deployInput) error {
 	if err := os.Remove(archive.Name()); err != nil {
 		slog.Error("Error removing temporary app archive", slog.String("error", err.Error()))
 	}
+}
 
-	if err := deployApp(ctx, appVersionOutput, secrets, apps, input, appRelativePath); err != nil {
+func dryRun(input deployInput) error {
+	if _, err := findAppRelativePath(input); err != nil {
+		output.PrintError("Project directory %q must be a parent of app directory %q", "", input.projectDir, input.appDir)
 		return err
 	}
 
-	output.PrintlnOK("Access your app at: " + links.GetAppURL(orgSlug, appSlug))
-
-	if input.follow {
-		output.Notify("Following logs of %s/%s:", "", orgSlug, appSlug)
-		if err := followLogs(ctx, apps, orgSlug, appSlug); err != nil {
-			return err
-		}
-	} else {
-		fmt.Println()
-		fmt.Println("To read the logs from your app you can:")
-		fmt.Println("  " + output.Highlight("numerous logs --organization="+orgSlug+" --app="+appSlug))
-		fmt.Println("Or you can use the " + output.Highlight("--follow") + " flag:")
-
-		projectDirArg := ""
-		if input.projectDir != "" {
-			projectDirArg = " --project-dir=" + input.projectDir
-		}
-
-		appDirArg := ""
-		if input.appDir != "" {
-			appDirArg = " " + input.appDir
-		}
-
-		fmt.Println("  " + output.Highlight("numerous deploy --follow --organization="+orgSlug+" --app="+appSlug+projectDirArg+appDirArg))
+	manifest, secrets, err := loadAppConfiguration(input)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	variables := mergeEnvironmentVariables(manifest.Env, secrets)
+
+	return printDryRun(input, manifest, variables, secrets)
 }
 
 func findAppRelativePath(input deployInput) (string, error) {
@@ -153,8 +197,9 @@ func findAppRelativePath(input deployInput) (string, error) {
 }
 
 func loadAppConfiguration(input deployInput) (*manifest.Manifest, map[string]string, error) {
+	manifestPath := filepath.Join(input.appDir, manifest.ManifestFileName)
 	task := output.StartTask("Loading app configuration")
-	m, err := manifest.Load(filepath.Join(input.appDir, manifest.ManifestFileName))
+	m, format, err := manifest.LoadWithFormat(manifestPath)
 	if err != nil {
 		task.Error()
 		output.PrintErrorAppNotInitialized(input.appDir)
@@ -163,10 +208,16 @@ func loadAppConfiguration(input deployInput) (*manifest.Manifest, map[string]str
 		return nil, nil, err
 	}
 
-	secrets := loadSecretsFromEnv(input.appDir)
-
 	// for validation
-	ai, err := appident.GetAppIdentifier(input.appDir, m, input.orgSlug, input.appSlug)
+	ai, err := appident.GetAppIdentifier(input.appDir, m, input.env, input.orgSlug, input.appSlug)
+	if err != nil {
+		task.Error()
+		appident.PrintGetAppIdentifierError(err, input.appDir, ai)
+
+		return nil, nil, err
+	}
+
+	deployment, err := m.ResolveDeployEnvironment(input.env)
 	if err != nil {
 		task.Error()
 		appident.PrintGetAppIdentifierError(err, input.appDir, ai)
@@ -174,7 +225,19 @@ func loadAppConfiguration(input deployInput) (*manifest.Manifest, map[string]str
 		return nil, nil, err
 	}
 
+	// the deploy environment size overrides the app size for this deployment
+	m.Size = deployment.Size
+
+	secrets, err := deployment.LoadSecrets(input.appDir)
+	if err != nil {
+		task.Error()
+		output.PrintErrorDetails("Error reading secrets from environment file %q", err, deployment.EnvFile)
+
+		return nil, nil, err
+	}
+
 	task.Done()
+	manifest.WarnIfDeprecated(manifestPath, format)
 
 	return m, secrets, nil
 }
@@ -186,7 +249,7 @@ func createAppArchive(input deployInput, manifest *manifest.Manifest) (*os.File,
 	}
 
 	task := output.StartTask("Creating app archive")
-	archivePath := path.Join(srcPath, ".tmp_app_archive.tar")
+	archivePath := path.Join(srcPath, appArchiveFileName)
 
 	if err := archive.TarCreate(srcPath, archivePath, manifest.Exclude); err != nil {
 		task.Error()
@@ -209,7 +272,7 @@ func createAppArchive(input deployInput, manifest *manifest.Manifest) (*os.File,
 }
 
 func registerAppVersion(ctx context.Context, apps appService, input deployInput, manifest *manifest.Manifest) (app.CreateAppVersionOutput, string, string, error) {
-	ai, err := appident.GetAppIdentifier("", manifest, input.orgSlug, input.appSlug)
+	ai, err := appident.GetAppIdentifier("", manifest, input.env, input.orgSlug, input.appSlug)
 	if err != nil {
 		appident.PrintGetAppIdentifierError(err, input.appDir, ai)
 		return app.CreateAppVersionOutput{}, "", "", err
@@ -327,7 +390,7 @@ func uploadAppArchive(ctx context.Context, apps appService, archive *os.File, ap
 		Size:   stat.Size(),
 	}
 
-	err = apps.UploadAppSource(uploadURLOutput.UploadURL, uploadArchive)
+	err = apps.UploadAppSource(ctx, uploadURLOutput.UploadURL, uploadArchive)
 	var appSourceUploadErr *app.AppSourceUploadError
 	if errors.As(err, &appSourceUploadErr) {
 		task.Error()
@@ -399,10 +462,10 @@ func printAppSourceUploadErr(appSourceUploadErr *app.AppSourceUploadError) {
 	)
 }
 
-func deployApp(ctx context.Context, appVersionOutput app.CreateAppVersionOutput, secrets map[string]string, apps appService, input deployInput, appRelativePath string) error {
+func deployApp(ctx context.Context, appVersionOutput app.CreateAppVersionOutput, variables map[string]string, apps appService, input deployInput, appRelativePath string) error {
 	task := output.StartTask("Deploying app")
 
-	deployAppInput := app.DeployAppInput{AppVersionID: appVersionOutput.AppVersionID, Secrets: secrets, AppRelativePath: appRelativePath}
+	deployAppInput := app.DeployAppInput{AppVersionID: appVersionOutput.AppVersionID, Secrets: variables, AppRelativePath: appRelativePath}
 	deployAppOutput, err := apps.DeployApp(ctx, deployAppInput)
 	if err != nil {
 		task.Error()
@@ -415,6 +478,11 @@ func deployApp(ctx context.Context, appVersionOutput app.CreateAppVersionOutput,
 	eventsInput := app.DeployEventsInput{
 		DeploymentVersionID: deployAppOutput.DeploymentVersionID,
 		Handler: func(de app.DeployEvent) error {
+			// stops the subscription if the deployment has been cancelled
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
 			switch de.Typename {
 			case "AppBuildMessageEvent":
 				if input.verbose {
@@ -440,13 +508,16 @@ func deployApp(ctx context.Context, appVersionOutput app.CreateAppVersionOutput,
 		},
 	}
 
-	err = apps.DeployEvents(ctx, eventsInput)
+	err = waitForDeployEvents(ctx, apps, eventsInput)
 	if err != nil {
 		var buildError *deployBuildError
 		task.Error()
-		if errors.As(err, &buildError) {
+		switch {
+		case errors.Is(err, context.Canceled):
+			output.PrintWarning("Deployment cancelled", "")
+		case errors.As(err, &buildError):
 			output.PrintError("Build error", buildError.Message)
-		} else {
+		default:
 			output.PrintErrorDetails("Error occurred during deploy", err)
 		}
 
@@ -457,6 +528,22 @@ func deployApp(ctx context.Context, appVersionOutput app.CreateAppVersionOutput,
 	return nil
 }
 
+// Waits for the deploy events to be handled, or for the context to be done. The
+// events subscription itself is stopped by the handler at the next event.
+func waitForDeployEvents(ctx context.Context, apps appService, input app.DeployEventsInput) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- apps.DeployEvents(ctx, input)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 type statusUpdater struct {
 	verbose               bool
 	lastStatus            *string
@@ -490,9 +577,67 @@ func (s *statusUpdater) update(status string) error {
 	return nil
 }
 
-func loadSecretsFromEnv(appDir string) map[string]string {
-	env, _ := dotenv.Load(path.Join(appDir, manifest.EnvFileName))
-	return env
+// Merges the environment variables of the manifest with the secrets. Keys
+// defined in both are reported, and the secret value is used.
+func mergeEnvironmentVariables(env map[string]string, secrets map[string]string) map[string]string {
+	if len(env) == 0 {
+		return secrets
+	}
+
+	merged := make(map[string]string, len(env)+len(secrets))
+	maps.Copy(merged, env)
+
+	var duplicates []string
+	for key, value := range secrets {
+		if _, exists := merged[key]; exists {
+			duplicates = append(duplicates, key)
+		}
+		merged[key] = value
+	}
+
+	if len(duplicates) > 0 {
+		slices.Sort(duplicates)
+		output.PrintWarning(
+			"Environment variables are defined both in the manifest and as secrets",
+			"The secret values are used for: "+strings.Join(duplicates, ", ")+"\n"+
+				"Remove them from the [env] table in "+manifest.ManifestFileName+" or from the secrets file.",
+		)
+	}
+
+	return merged
+}
+
+func printDryRun(input deployInput, m *manifest.Manifest, variables map[string]string, secrets map[string]string) error {
+	ai, err := appident.GetAppIdentifier("", m, input.env, input.orgSlug, input.appSlug)
+	if err != nil {
+		appident.PrintGetAppIdentifierError(err, input.appDir, ai)
+		return err
+	}
+
+	size := "default"
+	if m.Size != nil {
+		size = *m.Size
+	}
+
+	output.Notify("Dry run, the app is not deployed", "")
+	fmt.Println("App:  " + ai.OrganizationSlug + "/" + ai.AppSlug)
+	fmt.Println("Size: " + size)
+
+	if len(variables) == 0 {
+		fmt.Println("No environment variables")
+		return nil
+	}
+
+	fmt.Println("Environment variables:")
+	for _, key := range slices.Sorted(maps.Keys(variables)) {
+		if _, isSecret := secrets[key]; isSecret {
+			fmt.Println("  " + key + "=" + manifest.MaskedSecretValue + output.AnsiFaint + " (secret)" + output.AnsiReset)
+		} else {
+			fmt.Println("  " + key + "=" + variables[key])
+		}
+	}
+
+	return nil
 }
 
 func followLogs(ctx context.Context, apps appService, orgSlug, appSlug string) error {
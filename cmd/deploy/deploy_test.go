@@ -3,6 +3,8 @@ package deploy
 import (
 	"context"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -10,11 +12,13 @@ import (
 	"numerous.com/cli/internal/app"
 	"numerous.com/cli/internal/appident"
 	"numerous.com/cli/internal/config"
+	"numerous.com/cli/internal/manifest"
 	"numerous.com/cli/internal/output"
 	"numerous.com/cli/internal/test"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDeploy(t *testing.T) {
@@ -28,7 +32,7 @@ func TestDeploy(t *testing.T) {
 	mockVersionDeployWithDeployEventsRun := func(apps *mockAppService, deployEventsRun func(mock.Arguments)) {
 		apps.On("CreateVersion", mock.Anything, mock.Anything).Return(app.CreateAppVersionOutput{AppVersionID: appVersionID}, nil)
 		apps.On("AppVersionUploadURL", mock.Anything, mock.Anything).Return(app.AppVersionUploadURLOutput{UploadURL: uploadURL}, nil)
-		apps.On("UploadAppSource", mock.Anything, mock.Anything).Return(nil)
+		apps.On("UploadAppSource", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 		apps.On("DeployApp", mock.Anything, mock.Anything).Return(app.DeployAppOutput{DeploymentVersionID: deployVersionID}, nil)
 		apps.On("DeployEvents", mock.Anything, mock.Anything).Run(deployEventsRun).Return(nil)
 	}
@@ -81,6 +85,22 @@ func TestDeploy(t *testing.T) {
 		apps.AssertNotCalled(t, "Create")
 	})
 
+	t.Run("given upload error then it removes the app archive", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		apps := &mockAppService{}
+		apps.On("ReadApp", mock.Anything, mock.Anything).Return(app.ReadAppOutput{AppID: appID}, nil)
+		apps.On("CreateVersion", mock.Anything, mock.Anything).Return(app.CreateAppVersionOutput{AppVersionID: appVersionID}, nil)
+		apps.On("AppVersionUploadURL", mock.Anything, mock.Anything).Return(app.AppVersionUploadURLOutput{UploadURL: uploadURL}, nil)
+		apps.On("UploadAppSource", mock.Anything, mock.Anything, mock.Anything).Return(context.Canceled)
+
+		input := deployInput{appDir: appDir, orgSlug: slug, appSlug: appSlug}
+		err := deploy(context.TODO(), apps, input)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NoFileExists(t, filepath.Join(appDir, appArchiveFileName))
+	})
+
 	t.Run("given dir without numerous.toml then it returns error", func(t *testing.T) {
 		dir := t.TempDir()
 
@@ -181,6 +201,119 @@ func TestDeploy(t *testing.T) {
 		}
 	})
 
+	t.Run("given environment it uses environment deployment, size and env file", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		appendToManifest(t, appDir, "\n[deploy.staging]\norganization = \"staging-org\"\napp = \"staging-app\"\nsize = \"large\"\nenv_file = \".env.staging\"\n")
+		test.WriteFile(t, filepath.Join(appDir, ".env.staging"), []byte("SECRET=staging-value\n"))
+		apps := mockAppNotExists()
+
+		err := deploy(context.TODO(), apps, deployInput{appDir: appDir, env: "staging"})
+
+		if assert.NoError(t, err) {
+			size := "large"
+			apps.AssertCalled(t, "Create", mock.Anything, app.CreateAppInput{OrganizationSlug: "staging-org", AppSlug: "staging-app", DisplayName: "Streamlit App With Deploy"})
+			apps.AssertCalled(t, "CreateVersion", mock.Anything, app.CreateAppVersionInput{AppID: appID, Size: &size})
+			apps.AssertCalled(t, "DeployApp", mock.Anything, app.DeployAppInput{AppVersionID: appVersionID, Secrets: map[string]string{"SECRET": "staging-value"}})
+		}
+	})
+
+	t.Run("given environment and arguments then arguments override environment deployment", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		appendToManifest(t, appDir, "\n[deploy.staging]\norganization = \"staging-org\"\napp = \"staging-app\"\n")
+		apps := mockAppNotExists()
+
+		err := deploy(context.TODO(), apps, deployInput{appDir: appDir, env: "staging", orgSlug: "organization-slug-in-argument"})
+
+		if assert.NoError(t, err) {
+			apps.AssertCalled(t, "Create", mock.Anything, app.CreateAppInput{OrganizationSlug: "organization-slug-in-argument", AppSlug: "staging-app", DisplayName: "Streamlit App With Deploy"})
+		}
+	})
+
+	t.Run("given unknown environment then it returns error", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		apps := mockAppNotExists()
+
+		err := deploy(context.TODO(), apps, deployInput{appDir: appDir, env: "unknown"})
+
+		assert.ErrorIs(t, err, manifest.ErrDeployEnvironmentNotFound)
+		apps.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("given environment with missing env file then it returns error", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		appendToManifest(t, appDir, "\n[deploy.staging]\nenv_file = \".env.staging\"\n")
+		apps := mockAppNotExists()
+
+		err := deploy(context.TODO(), apps, deployInput{appDir: appDir, env: "staging"})
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+		apps.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("given manifest env table it deploys env variables merged with secrets", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		writeManifestWithEnv(t, appDir, "LOG_LEVEL = \"debug\"\nFEATURE_X = \"1\"\n")
+		test.WriteFile(t, filepath.Join(appDir, ".env"), []byte("SECRET=secret-value\n"))
+		apps := mockAppExists()
+
+		err := deploy(context.TODO(), apps, deployInput{appDir: appDir})
+
+		if assert.NoError(t, err) {
+			expected := map[string]string{"LOG_LEVEL": "debug", "FEATURE_X": "1", "SECRET": "secret-value"}
+			apps.AssertCalled(t, "DeployApp", mock.Anything, app.DeployAppInput{AppVersionID: appVersionID, Secrets: expected})
+		}
+	})
+
+	t.Run("given env variable also defined as secret it reports duplicate and uses secret", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		writeManifestWithEnv(t, appDir, "LOG_LEVEL = \"debug\"\nSECRET = \"manifest-value\"\n")
+		test.WriteFile(t, filepath.Join(appDir, ".env"), []byte("SECRET=secret-value\n"))
+		apps := mockAppExists()
+
+		stdoutR, err := test.RunEWithPatchedStdout(t, func() error {
+			return deploy(context.TODO(), apps, deployInput{appDir: appDir})
+		})
+
+		if assert.NoError(t, err) {
+			expected := map[string]string{"LOG_LEVEL": "debug", "SECRET": "secret-value"}
+			apps.AssertCalled(t, "DeployApp", mock.Anything, app.DeployAppInput{AppVersionID: appVersionID, Secrets: expected})
+		}
+		output, _ := io.ReadAll(stdoutR)
+		assert.Contains(t, cleanNonASCIIAndANSI(string(output)), "The secret values are used for: SECRET\n")
+	})
+
+	t.Run("given dry run it prints env variables with masked secrets and does not deploy", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		writeManifestWithEnv(t, appDir, "LOG_LEVEL = \"debug\"\n")
+		test.WriteFile(t, filepath.Join(appDir, ".env"), []byte("SECRET=secret-value\n"))
+		apps := &mockAppService{}
+
+		stdoutR, err := test.RunEWithPatchedStdout(t, func() error {
+			return deploy(context.TODO(), apps, deployInput{appDir: appDir, dryRun: true})
+		})
+
+		assert.NoError(t, err)
+		output, _ := io.ReadAll(stdoutR)
+		actual := cleanNonASCIIAndANSI(string(output))
+		expected := strings.Join([]string{
+			"App:  organization-slug-in-manifest/app-slug-in-manifest",
+			"Size: default",
+			"Environment variables:",
+			"  LOG_LEVEL=debug",
+			"  SECRET=******** (secret)",
+		}, "\n")
+		assert.Contains(t, actual, expected)
+		assert.NotContains(t, actual, "secret-value")
+		apps.AssertExpectations(t)
+	})
+
 	t.Run("given message and version arguments it creates app version with arguments", func(t *testing.T) {
 		appDir := t.TempDir()
 		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
@@ -311,9 +444,35 @@ func cleanNonASCIIAndANSI(s string) string {
 		}
 	}
 
-	for _, code := range []string{output.AnsiRed, output.AnsiReset, output.AnsiGreen, output.AnsiFaint, output.AnsiCyanBold} {
+	for _, code := range []string{output.AnsiRed, output.AnsiReset, output.AnsiGreen, output.AnsiFaint, output.AnsiCyanBold, output.AnsiYellow} {
 		cleaned = strings.ReplaceAll(cleaned, code, "")
 	}
 
 	return cleaned
 }
+
+func appendToManifest(t *testing.T, appDir string, content string) {
+	t.Helper()
+
+	f, err := os.OpenFile(filepath.Join(appDir, manifest.ManifestFileName), os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.WriteString(content)
+	require.NoError(t, err)
+}
+
+// Migrates the manifest in the app directory to the current format, and adds
+// an env table with the given content.
+func writeManifestWithEnv(t *testing.T, appDir string, env string) {
+	t.Helper()
+
+	manifestPath := filepath.Join(appDir, manifest.ManifestFileName)
+	content, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+
+	migrated, _, err := manifest.Migrate(string(content))
+	require.NoError(t, err)
+
+	test.WriteFile(t, manifestPath, []byte(migrated+"\n[env]\n"+env))
+}
@@ -52,8 +52,8 @@ func (m *mockAppService) CreateVersion(ctx context.Context, input app.CreateAppV
 }
 
 // UploadAppSource implements AppService.
-func (m *mockAppService) UploadAppSource(uploadURL string, archive app.UploadArchive) error {
-	args := m.Called(uploadURL, archive)
+func (m *mockAppService) UploadAppSource(ctx context.Context, uploadURL string, archive app.UploadArchive) error {
+	args := m.Called(ctx, uploadURL, archive)
 	return args.Error(0)
 }
 
@@ -0,0 +1,158 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"numerous.com/cli/cmd/logs"
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/manifest"
+	"numerous.com/cli/internal/output"
+	"numerous.com/cli/internal/watch"
+)
+
+const (
+	watchPollInterval   = 500 * time.Millisecond
+	watchDebounce       = time.Second
+	maxListedWatchFiles = 5
+)
+
+type deployResult struct {
+	orgSlug string
+	appSlug string
+	err     error
+}
+
+// Deploys the app, and redeploys it whenever files in the app source change,
+// where a deployment in progress is cancelled when new changes are detected.
+// Logs are followed from the first successful deployment, and keep streaming
+// between deployments. Each deployment uses a new service from newApps, since
+// the subscriptions of a service cannot run concurrently. Logs are followed
+// with a service from newLogsApps, which resubscribes if the connection is
+// lost.
+func deployWatch(ctx context.Context, newApps func() appService, newLogsApps func(app.ReconnectOptions) appService, input deployInput) error {
+	srcPath := input.appDir
+	if input.projectDir != "" {
+		srcPath = input.projectDir
+	}
+
+	exclude, err := loadWatchExclude(input.appDir)
+	if err != nil {
+		output.PrintErrorAppNotInitialized(input.appDir)
+		output.PrintManifestTOMLError(err)
+
+		return err
+	}
+
+	watcher := sourceWatcher{dir: srcPath}
+	defer watcher.stop()
+	if err := watcher.watch(ctx, exclude); err != nil {
+		output.PrintErrorDetails("Error watching the app source for changes", err)
+		return err
+	}
+
+	following := false
+	for {
+		deployCtx, cancel := context.WithCancel(ctx)
+		done := make(chan deployResult, 1)
+		go func() {
+			orgSlug, appSlug, err := deployVersion(deployCtx, newApps(), input)
+			done <- deployResult{orgSlug: orgSlug, appSlug: appSlug, err: err}
+		}()
+
+		var changed []string
+		var ok bool
+		select {
+		case changed, ok = <-watcher.changes:
+			cancel()
+			<-done
+		case result := <-done:
+			cancel()
+			if result.err == nil && !following {
+				following = true
+				output.Notify("Following logs of %s/%s:", "", result.orgSlug, result.appSlug)
+				go followLogs(ctx, newLogsApps(logs.ReconnectOptions(logs.PrintConnectionLost)), result.orgSlug, result.appSlug) // nolint:errcheck
+			}
+
+			output.Notify("Watching for changes", "The app is redeployed when files in %q change.", srcPath)
+			changed, ok = <-watcher.changes
+		}
+
+		if !ok {
+			return nil
+		}
+
+		printChangedFiles(changed)
+
+		// the manifest may have changed, in which case the deployment reports
+		// any errors reading it
+		if exclude, err := loadWatchExclude(input.appDir); err == nil {
+			if err := watcher.watch(ctx, exclude); err != nil {
+				output.PrintErrorDetails("Error watching the app source for changes", err)
+				return err
+			}
+		}
+	}
+}
+
+// loadWatchExclude reads the patterns of the files, which are not watched,
+// from the manifest in the app directory.
+func loadWatchExclude(appDir string) ([]string, error) {
+	m, err := manifest.Load(filepath.Join(appDir, manifest.ManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(slices.Clone(m.Exclude), appArchiveFileName), nil
+}
+
+// sourceWatcher watches the app source for changes, and restarts watching
+// when the excluded files change.
+type sourceWatcher struct {
+	dir     string
+	exclude []string
+	changes <-chan []string
+	cancel  context.CancelFunc
+}
+
+func (w *sourceWatcher) watch(ctx context.Context, exclude []string) error {
+	if w.changes != nil && slices.Equal(w.exclude, exclude) {
+		return nil
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	changes, err := watch.Changes(watchCtx, watch.Options{Dir: w.dir, Exclude: exclude, Interval: watchPollInterval, Debounce: watchDebounce})
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	w.stop()
+	w.exclude, w.changes, w.cancel = exclude, changes, cancel
+
+	return nil
+}
+
+func (w *sourceWatcher) stop() {
+	if w.cancel != nil {
+		w.cancel()
+	}
+}
+
+func printChangedFiles(changed []string) {
+	listed := changed
+	if len(listed) > maxListedWatchFiles {
+		listed = listed[:maxListedWatchFiles]
+	}
+
+	body := "  " + strings.Join(listed, "\n  ")
+	if len(changed) > len(listed) {
+		body += fmt.Sprintf("\n  ... and %d more", len(changed)-len(listed))
+	}
+
+	output.Notify("Detected changes, redeploying the app", "%s", body)
+}
@@ -0,0 +1,132 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployWatch(t *testing.T) {
+	const timeout = 5 * time.Second
+
+	mockApps := func(deployEventsRun func(mock.Arguments)) (*mockAppService, chan struct{}) {
+		deployed := make(chan struct{}, 10)
+		apps := &mockAppService{}
+		apps.On("ReadApp", mock.Anything, mock.Anything).Return(app.ReadAppOutput{AppID: "app-id"}, nil)
+		apps.On("CreateVersion", mock.Anything, mock.Anything).Return(app.CreateAppVersionOutput{AppVersionID: "app-version-id"}, nil)
+		apps.On("AppVersionUploadURL", mock.Anything, mock.Anything).Return(app.AppVersionUploadURLOutput{UploadURL: "https://upload/url"}, nil)
+		apps.On("UploadAppSource", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		apps.On("DeployApp", mock.Anything, mock.Anything).Run(func(mock.Arguments) { deployed <- struct{}{} }).Return(app.DeployAppOutput{DeploymentVersionID: "deploy-version-id"}, nil)
+		apps.On("DeployEvents", mock.Anything, mock.Anything).Run(deployEventsRun).Return(nil)
+		apps.On("AppDeployLogs", mock.Anything, mock.Anything, true).Return(make(chan app.AppDeployLogEntry), nil)
+
+		return apps, deployed
+	}
+
+	waitFor := func(t *testing.T, ch chan struct{}, msg string) {
+		t.Helper()
+
+		select {
+		case <-ch:
+		case <-time.After(timeout):
+			t.Fatal(msg)
+		}
+	}
+
+	startWatch := func(t *testing.T, apps *mockAppService, appDir string) (context.CancelFunc, chan error) {
+		t.Helper()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		done := make(chan error, 1)
+		go func() {
+			input := deployInput{appDir: appDir, orgSlug: "organization-slug", appSlug: "app-slug"}
+			done <- deployWatch(ctx, func() appService { return apps }, func(app.ReconnectOptions) appService { return apps }, input)
+		}()
+
+		return cancel, done
+	}
+
+	t.Run("redeploys when files change, and returns when context is done", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		apps, deployed := mockApps(nil)
+
+		cancel, done := startWatch(t, apps, appDir)
+		waitFor(t, deployed, "expected initial deployment")
+
+		test.WriteFile(t, filepath.Join(appDir, "app.py"), []byte("print('changed')"))
+		waitFor(t, deployed, "expected redeployment after change")
+
+		cancel()
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(timeout):
+			t.Fatal("expected watch to return")
+		}
+		apps.AssertCalled(t, "AppDeployLogs", mock.Anything, mock.Anything, true)
+	})
+
+	t.Run("cancels deployment in progress when files change", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		cancelled := make(chan struct{}, 1)
+		first := true
+		apps, deployed := mockApps(func(args mock.Arguments) {
+			if !first {
+				return
+			}
+			first = false
+
+			<-args.Get(0).(context.Context).Done()
+			cancelled <- struct{}{}
+		})
+
+		cancel, done := startWatch(t, apps, appDir)
+		waitFor(t, deployed, "expected initial deployment")
+
+		test.WriteFile(t, filepath.Join(appDir, "app.py"), []byte("print('changed')"))
+		waitFor(t, cancelled, "expected deployment in progress to be cancelled")
+		waitFor(t, deployed, "expected redeployment after change")
+
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	t.Run("reloads excluded files when the manifest changes", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		apps, deployed := mockApps(nil)
+
+		cancel, done := startWatch(t, apps, appDir)
+		waitFor(t, deployed, "expected initial deployment")
+
+		manifestPath := filepath.Join(appDir, "numerous.toml")
+		data, err := os.ReadFile(manifestPath)
+		require.NoError(t, err)
+		test.WriteFile(t, manifestPath, bytes.Replace(data, []byte(`".git"]`), []byte(`".git", "ignored.py"]`), 1))
+		waitFor(t, deployed, "expected redeployment after manifest change")
+
+		test.WriteFile(t, filepath.Join(appDir, "ignored.py"), []byte("print('ignored')"))
+		select {
+		case <-deployed:
+			t.Fatal("expected no redeployment after change to excluded file")
+		case <-time.After(3 * watchDebounce):
+		}
+
+		cancel()
+		require.NoError(t, <-done)
+	})
+}
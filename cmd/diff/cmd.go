@@ -0,0 +1,91 @@
+package diff
+
+import (
+	"fmt"
+	"net/http"
+
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/cmd/errorhandling"
+	"numerous.com/cli/cmd/group"
+	"numerous.com/cli/cmd/usage"
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/gql"
+
+	"github.com/spf13/cobra"
+)
+
+const longFormat string = `Compare the local app source with the source of a deployed app version.
+
+Downloads the source archive of the currently deployed app version, or the app
+version given with --app-version-id, and compares it with the files in the
+[app directory]. Files matching the exclude patterns of the app manifest are
+ignored in the app directory, just like when deploying.
+
+Files are reported as added if they only exist locally, removed if they only
+exist in the deployed version, and modified if their contents differ.
+
+%s
+
+%s
+`
+
+const cmdActionText string = "to compare with"
+
+var long string = fmt.Sprintf(longFormat, usage.AppIdentifier(cmdActionText), usage.AppDirectoryArgument)
+
+const example string = `To list the files that differ between the current directory and the deployed
+version of the app:
+
+    numerous diff
+
+To show the changes of text files as unified diffs:
+
+    numerous diff --patch
+
+To use in a script that should fail if the local source is not deployed:
+
+    numerous diff --exit-code --organization "organization-slug-a2ecf59b" --app "my-app" my_project/my_app
+`
+
+var Cmd = &cobra.Command{
+	Use:     "diff [app directory]",
+	RunE:    run,
+	Short:   "Compare local app source with the deployed version",
+	Long:    long,
+	Example: example,
+	GroupID: group.AppCommandsGroupID,
+	Args:    args.OptionalAppDir(&cmdArgs.appDir),
+}
+
+var cmdArgs struct {
+	appIdent     args.AppIdentifierArg
+	appDir       string
+	appVersionID string
+	patch        bool
+	exitCode     bool
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	service := app.New(gql.NewClient(), nil, http.DefaultClient)
+	input := diffInput{
+		appDir:       cmdArgs.appDir,
+		appSlug:      cmdArgs.appIdent.AppSlug,
+		orgSlug:      cmdArgs.appIdent.OrganizationSlug,
+		env:          cmdArgs.appIdent.Environment,
+		appVersionID: cmdArgs.appVersionID,
+		patch:        cmdArgs.patch,
+		exitCode:     cmdArgs.exitCode,
+	}
+
+	err := diff(cmd.Context(), http.DefaultClient, service, input)
+
+	return errorhandling.ErrorAlreadyPrinted(err)
+}
+
+func init() {
+	flags := Cmd.Flags()
+	cmdArgs.appIdent.AddAppIdentifierFlags(flags, cmdActionText)
+	flags.StringVar(&cmdArgs.appVersionID, "app-version-id", "", "The ID of the app version to compare with, instead of the currently deployed version.")
+	flags.BoolVarP(&cmdArgs.patch, "patch", "p", false, "Show unified diffs of modified text files.")
+	flags.BoolVar(&cmdArgs.exitCode, "exit-code", false, "Exit with a non-zero status if there are differences.")
+}
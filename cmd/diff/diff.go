@@ -0,0 +1,294 @@
+package diff
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/appident"
+	"numerous.com/cli/internal/archive"
+	"numerous.com/cli/internal/manifest"
+	"numerous.com/cli/internal/output"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+var (
+	errDownloadFailed   = errors.New("download failed")
+	errDifferencesFound = errors.New("differences found")
+)
+
+const patchContextLines int = 3
+
+type diffInput struct {
+	appDir       string
+	appSlug      string
+	orgSlug      string
+	env          string
+	appVersionID string
+	patch        bool
+	exitCode     bool
+}
+
+type appService interface {
+	CurrentAppVersion(context.Context, app.CurrentAppVersionInput) (app.CurrentAppVersionOutput, error)
+	AppVersionDownloadURL(context.Context, app.AppVersionDownloadURLInput) (app.AppVersionDownloadURLOutput, error)
+}
+
+type fileChange string
+
+const (
+	fileAdded    fileChange = "added"
+	fileRemoved  fileChange = "removed"
+	fileModified fileChange = "modified"
+)
+
+type fileDiff struct {
+	path     string
+	change   fileChange
+	deployed []byte
+	local    []byte
+}
+
+func diff(ctx context.Context, client *http.Client, service appService, input diffInput) error {
+	m, err := manifest.Load(filepath.Join(input.appDir, manifest.ManifestFileName))
+	if err != nil {
+		output.PrintErrorAppNotInitialized(input.appDir)
+		output.PrintManifestTOMLError(err)
+
+		return err
+	}
+
+	ai, err := appident.GetAppIdentifier(input.appDir, m, input.env, input.orgSlug, input.appSlug)
+	if err != nil {
+		appident.PrintGetAppIdentifierError(err, input.appDir, ai)
+		return err
+	}
+
+	deployed, err := downloadAppVersion(ctx, client, service, ai, input.appVersionID)
+	if err != nil {
+		return err
+	}
+
+	t := output.StartTask("Comparing with local app source")
+	diffs, err := compare(input.appDir, m.Exclude, deployed)
+	if err != nil {
+		t.Error()
+		output.PrintErrorDetails("Error reading local app source", err)
+
+		return err
+	}
+	t.Done()
+
+	fmt.Println()
+	printDiffs(diffs, input.patch)
+
+	if input.exitCode && len(diffs) > 0 {
+		return errDifferencesFound
+	}
+
+	return nil
+}
+
+func downloadAppVersion(ctx context.Context, client *http.Client, service appService, ai appident.AppIdentifier, appVersionID string) (map[string][]byte, error) {
+	t := output.StartTask(fmt.Sprintf("Downloading app source for %s/%s", ai.OrganizationSlug, ai.AppSlug))
+
+	if appVersionID == "" {
+		appVersionOutput, err := service.CurrentAppVersion(ctx, app.CurrentAppVersionInput(ai))
+		if err != nil {
+			t.Error()
+			if errors.Is(err, app.ErrNotDeployed) {
+				output.PrintError("App not deployed", "The app \"%s/%s\" has no deployed version to compare with.", ai.OrganizationSlug, ai.AppSlug)
+			} else {
+				app.PrintAppError(err, ai)
+			}
+
+			return nil, err
+		}
+		appVersionID = appVersionOutput.AppVersionID
+	}
+
+	urlOutput, err := service.AppVersionDownloadURL(ctx, app.AppVersionDownloadURLInput{AppVersionID: appVersionID})
+	if err != nil {
+		t.Error()
+		if errors.Is(err, app.ErrAccessDenied) {
+			app.PrintErrorAccessDenied(ai)
+		} else {
+			output.PrintErrorDetails("Error getting app download URL", err)
+		}
+
+		return nil, err
+	}
+
+	files, err := downloadArchive(client, urlOutput.DownloadURL)
+	if err != nil {
+		t.Error()
+		output.PrintErrorDetails("Error downloading app source", err)
+
+		return nil, err
+	}
+	t.Done()
+
+	return files, nil
+}
+
+func downloadArchive(client *http.Client, url string) (map[string][]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errDownloadFailed
+	}
+
+	return archive.TarReadFiles(resp.Body)
+}
+
+// Compares the files in appDir, which are not excluded, with the deployed
+// files, and returns the differences sorted by path.
+func compare(appDir string, exclude []string, deployed map[string][]byte) ([]fileDiff, error) {
+	localPaths, err := archive.ListFiles(appDir, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := []fileDiff{}
+	seen := make(map[string]bool, len(localPaths))
+	for _, p := range localPaths {
+		seen[p] = true
+
+		local, err := os.ReadFile(filepath.Join(appDir, filepath.FromSlash(p)))
+		if err != nil {
+			return nil, err
+		}
+
+		deployedData, exists := deployed[p]
+		switch {
+		case !exists:
+			diffs = append(diffs, fileDiff{path: p, change: fileAdded, local: local})
+		case !bytes.Equal(deployedData, local):
+			diffs = append(diffs, fileDiff{path: p, change: fileModified, deployed: deployedData, local: local})
+		}
+	}
+
+	for p, deployedData := range deployed {
+		if !seen[p] {
+			diffs = append(diffs, fileDiff{path: p, change: fileRemoved, deployed: deployedData})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].path < diffs[j].path })
+
+	return diffs, nil
+}
+
+func printDiffs(diffs []fileDiff, patch bool) {
+	if len(diffs) == 0 {
+		output.PrintlnOK("The local app source is identical to the deployed version")
+		return
+	}
+
+	fmt.Printf("Found %d differences between the local app source and the deployed version:\n", len(diffs))
+	for _, d := range diffs {
+		fmt.Printf("  %s%-9s%s %s\n", changeColor(d.change), string(d.change)+":", output.AnsiReset, d.path)
+	}
+
+	if !patch {
+		return
+	}
+
+	for _, d := range diffs {
+		fmt.Println()
+		printPatch(d)
+	}
+}
+
+func changeColor(change fileChange) string {
+	switch change {
+	case fileAdded:
+		return output.AnsiGreen
+	case fileRemoved:
+		return output.AnsiRed
+	default:
+		return output.AnsiYellow
+	}
+}
+
+func printPatch(d fileDiff) {
+	if !isText(d.deployed) || !isText(d.local) {
+		fmt.Printf("Binary file %s differs\n", d.path)
+		return
+	}
+
+	fromFile := "deployed/" + d.path
+	if d.change == fileAdded {
+		fromFile = "/dev/null"
+	}
+
+	toFile := "local/" + d.path
+	if d.change == fileRemoved {
+		toFile = "/dev/null"
+	}
+
+	patch, err := unifiedDiff(d.deployed, d.local, fromFile, toFile)
+	if err != nil {
+		output.PrintErrorDetails("Error creating diff of %q", err, d.path)
+		return
+	}
+
+	for _, ln := range strings.SplitAfter(patch, "\n") {
+		switch {
+		case ln == "":
+			continue
+		case strings.HasPrefix(ln, "---"), strings.HasPrefix(ln, "+++"):
+			fmt.Print(ln)
+		case strings.HasPrefix(ln, "@@"):
+			fmt.Print(output.AnsiFaint + strings.TrimSuffix(ln, "\n") + output.AnsiReset + "\n")
+		case strings.HasPrefix(ln, "+"):
+			fmt.Print(output.AnsiGreen + strings.TrimSuffix(ln, "\n") + output.AnsiReset + "\n")
+		case strings.HasPrefix(ln, "-"):
+			fmt.Print(output.AnsiRed + strings.TrimSuffix(ln, "\n") + output.AnsiReset + "\n")
+		default:
+			fmt.Print(ln)
+		}
+	}
+}
+
+func unifiedDiff(from, to []byte, fromFile, toFile string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(from),
+		B:        splitLines(to),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  patchContextLines,
+	})
+}
+
+// Splits the data into lines, ensuring each line ends with a newline, so that
+// a missing newline at the end of a file does not garble the diff.
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+
+	lines := difflib.SplitLines(string(data))
+	if last := lines[len(lines)-1]; last == "\n" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+func isText(data []byte) bool {
+	return utf8.Valid(data) && !bytes.ContainsRune(data, 0)
+}
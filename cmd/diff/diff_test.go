@@ -0,0 +1,193 @@
+package diff
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/test"
+)
+
+func TestDiff(t *testing.T) {
+	const appSlug = "app-slug"
+	const orgSlug = "org-slug"
+	const appVersionID = "app-version-id"
+
+	mockApps := func(downloadURL string) *mockAppService {
+		apps := &mockAppService{}
+		apps.On("CurrentAppVersion", mock.Anything, app.CurrentAppVersionInput{OrganizationSlug: orgSlug, AppSlug: appSlug}).Return(app.CurrentAppVersionOutput{AppVersionID: appVersionID}, nil)
+		apps.On("AppVersionDownloadURL", mock.Anything, app.AppVersionDownloadURLInput{AppVersionID: appVersionID}).Return(app.AppVersionDownloadURLOutput{DownloadURL: downloadURL}, nil)
+
+		return apps
+	}
+
+	t.Run("given identical app source it reports no differences", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		client, downloadURL := newTestHTTPClientWithDownload(t, "streamlit_app.tar")
+		apps := mockApps(downloadURL)
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return diff(context.TODO(), client, apps, diffInput{appDir: appDir, appSlug: appSlug, orgSlug: orgSlug, exitCode: true})
+		})
+
+		assert.NoError(t, err)
+		assert.Contains(t, readAll(t, stdout), "identical to the deployed version")
+		apps.AssertExpectations(t)
+	})
+
+	t.Run("given changed app source and exit code flag it returns error", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		test.WriteFile(t, filepath.Join(appDir, "new_file.py"), []byte("print('new')\n"))
+		client, downloadURL := newTestHTTPClientWithDownload(t, "streamlit_app.tar")
+		apps := mockApps(downloadURL)
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return diff(context.TODO(), client, apps, diffInput{appDir: appDir, appSlug: appSlug, orgSlug: orgSlug, exitCode: true})
+		})
+
+		assert.ErrorIs(t, err, errDifferencesFound)
+		assert.Contains(t, readAll(t, stdout), "new_file.py")
+	})
+
+	t.Run("given app version id it does not read current app version", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		client, downloadURL := newTestHTTPClientWithDownload(t, "streamlit_app.tar")
+		apps := &mockAppService{}
+		apps.On("AppVersionDownloadURL", mock.Anything, app.AppVersionDownloadURLInput{AppVersionID: "other-app-version-id"}).Return(app.AppVersionDownloadURLOutput{DownloadURL: downloadURL}, nil)
+
+		_, err := test.RunEWithPatchedStdout(t, func() error {
+			return diff(context.TODO(), client, apps, diffInput{appDir: appDir, appSlug: appSlug, orgSlug: orgSlug, appVersionID: "other-app-version-id"})
+		})
+
+		assert.NoError(t, err)
+		apps.AssertNotCalled(t, "CurrentAppVersion")
+		apps.AssertExpectations(t)
+	})
+
+	t.Run("given patch flag it prints unified diff of modified files", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		test.WriteFile(t, filepath.Join(appDir, "requirements.txt"), []byte("streamlit\npandas\n"))
+		client, downloadURL := newTestHTTPClientWithDownload(t, "streamlit_app.tar")
+		apps := mockApps(downloadURL)
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return diff(context.TODO(), client, apps, diffInput{appDir: appDir, appSlug: appSlug, orgSlug: orgSlug, patch: true})
+		})
+
+		assert.NoError(t, err)
+		out := readAll(t, stdout)
+		assert.Contains(t, out, "--- deployed/requirements.txt\n+++ local/requirements.txt\n")
+		assert.Contains(t, out, "+pandas")
+	})
+
+	t.Run("given app dir without manifest it returns error", func(t *testing.T) {
+		appDir := t.TempDir()
+
+		_, err := test.RunEWithPatchedStdout(t, func() error {
+			return diff(context.TODO(), nil, nil, diffInput{appDir: appDir, appSlug: appSlug, orgSlug: orgSlug})
+		})
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("returns error if download http request is not ok", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		apps := mockApps(server.URL + "/some-file")
+
+		_, err := test.RunEWithPatchedStdout(t, func() error {
+			return diff(context.TODO(), server.Client(), apps, diffInput{appDir: appDir, appSlug: appSlug, orgSlug: orgSlug})
+		})
+
+		assert.ErrorIs(t, err, errDownloadFailed)
+	})
+}
+
+func TestCompare(t *testing.T) {
+	t.Run("reports added, removed and modified files", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.WriteFile(t, filepath.Join(appDir, "unchanged.py"), []byte("unchanged"))
+		test.WriteFile(t, filepath.Join(appDir, "modified.py"), []byte("local"))
+		test.WriteFile(t, filepath.Join(appDir, "added.py"), []byte("added"))
+		deployed := map[string][]byte{
+			"unchanged.py": []byte("unchanged"),
+			"modified.py":  []byte("deployed"),
+			"removed.py":   []byte("removed"),
+		}
+
+		actual, err := compare(appDir, nil, deployed)
+
+		assert.NoError(t, err)
+		expected := []fileDiff{
+			{path: "added.py", change: fileAdded, local: []byte("added")},
+			{path: "modified.py", change: fileModified, deployed: []byte("deployed"), local: []byte("local")},
+			{path: "removed.py", change: fileRemoved, deployed: []byte("removed")},
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("ignores excluded local files", func(t *testing.T) {
+		appDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(appDir, "venv"), 0o755))
+		test.WriteFile(t, filepath.Join(appDir, "venv", "lib.py"), []byte("excluded"))
+		test.WriteFile(t, filepath.Join(appDir, ".env"), []byte("SECRET=value"))
+
+		actual, err := compare(appDir, []string{"venv", ".env"}, map[string][]byte{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Run("handles missing trailing newline", func(t *testing.T) {
+		actual, err := unifiedDiff([]byte("a\nb"), []byte("a\nc\n"), "from", "to")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "--- from\n+++ to\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n", actual)
+	})
+}
+
+func newTestHTTPClientWithDownload(t *testing.T, testdataFilePath string) (client *http.Client, url string) {
+	t.Helper()
+
+	contentFilePath := filepath.Join("../../testdata/", testdataFilePath)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+testdataFilePath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		data, _ := os.ReadFile(contentFilePath)
+
+		w.Write(data) // nolint:errcheck
+	}))
+	t.Cleanup(s.Close)
+
+	return s.Client(), s.URL + "/" + testdataFilePath
+}
+
+func readAll(t *testing.T, r io.Reader) string {
+	t.Helper()
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return string(data)
+}
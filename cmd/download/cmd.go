@@ -1,6 +1,7 @@
 package download
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/spf13/cobra"
@@ -9,6 +10,7 @@ import (
 	"numerous.com/cli/cmd/group"
 	"numerous.com/cli/internal/app"
 	"numerous.com/cli/internal/gql"
+	"numerous.com/cli/internal/output"
 )
 
 var long string = `Download app sources of the specified app.
@@ -19,9 +21,39 @@ Downloads the sources of an app into the specified [app directory]. If
 If an app already exists in [app directory], and a default deployment is
 configured in numerous.toml, then that will be used to identify the app to
 download the source from. In this case the app source code will be downloaded
-directly on top of the local source, so be careful!
+on top of the local source, so be careful!
+
+The app source is first downloaded and extracted into a staging directory next
+to [app directory], and only then moved into place. If moving the files fails,
+the changes are rolled back, so the local source is never left half-extracted.
 
 A confirmation prompt will be shown if file overwrites are a possibility.
+
+With --merge, no confirmation is asked. Local files that differ from the
+downloaded files are reported as conflicts, and handled according to
+--conflict:
+
+  keep:      keep the local version (the default)
+  remote:    keep the local version, and write the downloaded version next to it
+             with a ".remote" suffix
+  overwrite: overwrite the local version with the downloaded version
+
+Use --dry-run to list the files that would be created or changed without
+changing anything.
+`
+
+const example = `To download an app into the folder "my-app":
+
+    numerous download --organization "organization-slug-a2ecf59b" --app "my-app" my-app
+
+To see what would change when downloading into an existing app folder:
+
+    numerous download --dry-run --merge my-app
+
+To merge the downloaded app source, writing conflicting files with a ".remote"
+suffix:
+
+    numerous download --merge --conflict remote my-app
 `
 
 var Cmd = &cobra.Command{
@@ -29,6 +61,7 @@ var Cmd = &cobra.Command{
 	RunE:    run,
 	Short:   "Download app sources",
 	Long:    long,
+	Example: example,
 	GroupID: group.AppCommandsGroupID,
 	Args:    args.OptionalAppDir(&cmdArgs.appDir),
 }
@@ -36,14 +69,30 @@ var Cmd = &cobra.Command{
 var cmdArgs struct {
 	appIdent args.AppIdentifierArg
 	appDir   string
+	merge    bool
+	conflict ConflictPolicy
+	dryRun   bool
 }
 
+var ErrConflictWithoutMerge = errors.New("--conflict can only be used with --merge")
+
 func run(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("conflict") && !cmdArgs.merge {
+		output.PrintError("Cannot use --conflict without --merge", "Conflicting files are only handled according to --conflict when merging with --merge.")
+		cmd.Usage() // nolint:errcheck
+
+		return errorhandling.ErrorAlreadyPrinted(ErrConflictWithoutMerge)
+	}
+
 	service := app.New(gql.NewClient(), nil, http.DefaultClient)
 	input := downloadInput{
 		appDir:             cmdArgs.appDir,
 		appSlug:            cmdArgs.appIdent.AppSlug,
 		orgSlug:            cmdArgs.appIdent.OrganizationSlug,
+		env:                cmdArgs.appIdent.Environment,
+		merge:              cmdArgs.merge,
+		conflict:           cmdArgs.conflict,
+		dryRun:             cmdArgs.dryRun,
 		overwriteConfirmer: surveyConfirmOverwrite,
 	}
 
@@ -55,4 +104,8 @@ func run(cmd *cobra.Command, args []string) error {
 func init() {
 	flags := Cmd.Flags()
 	cmdArgs.appIdent.AddAppIdentifierFlags(flags, "to download")
+	flags.BoolVar(&cmdArgs.merge, "merge", false, "Merge the app source into an existing app directory without overwriting conflicting local files, unless --conflict is \"overwrite\".")
+	cmdArgs.conflict = ConflictPolicyKeep
+	flags.Var(&cmdArgs.conflict, "conflict", `How to handle local files that differ from the downloaded files when merging, one of "keep", "remote", or "overwrite". Can only be used with --merge.`)
+	flags.BoolVar(&cmdArgs.dryRun, "dry-run", false, "List the files that would be created or changed, without changing anything.")
 }
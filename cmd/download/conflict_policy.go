@@ -0,0 +1,35 @@
+package download
+
+import (
+	"errors"
+	"strings"
+)
+
+type ConflictPolicy string
+
+const (
+	ConflictPolicyKeep      ConflictPolicy = "keep"
+	ConflictPolicyRemote    ConflictPolicy = "remote"
+	ConflictPolicyOverwrite ConflictPolicy = "overwrite"
+)
+
+var errInvalidConflictPolicy error = errors.New(`must be one of "keep", "remote", or "overwrite"`)
+
+func (c *ConflictPolicy) String() string {
+	return string(*c)
+}
+
+func (c *ConflictPolicy) Set(v string) error {
+	v = strings.ToLower(v)
+	switch v {
+	case "keep", "remote", "overwrite":
+		*c = ConflictPolicy(v)
+		return nil
+	default:
+		return errInvalidConflictPolicy
+	}
+}
+
+func (c *ConflictPolicy) Type() string {
+	return "Conflict policy"
+}
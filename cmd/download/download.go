@@ -21,6 +21,10 @@ type downloadInput struct {
 	appDir             string
 	appSlug            string
 	orgSlug            string
+	env                string
+	merge              bool
+	conflict           ConflictPolicy
+	dryRun             bool
 	overwriteConfirmer func(appDir string) bool
 }
 
@@ -30,7 +34,7 @@ type appService interface {
 }
 
 func download(ctx context.Context, client *http.Client, service appService, input downloadInput) error {
-	ai, err := appident.GetAppIdentifier(input.appDir, nil, input.orgSlug, input.appSlug)
+	ai, err := appident.GetAppIdentifier(input.appDir, nil, input.env, input.orgSlug, input.appSlug)
 	if errors.Is(err, appident.ErrAppNotInitialized) {
 		// ErrAppNotInitialized is only returned if both organization and app
 		// slugs are missing. We just print there error for missing the app
@@ -69,20 +73,84 @@ func download(ctx context.Context, client *http.Client, service appService, inpu
 	}
 	t.Done()
 
-	if dirExists(input.appDir) && !input.overwriteConfirmer(input.appDir) {
+	t = output.StartTask("Downloading app source")
+	stagingDir, createdDirs, err := createStagingDir(input.appDir)
+	if err != nil {
+		t.Error()
+		output.PrintErrorDetails("Error creating staging directory for app source", err)
+
+		return err
+	}
+	// created parent directories are only removed if they are empty, i.e. if
+	// the app source was not moved into them
+	defer removeDirs(createdDirs)
+	defer os.RemoveAll(stagingDir)
+
+	if err := downloadArchive(client, stagingDir, urlOutput.DownloadURL); err != nil {
+		t.Error()
+		output.PrintErrorDetails("Error downloading app source", err)
+
+		return err
+	}
+	t.Done()
+
+	if !dirExists(input.appDir) {
+		return applyNewAppDir(stagingDir, input)
+	}
+
+	return applyExistingAppDir(stagingDir, input)
+}
+
+// Moves the entire staging directory into place, when the app directory does
+// not exist.
+func applyNewAppDir(stagingDir string, input downloadInput) error {
+	if input.dryRun {
+		output.Notify("Dry run", "The app source would be downloaded into the new directory %q.", input.appDir)
+		return nil
+	}
+
+	t := output.StartTask(fmt.Sprintf("Moving app source into %q", input.appDir))
+	if err := os.Rename(stagingDir, input.appDir); err != nil {
+		t.Error()
+		output.PrintErrorDetails("Error moving app source into place", err)
+
+		return err
+	}
+	t.Done()
+
+	return nil
+}
+
+// Compares the staged app source with the existing app directory, and moves
+// the staged files into place according to the input.
+func applyExistingAppDir(stagingDir string, input downloadInput) error {
+	changes, err := planChanges(stagingDir, input.appDir, input.merge, input.conflict)
+	if err != nil {
+		output.PrintErrorDetails("Error comparing app source with %q", err, input.appDir)
+		return err
+	}
+
+	if input.dryRun {
+		printPlannedChanges(changes)
+		return nil
+	}
+
+	if !input.merge && !input.overwriteConfirmer(input.appDir) {
 		output.PrintError("Download interrupted", "")
 		return nil
 	}
 
-	t = output.StartTask(fmt.Sprintf("Downloading app source into %q", input.appDir))
-	if err := downloadArchive(client, input.appDir, urlOutput.DownloadURL); err != nil {
+	t := output.StartTask(fmt.Sprintf("Moving app source into %q", input.appDir))
+	if err := applyChanges(stagingDir, input.appDir, changes); err != nil {
 		t.Error()
-		output.PrintErrorDetails("Error downloading app source", err)
+		output.PrintErrorDetails("Error moving app source into place, no files were changed", err)
 
 		return err
 	}
 	t.Done()
 
+	printConflicts(changes)
+
 	return nil
 }
 
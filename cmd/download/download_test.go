@@ -137,6 +137,79 @@ func TestDownload(t *testing.T) {
 		apps.AssertExpectations(t)
 	})
 
+	t.Run("given merge with keep conflict policy it keeps conflicting local files", func(t *testing.T) {
+		appDir := t.TempDir()
+		client, downloadURL := newTestHTTPClientWithDownload(t, "streamlit_app.tar")
+		apps := mockDownloadAppService(appSlug, orgSlug, appVersionID, downloadURL)
+		localData := []byte("local content")
+		test.WriteFile(t, appDir+"/app.py", localData)
+
+		err := download(context.TODO(), client, apps, downloadInput{appDir: appDir, appSlug: appSlug, orgSlug: orgSlug, merge: true, conflict: ConflictPolicyKeep, overwriteConfirmer: confirmNever(t)})
+
+		assert.NoError(t, err)
+		test.AssertFileContent(t, appDir+"/app.py", localData)
+		assert.NoFileExists(t, appDir+"/app.py.remote")
+		assertFileContentEqual(t, "../../testdata/streamlit_app/requirements.txt", appDir+"/requirements.txt")
+	})
+
+	t.Run("given merge with remote conflict policy it writes remote copies of conflicting files", func(t *testing.T) {
+		appDir := t.TempDir()
+		client, downloadURL := newTestHTTPClientWithDownload(t, "streamlit_app.tar")
+		apps := mockDownloadAppService(appSlug, orgSlug, appVersionID, downloadURL)
+		localData := []byte("local content")
+		test.WriteFile(t, appDir+"/app.py", localData)
+
+		err := download(context.TODO(), client, apps, downloadInput{appDir: appDir, appSlug: appSlug, orgSlug: orgSlug, merge: true, conflict: ConflictPolicyRemote, overwriteConfirmer: confirmNever(t)})
+
+		assert.NoError(t, err)
+		test.AssertFileContent(t, appDir+"/app.py", localData)
+		assertFileContentEqual(t, "../../testdata/streamlit_app/app.py", appDir+"/app.py.remote")
+	})
+
+	t.Run("given merge with overwrite conflict policy it overwrites conflicting files", func(t *testing.T) {
+		appDir := t.TempDir()
+		client, downloadURL := newTestHTTPClientWithDownload(t, "streamlit_app.tar")
+		apps := mockDownloadAppService(appSlug, orgSlug, appVersionID, downloadURL)
+		test.WriteFile(t, appDir+"/app.py", []byte("local content"))
+		test.WriteFile(t, appDir+"/local_only.py", []byte("local only"))
+
+		err := download(context.TODO(), client, apps, downloadInput{appDir: appDir, appSlug: appSlug, orgSlug: orgSlug, merge: true, conflict: ConflictPolicyOverwrite, overwriteConfirmer: confirmNever(t)})
+
+		assert.NoError(t, err)
+		assertFileContentEqual(t, "../../testdata/streamlit_app/app.py", appDir+"/app.py")
+		test.AssertFileContent(t, appDir+"/local_only.py", []byte("local only"))
+	})
+
+	t.Run("given dry run it does not change any files", func(t *testing.T) {
+		parentDir := t.TempDir()
+		appDir := parentDir + "/app"
+		client, downloadURL := newTestHTTPClientWithDownload(t, "streamlit_app.tar")
+		apps := mockDownloadAppService(appSlug, orgSlug, appVersionID, downloadURL)
+		localData := []byte("local content")
+		require.NoError(t, os.Mkdir(appDir, 0o755))
+		test.WriteFile(t, appDir+"/app.py", localData)
+
+		err := download(context.TODO(), client, apps, downloadInput{appDir: appDir, appSlug: appSlug, orgSlug: orgSlug, dryRun: true, overwriteConfirmer: confirmNever(t)})
+
+		assert.NoError(t, err)
+		test.AssertFileContent(t, appDir+"/app.py", localData)
+		assert.NoFileExists(t, appDir+"/numerous.toml")
+		assertOnlyEntries(t, parentDir, "app")
+	})
+
+	t.Run("removes staging directory after download", func(t *testing.T) {
+		parentDir := t.TempDir()
+		appDir := parentDir + "/app"
+		client, downloadURL := newTestHTTPClientWithDownload(t, "streamlit_app.tar")
+		apps := mockDownloadAppService(appSlug, orgSlug, appVersionID, downloadURL)
+		require.NoError(t, os.Mkdir(appDir, 0o755))
+
+		err := download(context.TODO(), client, apps, downloadInput{appDir: appDir, appSlug: appSlug, orgSlug: orgSlug, overwriteConfirmer: confirmAlways})
+
+		assert.NoError(t, err)
+		assertOnlyEntries(t, parentDir, "app")
+	})
+
 	t.Run("returns error if download http request is not ok", func(t *testing.T) {
 		appDir := t.TempDir()
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -177,6 +250,36 @@ func newTestHTTPClientWithDownload(t *testing.T, testdataFilePath string) (clien
 	return s.Client(), s.URL + "/" + testdataFilePath
 }
 
+func mockDownloadAppService(appSlug, orgSlug, appVersionID, downloadURL string) *mockAppService {
+	apps := &mockAppService{}
+	apps.On("CurrentAppVersion", mock.Anything, app.CurrentAppVersionInput{OrganizationSlug: orgSlug, AppSlug: appSlug}).Return(app.CurrentAppVersionOutput{AppVersionID: appVersionID}, nil)
+	apps.On("AppVersionDownloadURL", mock.Anything, app.AppVersionDownloadURLInput{AppVersionID: appVersionID}).Return(app.AppVersionDownloadURLOutput{DownloadURL: downloadURL}, nil)
+
+	return apps
+}
+
+func assertOnlyEntries(t *testing.T, dir string, expected ...string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	actual := []string{}
+	for _, e := range entries {
+		actual = append(actual, e.Name())
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func confirmNever(t *testing.T) func(string) bool {
+	t.Helper()
+
+	return func(string) bool {
+		t.Error("unexpected overwrite confirmation")
+		return false
+	}
+}
+
 func confirmAlways(appDir string) bool {
 	return true
 }
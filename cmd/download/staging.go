@@ -0,0 +1,314 @@
+package download
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"numerous.com/cli/internal/archive"
+	"numerous.com/cli/internal/output"
+)
+
+const (
+	stagingDirPattern = ".numerous-download-*"
+	backupDirPattern  = ".numerous-download-backup-*"
+	remoteCopySuffix  = ".remote"
+	stagingDirPerm    = 0o755
+)
+
+type fileAction string
+
+const (
+	actionCreate      fileAction = "create"
+	actionOverwrite   fileAction = "overwrite"
+	actionKeepLocal   fileAction = "conflict, keep local"
+	actionWriteRemote fileAction = "conflict, write " + remoteCopySuffix
+)
+
+// A change to a single file in the app directory, that is required to apply
+// the downloaded app source.
+type fileChange struct {
+	path   string
+	action fileAction
+}
+
+// Returns the path, relative to the app directory, that the downloaded file is
+// written to, or an empty string if it is not written.
+func (c fileChange) destination() string {
+	switch c.action {
+	case actionCreate, actionOverwrite:
+		return c.path
+	case actionWriteRemote:
+		return c.path + remoteCopySuffix
+	default:
+		return ""
+	}
+}
+
+// Creates a staging directory next to the app directory, so that files can be
+// moved from it to the app directory without copying. The staging directory
+// gets the permissions of a regular directory, since it becomes the app
+// directory, if that does not exist. The parent directories that were created
+// are returned, deepest first, so they can be removed again.
+func createStagingDir(appDir string) (string, []string, error) {
+	parent, err := parentDir(appDir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	createdDirs, err := mkdirAll(parent)
+	if err != nil {
+		removeDirs(createdDirs)
+		return "", nil, err
+	}
+
+	dir, err := os.MkdirTemp(parent, stagingDirPattern)
+	if err != nil {
+		removeDirs(createdDirs)
+		return "", nil, err
+	}
+
+	if err := os.Chmod(dir, stagingDirPerm); err != nil {
+		os.RemoveAll(dir) // nolint:errcheck
+		removeDirs(createdDirs)
+
+		return "", nil, err
+	}
+
+	return dir, createdDirs, nil
+}
+
+// Returns the parent of the app directory, which is resolved as an absolute
+// path first, so that e.g. "." does not resolve to the app directory itself.
+func parentDir(appDir string) (string, error) {
+	abs, err := filepath.Abs(appDir)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Dir(abs), nil
+}
+
+// Compares the staged files with the files in the app directory, and returns
+// the changes needed to apply the staged files, sorted by path. Files that are
+// identical in both directories are left out.
+//
+// If merging, files that differ are handled according to the conflict policy,
+// otherwise they are overwritten.
+func planChanges(stagingDir, appDir string, merge bool, policy ConflictPolicy) ([]fileChange, error) {
+	staged, err := archive.ListFiles(stagingDir, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []fileChange{}
+	for _, p := range staged {
+		localPath := filepath.Join(appDir, filepath.FromSlash(p))
+		local, err := os.ReadFile(localPath)
+		if errors.Is(err, os.ErrNotExist) {
+			changes = append(changes, fileChange{path: p, action: actionCreate})
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		remote, err := os.ReadFile(filepath.Join(stagingDir, filepath.FromSlash(p)))
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.Equal(local, remote) {
+			continue
+		}
+
+		changes = append(changes, fileChange{path: p, action: conflictAction(merge, policy)})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].path < changes[j].path })
+
+	return changes, nil
+}
+
+func conflictAction(merge bool, policy ConflictPolicy) fileAction {
+	if !merge {
+		return actionOverwrite
+	}
+
+	switch policy {
+	case ConflictPolicyRemote:
+		return actionWriteRemote
+	case ConflictPolicyOverwrite:
+		return actionOverwrite
+	default:
+		return actionKeepLocal
+	}
+}
+
+// A file that was moved into the app directory, and how to undo it.
+type appliedChange struct {
+	dest        string
+	backup      string
+	createdDirs []string // directories created for the file, deepest first
+}
+
+// Moves the staged files into the app directory according to the changes.
+// Replaced files are moved to a backup directory first, and if any change
+// fails, all applied changes are rolled back, leaving the app directory as it
+// was.
+func applyChanges(stagingDir, appDir string, changes []fileChange) error {
+	parent, err := parentDir(appDir)
+	if err != nil {
+		return err
+	}
+
+	backupDir, err := os.MkdirTemp(parent, backupDirPattern)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(backupDir)
+
+	applied := []appliedChange{}
+	for i, c := range changes {
+		dest := c.destination()
+		if dest == "" {
+			continue
+		}
+
+		a, err := applyChange(filepath.Join(stagingDir, filepath.FromSlash(c.path)), filepath.Join(appDir, filepath.FromSlash(dest)), filepath.Join(backupDir, fmt.Sprint(i)))
+		if err != nil {
+			rollbackChanges(applied)
+			return err
+		}
+		applied = append(applied, a)
+	}
+
+	return nil
+}
+
+func applyChange(src, dest, backup string) (appliedChange, error) {
+	a := appliedChange{dest: dest}
+
+	createdDirs, err := mkdirAll(filepath.Dir(dest))
+	a.createdDirs = createdDirs
+	if err != nil {
+		removeDirs(a.createdDirs)
+		return a, err
+	}
+
+	if err := os.Rename(dest, backup); err == nil {
+		a.backup = backup
+	} else if !errors.Is(err, os.ErrNotExist) {
+		removeDirs(a.createdDirs)
+		return a, err
+	}
+
+	if err := os.Rename(src, dest); err != nil {
+		if a.backup != "" {
+			os.Rename(a.backup, dest) // nolint:errcheck
+		}
+		removeDirs(a.createdDirs)
+
+		return a, err
+	}
+
+	return a, nil
+}
+
+// Creates the directory and any missing parents, and returns the directories
+// that were created, deepest first, so they can be removed again.
+func mkdirAll(dir string) ([]string, error) {
+	missing := []string{}
+	for d := filepath.Clean(dir); ; d = filepath.Dir(d) {
+		if _, err := os.Lstat(d); err == nil {
+			break
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		missing = append(missing, d)
+
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+
+	created := []string{}
+	for i := len(missing) - 1; i >= 0; i-- {
+		if err := os.Mkdir(missing[i], stagingDirPerm); err != nil {
+			slices.Reverse(created)
+			return created, err
+		}
+		created = append(created, missing[i])
+	}
+	slices.Reverse(created)
+
+	return created, nil
+}
+
+func removeDirs(dirs []string) {
+	for _, d := range dirs {
+		os.Remove(d) // nolint:errcheck
+	}
+}
+
+func rollbackChanges(applied []appliedChange) {
+	for i := len(applied) - 1; i >= 0; i-- {
+		a := applied[i]
+		if a.backup != "" {
+			os.Rename(a.backup, a.dest) // nolint:errcheck
+		} else {
+			os.Remove(a.dest) // nolint:errcheck
+		}
+		removeDirs(a.createdDirs)
+	}
+}
+
+func printPlannedChanges(changes []fileChange) {
+	if len(changes) == 0 {
+		fmt.Println("No files would change.")
+		return
+	}
+
+	fmt.Println("The following changes would be made:")
+
+	for _, c := range changes {
+		fmt.Printf("  %s%-20s%s %s\n", actionColor(c.action), string(c.action)+":", output.AnsiReset, c.path)
+	}
+}
+
+func printConflicts(changes []fileChange) {
+	conflicts := 0
+	for _, c := range changes {
+		if c.action == actionKeepLocal || c.action == actionWriteRemote {
+			conflicts++
+		}
+	}
+
+	if conflicts == 0 {
+		return
+	}
+
+	fmt.Printf("\n%d files differ from the downloaded app source:\n", conflicts)
+	for _, c := range changes {
+		switch c.action {
+		case actionKeepLocal:
+			fmt.Printf("  %s (kept local version)\n", c.path)
+		case actionWriteRemote:
+			fmt.Printf("  %s (downloaded version written to %s)\n", c.path, c.destination())
+		}
+	}
+}
+
+func actionColor(action fileAction) string {
+	switch action {
+	case actionCreate:
+		return output.AnsiGreen
+	case actionOverwrite:
+		return output.AnsiRed
+	default:
+		return output.AnsiYellow
+	}
+}
@@ -0,0 +1,141 @@
+package download
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"numerous.com/cli/internal/test"
+)
+
+func TestPlanChanges(t *testing.T) {
+	setup := func(t *testing.T) (string, string) {
+		t.Helper()
+
+		stagingDir := t.TempDir()
+		test.WriteFile(t, filepath.Join(stagingDir, "new.py"), []byte("new"))
+		test.WriteFile(t, filepath.Join(stagingDir, "same.py"), []byte("same"))
+		test.WriteFile(t, filepath.Join(stagingDir, "changed.py"), []byte("remote"))
+
+		appDir := t.TempDir()
+		test.WriteFile(t, filepath.Join(appDir, "same.py"), []byte("same"))
+		test.WriteFile(t, filepath.Join(appDir, "changed.py"), []byte("local"))
+
+		return stagingDir, appDir
+	}
+
+	for _, tc := range []struct {
+		name     string
+		merge    bool
+		policy   ConflictPolicy
+		expected fileAction
+	}{
+		{name: "without merge it overwrites", merge: false, policy: ConflictPolicyKeep, expected: actionOverwrite},
+		{name: "with merge and keep policy it keeps local", merge: true, policy: ConflictPolicyKeep, expected: actionKeepLocal},
+		{name: "with merge and remote policy it writes remote copy", merge: true, policy: ConflictPolicyRemote, expected: actionWriteRemote},
+		{name: "with merge and overwrite policy it overwrites", merge: true, policy: ConflictPolicyOverwrite, expected: actionOverwrite},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			stagingDir, appDir := setup(t)
+
+			actual, err := planChanges(stagingDir, appDir, tc.merge, tc.policy)
+
+			assert.NoError(t, err)
+			expected := []fileChange{
+				{path: "changed.py", action: tc.expected},
+				{path: "new.py", action: actionCreate},
+			}
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestApplyChanges(t *testing.T) {
+	t.Run("moves staged files into place", func(t *testing.T) {
+		stagingDir := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(stagingDir, "dir"), 0o755))
+		test.WriteFile(t, filepath.Join(stagingDir, "dir", "new.py"), []byte("new"))
+		test.WriteFile(t, filepath.Join(stagingDir, "changed.py"), []byte("remote"))
+		test.WriteFile(t, filepath.Join(stagingDir, "conflict.py"), []byte("remote"))
+		appDir := t.TempDir()
+		test.WriteFile(t, filepath.Join(appDir, "changed.py"), []byte("local"))
+		test.WriteFile(t, filepath.Join(appDir, "conflict.py"), []byte("local"))
+		changes := []fileChange{
+			{path: "changed.py", action: actionOverwrite},
+			{path: "conflict.py", action: actionWriteRemote},
+			{path: "dir/new.py", action: actionCreate},
+		}
+
+		err := applyChanges(stagingDir, appDir, changes)
+
+		assert.NoError(t, err)
+		test.AssertFileContent(t, filepath.Join(appDir, "changed.py"), []byte("remote"))
+		test.AssertFileContent(t, filepath.Join(appDir, "conflict.py"), []byte("local"))
+		test.AssertFileContent(t, filepath.Join(appDir, "conflict.py.remote"), []byte("remote"))
+		test.AssertFileContent(t, filepath.Join(appDir, "dir", "new.py"), []byte("new"))
+	})
+
+	t.Run("given a failing change it rolls back applied changes", func(t *testing.T) {
+		stagingDir := t.TempDir()
+		test.WriteFile(t, filepath.Join(stagingDir, "changed.py"), []byte("remote"))
+		test.WriteFile(t, filepath.Join(stagingDir, "new.py"), []byte("new"))
+		require.NoError(t, os.MkdirAll(filepath.Join(stagingDir, "newdir", "sub"), 0o755))
+		test.WriteFile(t, filepath.Join(stagingDir, "newdir", "sub", "file.py"), []byte("new"))
+		require.NoError(t, os.Mkdir(filepath.Join(stagingDir, "blocked"), 0o755))
+		test.WriteFile(t, filepath.Join(stagingDir, "blocked", "file.py"), []byte("blocked"))
+		appDir := t.TempDir()
+		test.WriteFile(t, filepath.Join(appDir, "changed.py"), []byte("local"))
+		// a regular file where a directory is needed makes the change fail
+		test.WriteFile(t, filepath.Join(appDir, "blocked"), []byte("a file"))
+		changes := []fileChange{
+			{path: "changed.py", action: actionOverwrite},
+			{path: "new.py", action: actionCreate},
+			{path: "newdir/sub/file.py", action: actionCreate},
+			{path: "blocked/file.py", action: actionCreate},
+		}
+
+		err := applyChanges(stagingDir, appDir, changes)
+
+		assert.Error(t, err)
+		test.AssertFileContent(t, filepath.Join(appDir, "changed.py"), []byte("local"))
+		assert.NoFileExists(t, filepath.Join(appDir, "new.py"))
+		assert.NoDirExists(t, filepath.Join(appDir, "newdir"))
+		test.AssertFileContent(t, filepath.Join(appDir, "blocked"), []byte("a file"))
+	})
+}
+
+func TestCreateStagingDir(t *testing.T) {
+	t.Run("creates staging dir with directory permissions", func(t *testing.T) {
+		appDir := filepath.Join(t.TempDir(), "app")
+
+		stagingDir, createdDirs, err := createStagingDir(appDir)
+
+		require.NoError(t, err)
+		assert.Empty(t, createdDirs)
+		info, err := os.Stat(stagingDir)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+	})
+
+	t.Run("creates staging dir outside of the current app dir", func(t *testing.T) {
+		appDir := t.TempDir()
+		t.Chdir(appDir)
+
+		stagingDir, _, err := createStagingDir(".")
+
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Dir(appDir), filepath.Dir(stagingDir))
+	})
+
+	t.Run("returns created parent dirs", func(t *testing.T) {
+		base := t.TempDir()
+		appDir := filepath.Join(base, "parent", "sub", "app")
+
+		_, createdDirs, err := createStagingDir(appDir)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join(base, "parent", "sub"), filepath.Join(base, "parent")}, createdDirs)
+	})
+}
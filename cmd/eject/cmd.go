@@ -0,0 +1,49 @@
+package eject
+
+import (
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/cmd/errorhandling"
+	"numerous.com/cli/cmd/group"
+
+	"github.com/spf13/cobra"
+)
+
+const long string = `Convert an app with a [python] configuration into an app built from a
+Dockerfile.
+
+Generates a Dockerfile which builds the app like the Numerous platform does,
+using the Python version, the requirements file or project file, the serve
+command of the library, and the port of the app manifest, or the default port
+of the library. A .dockerignore file is generated from the exclude list of the
+manifest.
+
+The [python] table of the app manifest is then replaced by a [docker]
+configuration with the generated Dockerfile, which can be modified to customize
+the build. Comments and formatting of the rest of the manifest are kept. If the
+manifest cannot be updated while keeping them, it is not changed, the required
+change is printed instead, and the command fails.
+
+If a Dockerfile already exists in the app directory, nothing is changed.
+
+If [app directory] is specified, the app in that directory is ejected,
+otherwise the app in the current working directory is ejected.
+`
+
+var Cmd = &cobra.Command{
+	Use:     "eject [app directory]",
+	RunE:    run,
+	Short:   "Convert a Python app into an app built from a Dockerfile",
+	Long:    long,
+	GroupID: group.AppCommandsGroupID,
+	Args:    args.OptionalAppDir(&cmdArgs.appDir),
+}
+
+var cmdArgs struct {
+	appDir string
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	err := eject(ejectInput{appDir: cmdArgs.appDir})
+
+	return errorhandling.ErrorAlreadyPrinted(err)
+}
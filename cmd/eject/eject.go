@@ -0,0 +1,104 @@
+package eject
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"numerous.com/cli/internal/manifest"
+	"numerous.com/cli/internal/output"
+)
+
+type ejectInput struct {
+	appDir string
+}
+
+func eject(input ejectInput) error {
+	manifestPath := filepath.Join(input.appDir, manifest.ManifestFileName)
+
+	info, err := os.Stat(manifestPath)
+	if errors.Is(err, os.ErrNotExist) {
+		output.PrintErrorAppNotInitialized(input.appDir)
+		return err
+	}
+
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		output.PrintErrorDetails("Error reading manifest %q", err, manifestPath)
+		return err
+	}
+
+	m, err := manifest.Load(manifestPath)
+	if err != nil {
+		output.PrintManifestTOMLError(err)
+		return err
+	}
+
+	dockerfilePath := filepath.Join(input.appDir, manifest.EjectDockerfileName)
+	dockerignorePath := filepath.Join(input.appDir, manifest.DockerignoreFileName)
+	_, dockerignoreErr := os.Stat(dockerignorePath)
+	createdDockerignore := errors.Is(dockerignoreErr, os.ErrNotExist)
+
+	err = m.Eject(input.appDir)
+	switch {
+	case errors.Is(err, manifest.ErrEjectNoPythonConfiguration):
+		output.PrintError("The app is already built from a Dockerfile", "The manifest %q has no [python] configuration to eject.", manifestPath)
+		return err
+	case errors.Is(err, manifest.ErrNoBootstrapDockerfileExists):
+		output.PrintError("A Dockerfile already exists", "Remove or rename %q to eject the app.", dockerfilePath)
+		return err
+	case errors.Is(err, manifest.ErrEjectNoPort):
+		output.PrintError("Cannot determine the port of the app", "Set the port in the manifest %q to eject the app.", manifestPath)
+		return err
+	case errors.Is(err, manifest.ErrNoServeCommand):
+		output.PrintErrorDetails("Cannot generate the Dockerfile command", err)
+		return err
+	case err != nil:
+		output.PrintErrorDetails("Error generating the Dockerfile", err)
+		return err
+	}
+
+	ejected, err := manifest.EjectText(string(content), m)
+	if err != nil {
+		output.PrintlnOK("Generated %q", dockerfilePath)
+		printRequiredManifestChange(manifestPath, m, err)
+
+		return err
+	}
+
+	if err := os.WriteFile(manifestPath, []byte(ejected), info.Mode().Perm()); err != nil {
+		output.PrintErrorDetails("Error writing manifest %q", err, manifestPath)
+
+		// the generated files are removed, so the app can be ejected again
+		os.Remove(dockerfilePath) // nolint:errcheck
+		if createdDockerignore {
+			os.Remove(dockerignorePath) // nolint:errcheck
+		}
+
+		return err
+	}
+
+	output.PrintlnOK("Generated %q", dockerfilePath)
+	output.PrintlnOK("Updated manifest %q to build the app from the Dockerfile", manifestPath)
+	output.Notify(
+		"The app is now built from a Dockerfile",
+		"Review and customize %q, and deploy the app with %s.",
+		dockerfilePath, output.Highlight("numerous deploy"),
+	)
+
+	return nil
+}
+
+// printRequiredManifestChange prints how to change the manifest to build the
+// app from the Dockerfile, for manifests that cannot be updated while keeping
+// their formatting. Until the manifest is changed, the app is still built from
+// its [python] configuration.
+func printRequiredManifestChange(manifestPath string, m *manifest.Manifest, err error) {
+	output.PrintError(
+		"Could not update the manifest to build the app from the Dockerfile",
+		"The manifest %q could not be updated: %s.\n"+
+			"The app is built from the [python] table until it is replaced with the table below, with the port set to %d:\n\n"+
+			"[docker]\ndockerfile = %q\ncontext = %q",
+		manifestPath, err, m.Port, m.Docker.Dockerfile, m.Docker.Context,
+	)
+}
@@ -0,0 +1,108 @@
+package eject
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"numerous.com/cli/internal/manifest"
+	"numerous.com/cli/internal/test"
+)
+
+func TestEject(t *testing.T) {
+	t.Run("generates Dockerfile and rewrites manifest", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+
+		_, err := test.RunEWithPatchedStdout(t, func() error {
+			return eject(ejectInput{appDir: appDir})
+		})
+
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(appDir, "Dockerfile"))
+		assert.FileExists(t, filepath.Join(appDir, ".dockerignore"))
+
+		m, err := manifest.Load(filepath.Join(appDir, manifest.ManifestFileName))
+		require.NoError(t, err)
+		assert.Nil(t, m.Python)
+		assert.Equal(t, &manifest.Docker{Dockerfile: "Dockerfile", Context: "."}, m.Docker)
+		assert.Equal(t, "Streamlit App With Deploy", m.Name)
+		assert.Equal(t, uint(80), m.Port)
+		assert.Equal(t, &manifest.Deployment{OrganizationSlug: "organization-slug-in-manifest", AppSlug: "app-slug-in-manifest"}, m.Deployment)
+	})
+
+	t.Run("keeps comments in the manifest", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		manifestPath := filepath.Join(appDir, manifest.ManifestFileName)
+		original, err := os.ReadFile(manifestPath)
+		require.NoError(t, err)
+		test.WriteFile(t, manifestPath, append([]byte("# My app\n"), original...))
+
+		_, err = test.RunEWithPatchedStdout(t, func() error {
+			return eject(ejectInput{appDir: appDir})
+		})
+
+		require.NoError(t, err)
+		ejected, err := os.ReadFile(manifestPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(ejected), "# My app\nname = \"Streamlit App With Deploy\"\n")
+	})
+
+	t.Run("given manifest which cannot be updated it prints the required change and returns error", func(t *testing.T) {
+		appDir := t.TempDir()
+		manifestPath := filepath.Join(appDir, manifest.ManifestFileName)
+		original := []byte("name = \"App\"\nport = 80\npython = { library = \"streamlit\", version = \"3.12\", app_file = \"app.py\" }\n")
+		test.WriteFile(t, manifestPath, original)
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return eject(ejectInput{appDir: appDir})
+		})
+
+		assert.ErrorIs(t, err, manifest.ErrEjectManifestText)
+		assert.FileExists(t, filepath.Join(appDir, "Dockerfile"))
+		test.AssertFileContent(t, manifestPath, original)
+		out, _ := io.ReadAll(stdout)
+		assert.Contains(t, string(out), "[docker]\ndockerfile = \"Dockerfile\"\ncontext = \".\"")
+	})
+
+	t.Run("given existing Dockerfile it does not change manifest", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.CopyDir(t, "../../testdata/streamlit_app", appDir)
+		manifestPath := filepath.Join(appDir, manifest.ManifestFileName)
+		original, err := os.ReadFile(manifestPath)
+		require.NoError(t, err)
+		test.WriteFile(t, filepath.Join(appDir, "Dockerfile"), []byte("FROM scratch\n"))
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return eject(ejectInput{appDir: appDir})
+		})
+
+		assert.ErrorIs(t, err, manifest.ErrNoBootstrapDockerfileExists)
+		test.AssertFileContent(t, manifestPath, original)
+		out, _ := io.ReadAll(stdout)
+		assert.Contains(t, string(out), "A Dockerfile already exists")
+	})
+
+	t.Run("given docker app it returns error", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.WriteFile(t, filepath.Join(appDir, manifest.ManifestFileName), []byte("name = \"App\"\nport = 80\n\n[docker]\ndockerfile = \"Dockerfile\"\ncontext = \".\"\n"))
+
+		_, err := test.RunEWithPatchedStdout(t, func() error {
+			return eject(ejectInput{appDir: appDir})
+		})
+
+		assert.ErrorIs(t, err, manifest.ErrEjectNoPythonConfiguration)
+	})
+
+	t.Run("given missing manifest it returns error", func(t *testing.T) {
+		_, err := test.RunEWithPatchedStdout(t, func() error {
+			return eject(ejectInput{appDir: t.TempDir()})
+		})
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
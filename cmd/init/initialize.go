@@ -2,6 +2,7 @@ package init
 
 import (
 	"errors"
+	"path/filepath"
 
 	"numerous.com/cli/internal/manifest"
 	"numerous.com/cli/internal/output"
@@ -30,6 +31,10 @@ func Initialize(asker wizard.Asker, params InitializeParams) (*manifest.Manifest
 				"numerous init ./my-app-folder\n\n",
 			params.AppDir,
 		)
+		manifestPath := filepath.Join(params.AppDir, manifest.ManifestFileName)
+		if _, format, err := manifest.LoadWithFormat(manifestPath); err == nil {
+			manifest.WarnIfDeprecated(manifestPath, format)
+		}
 
 		return nil, ErrAppAlreadyInitialized
 	}
@@ -46,6 +51,13 @@ func Initialize(asker wizard.Asker, params InitializeParams) (*manifest.Manifest
 		return nil, err
 	}
 
+	// uv and Poetry projects are used instead of a requirements file, unless
+	// one is given explicitly
+	var projectFile, lockFile string
+	if params.RequirementsFile == "" {
+		projectFile, lockFile = manifest.DetectPythonProject(params.AppDir)
+	}
+
 	runWizardParams := wizard.RunWizardParams{
 		AppDir: params.AppDir,
 		App: wizard.AppAnswers{
@@ -56,6 +68,8 @@ func Initialize(asker wizard.Asker, params InitializeParams) (*manifest.Manifest
 		},
 		Python: wizard.PythonAnswers{
 			RequirementsFile: params.RequirementsFile,
+			ProjectFile:      projectFile,
+			LockFile:         lockFile,
 			AppFile:          params.AppFile,
 			Library:          lib,
 		},
@@ -72,7 +86,10 @@ func Initialize(asker wizard.Asker, params InitializeParams) (*manifest.Manifest
 	}
 
 	if m.Python != nil && m.Python.Library.Key != "" {
-		m.Python.Version = python.PythonVersion()
+		m.Python.Version = m.Python.ProjectPythonVersion(params.AppDir)
+		if m.Python.Version == "" {
+			m.Python.Version = python.PythonVersion()
+		}
 	}
 
 	err = m.BootstrapFiles(params.AppDir)
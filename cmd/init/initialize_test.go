@@ -44,6 +44,62 @@ func TestInitialize(t *testing.T) {
 		}
 	})
 
+	t.Run("initializes python app in uv project", func(t *testing.T) {
+		appdir := t.TempDir()
+		pyproject := "[project]\nname = \"my-app\"\nrequires-python = \">=3.12\"\ndependencies = [\n    \"pandas\",\n]\n"
+		test.WriteFile(t, filepath.Join(appdir, "pyproject.toml"), []byte(pyproject))
+		test.WriteFile(t, filepath.Join(appdir, "uv.lock"), []byte(""))
+
+		asker := wizard.StubAsker{wizard.UseFolderQuestion(appdir): true}
+		params := InitializeParams{
+			AppDir:     appdir,
+			Name:       appName,
+			Desc:       appDesc,
+			LibraryKey: appLibraryKey,
+			AppFile:    appFile,
+		}
+		_, err := Initialize(asker, params)
+
+		assert.NoError(t, err)
+		assert.NoFileExists(t, filepath.Join(appdir, appRequirementsFile))
+		test.AssertFileContent(t, filepath.Join(appdir, "pyproject.toml"), []byte("[project]\nname = \"my-app\"\nrequires-python = \">=3.12\"\ndependencies = [\n    \"pandas\",\n    \"streamlit\",\n]\n"))
+		m, err := manifest.Load(filepath.Join(appdir, "numerous.toml"))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "pyproject.toml", m.Python.ProjectFile)
+			assert.Equal(t, "uv.lock", m.Python.LockFile)
+			assert.Equal(t, "", m.Python.RequirementsFile)
+			assert.Equal(t, "3.12", m.Python.Version)
+		}
+	})
+
+	t.Run("initializes python app with requirements file when pyproject.toml has only tool configuration", func(t *testing.T) {
+		appdir := t.TempDir()
+		pyproject := "[tool.black]\nline-length = 88\n"
+		test.WriteFile(t, filepath.Join(appdir, "pyproject.toml"), []byte(pyproject))
+
+		asker := wizard.StubAsker{
+			wizard.UseFolderQuestion(appdir): true,
+			"RequirementsFile":               appRequirementsFile,
+		}
+		params := InitializeParams{
+			AppDir:     appdir,
+			Name:       appName,
+			Desc:       appDesc,
+			LibraryKey: appLibraryKey,
+			AppFile:    appFile,
+		}
+		_, err := Initialize(asker, params)
+
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(appdir, appRequirementsFile))
+		test.AssertFileContent(t, filepath.Join(appdir, "pyproject.toml"), []byte(pyproject))
+		m, err := manifest.Load(filepath.Join(appdir, "numerous.toml"))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "", m.Python.ProjectFile)
+			assert.Equal(t, appRequirementsFile, m.Python.RequirementsFile)
+		}
+	})
+
 	t.Run("given appdir with manifest file returns error", func(t *testing.T) {
 		appdir := t.TempDir()
 		test.WriteFile(t, filepath.Join(appdir, "numerous.toml"), []byte("manifest contet"))
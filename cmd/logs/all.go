@@ -0,0 +1,318 @@
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"sync"
+	"time"
+
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/appident"
+	"numerous.com/cli/internal/logfilter"
+	"numerous.com/cli/internal/logformat"
+	"numerous.com/cli/internal/logstore"
+	"numerous.com/cli/internal/output"
+)
+
+var ErrNoApps = errors.New("no apps to read logs from")
+
+// reorderWindow is how long entries are held back before they are printed, so
+// entries from different apps, which arrive at about the same time, are
+// printed in the order of their timestamps.
+const reorderWindow = 500 * time.Millisecond
+
+var prefixColors = []string{output.AnsiCyan, output.AnsiYellow, output.AnsiGreen, output.AnsiMagenta, output.AnsiBlue, output.AnsiRed}
+
+type appLister interface {
+	List(ctx context.Context, organizationSlug string) ([]app.ListApp, error)
+}
+
+type allLogsInput struct {
+	orgSlug    string
+	include    []string
+	exclude    []string
+	tail       int
+	follow     bool
+	timestamps bool
+	filter     logfilter.Options
+	format     args.OutputFormat
+	lineFormat logformat.Options
+	archive    *logstore.Store
+}
+
+type appLogEntry struct {
+	appSlug  string
+	entry    app.AppDeployLogEntry
+	separate bool
+	received time.Time
+}
+
+// allLogs reads the logs of all apps in the organization, and prints them as
+// one stream, with each entry prefixed by the slug of its app. Each app has
+// its own log subscription, so a new service is created for each app.
+func allLogs(ctx context.Context, lister appLister, newService func(app.ReconnectOptions) appService, input allLogsInput) error {
+	if input.orgSlug == "" {
+		output.PrintErrorMissingOrganizationSlug()
+		return appident.ErrMissingOrganizationSlug
+	}
+
+	apps, err := lister.List(ctx, input.orgSlug)
+	if err != nil {
+		printAppListError(err)
+		return err
+	}
+
+	slugs, err := selectApps(apps, input.include, input.exclude)
+	if err != nil {
+		output.PrintErrorDetails("Invalid app slug pattern", err)
+		return err
+	}
+
+	if len(apps) == 0 {
+		output.PrintError("No apps to read logs from", "There are no apps in the organization %q.", input.orgSlug)
+		return ErrNoApps
+	}
+
+	if len(slugs) == 0 {
+		output.PrintError("No apps to read logs from", "No apps in the organization %q match the --include and --exclude patterns.", input.orgSlug)
+		return ErrNoApps
+	}
+
+	var tail *int
+	if input.tail > 0 {
+		tail = &input.tail
+	}
+
+	var streamErrMu sync.Mutex
+	var streamErr error
+	setStreamErr := func(err error) {
+		streamErrMu.Lock()
+		defer streamErrMu.Unlock()
+		streamErr = err
+	}
+
+	merged := make(chan appLogEntry)
+	var wg sync.WaitGroup
+	for _, slug := range slugs {
+		ai := appident.AppIdentifier{OrganizationSlug: input.orgSlug, AppSlug: slug}
+		reconnect := ReconnectOptions(func(err error) {
+			output.PrintErrorDetails("Lost the connection to the logs of the app %q", err, slug)
+			setStreamErr(err)
+		})
+		reconnect.OnReconnect = func() { PrintReconnected("[" + slug + "] ") }
+
+		var recorder *logstore.Recorder
+		if input.archive != nil {
+			recorder, err = input.archive.Recorder(ai.OrganizationSlug, ai.AppSlug)
+			if err != nil {
+				printArchiveError(err)
+				return err
+			}
+			defer recorder.Close()
+		}
+
+		ch, err := newService(reconnect).AppDeployLogs(ai, tail, input.follow)
+		if err != nil {
+			app.PrintAppError(err, ai)
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := forwardAppLogs(ctx, slug, ch, logfilter.New(input.filter), recorder, merged); err != nil {
+				printArchiveError(err)
+				setStreamErr(err)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(merged)
+	}()
+
+	printMerged(ctx, merged, newPrefixPrinter(slugs, input))
+
+	streamErrMu.Lock()
+	defer streamErrMu.Unlock()
+
+	return streamErr
+}
+
+// selectApps returns the slugs of the apps matching any of the include
+// patterns, or all apps if there are none, and none of the exclude patterns.
+func selectApps(apps []app.ListApp, include []string, exclude []string) ([]string, error) {
+	for _, pattern := range slices.Concat(include, exclude) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%w: %q", err, pattern)
+		}
+	}
+
+	matchesAny := func(patterns []string, slug string) bool {
+		return slices.ContainsFunc(patterns, func(pattern string) bool {
+			matched, _ := path.Match(pattern, slug)
+			return matched
+		})
+	}
+
+	var slugs []string
+	for _, a := range apps {
+		if len(include) > 0 && !matchesAny(include, a.Slug) {
+			continue
+		}
+
+		if matchesAny(exclude, a.Slug) {
+			continue
+		}
+
+		slugs = append(slugs, a.Slug)
+	}
+
+	return slugs, nil
+}
+
+// forwardAppLogs records and filters the entries of the app, and sends them
+// to the merged channel. It returns an error if recording an entry fails.
+// When it returns, the remaining entries are drained, so the subscription
+// sending them is not blocked.
+func forwardAppLogs(ctx context.Context, slug string, ch chan app.AppDeployLogEntry, filter *logfilter.Filter, recorder *logstore.Recorder, merged chan<- appLogEntry) error {
+	defer func() { go drain(ch) }()
+
+	for {
+		select {
+		case entry, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := recordEntry(recorder, entry); err != nil {
+				return err
+			}
+
+			if filter.PastUntil(logfilter.Entry(entry)) {
+				return nil
+			}
+
+			entries, separate := filter.Add(logfilter.Entry(entry))
+			for i, e := range entries {
+				select {
+				case merged <- appLogEntry{appSlug: slug, entry: app.AppDeployLogEntry(e), separate: separate && i == 0, received: time.Now()}:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// drain receives entries from the channel until it is closed.
+func drain(ch chan app.AppDeployLogEntry) {
+	for range ch {
+		// the entries are discarded
+	}
+}
+
+// printMerged prints the entries in the order of their timestamps, holding
+// each entry back for the reorder window, in case an earlier entry from
+// another app arrives. Entries held back are printed when the merged channel
+// is closed, or the context is done.
+func printMerged(ctx context.Context, merged <-chan appLogEntry, printEntry func(appLogEntry)) {
+	var pending []appLogEntry
+	defer func() {
+		for _, e := range pending {
+			printEntry(e)
+		}
+	}()
+
+	ticker := time.NewTicker(reorderWindow / 5) // nolint:mnd
+	defer ticker.Stop()
+
+	for {
+		select {
+		case entry, ok := <-merged:
+			if !ok {
+				return
+			}
+
+			i, _ := slices.BinarySearchFunc(pending, entry, func(e appLogEntry, target appLogEntry) int {
+				if e.entry.Timestamp.After(target.entry.Timestamp) {
+					return 1
+				}
+
+				return -1
+			})
+			pending = slices.Insert(pending, i, entry)
+		case now := <-ticker.C:
+			printed := 0
+			for _, e := range pending {
+				if now.Sub(e.received) < reorderWindow {
+					break
+				}
+				printEntry(e)
+				printed++
+			}
+			pending = pending[printed:]
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// newPrefixPrinter returns a printer of entries, prefixed with the slug of
+// their app in a color, unless printing JSON.
+func newPrefixPrinter(slugs []string, input allLogsInput) func(appLogEntry) {
+	if input.format == args.OutputFormatJSON {
+		printers := make(map[string]func(app.AppDeployLogEntry), len(slugs))
+		for _, slug := range slugs {
+			printers[slug] = JSONPrinter(os.Stdout, appident.AppIdentifier{OrganizationSlug: input.orgSlug, AppSlug: slug})
+		}
+
+		return func(e appLogEntry) {
+			printers[e.appSlug](e.entry)
+		}
+	}
+
+	width := 0
+	for _, slug := range slugs {
+		width = max(width, len(slug))
+	}
+
+	prefixes := make(map[string]string, len(slugs))
+	for i, slug := range slugs {
+		color := prefixColors[i%len(prefixColors)]
+		prefixes[slug] = color + fmt.Sprintf("%-*s", width+2, "["+slug+"]") + output.AnsiReset + " " // nolint:mnd
+	}
+
+	return func(e appLogEntry) {
+		prefix := prefixes[e.appSlug]
+		if e.separate {
+			fmt.Println(prefix + output.AnsiFaint + "--" + output.AnsiReset)
+		}
+
+		timestamp, text := FormatLine(e.entry.Timestamp, e.entry.Text, input.lineFormat)
+		if input.timestamps {
+			prefix += output.AnsiFaint + timestamp.Format(time.RFC3339) + output.AnsiReset + " "
+		}
+
+		fmt.Println(prefix + text)
+	}
+}
+
+func printAppListError(err error) {
+	switch {
+	case errors.Is(err, app.ErrAccessDenied):
+		output.PrintError("Access denied", "")
+	case errors.Is(err, app.ErrOrganizationNotFound):
+		output.PrintError("Organization not found", "")
+	default:
+		output.PrintErrorDetails("Sorry! An unexpected error occurred listing apps", err)
+	}
+}
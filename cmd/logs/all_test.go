@@ -0,0 +1,169 @@
+package logs
+
+import (
+	"context"
+	"io"
+	"path"
+	"testing"
+	"time"
+
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/appident"
+	"numerous.com/cli/internal/logfilter"
+	"numerous.com/cli/internal/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllLogs(t *testing.T) {
+	const orgSlug = "organization-slug"
+	apps := []app.ListApp{{Slug: "api"}, {Slug: "dashboard"}, {Slug: "test-app"}}
+	ts := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	logChannel := func(entries ...app.AppDeployLogEntry) chan app.AppDeployLogEntry {
+		ch := make(chan app.AppDeployLogEntry, len(entries))
+		for _, e := range entries {
+			ch <- e
+		}
+		close(ch)
+
+		return ch
+	}
+
+	t.Run("prints entries of selected apps in timestamp order with app prefixes", func(t *testing.T) {
+		lister := &AppListerMock{}
+		lister.On("List", mock.Anything, orgSlug).Return(apps, nil)
+		service := &AppServiceMock{}
+		service.On("AppDeployLogs", appident.AppIdentifier{OrganizationSlug: orgSlug, AppSlug: "api"}, (*int)(nil), false).Return(logChannel(
+			app.AppDeployLogEntry{Timestamp: ts, Text: "api 1"},
+			app.AppDeployLogEntry{Timestamp: ts.Add(2 * time.Second), Text: "api 2"},
+		), nil)
+		service.On("AppDeployLogs", appident.AppIdentifier{OrganizationSlug: orgSlug, AppSlug: "dashboard"}, (*int)(nil), false).Return(logChannel(
+			app.AppDeployLogEntry{Timestamp: ts.Add(time.Second), Text: "dashboard 1"},
+		), nil)
+		newService := func(app.ReconnectOptions) appService { return service }
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return allLogs(context.TODO(), lister, newService, allLogsInput{orgSlug: orgSlug, exclude: []string{"test-*"}})
+		})
+
+		require.NoError(t, err)
+		out, _ := io.ReadAll(stdout)
+		expected := "\033[36m[api]      \033[0m api 1\n" +
+			"\033[33m[dashboard]\033[0m dashboard 1\n" +
+			"\033[36m[api]      \033[0m api 2\n"
+		assert.Equal(t, expected, string(out))
+		service.AssertExpectations(t)
+	})
+
+	t.Run("returns error if no apps match", func(t *testing.T) {
+		lister := &AppListerMock{}
+		lister.On("List", mock.Anything, orgSlug).Return(apps, nil)
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return allLogs(context.TODO(), lister, nil, allLogsInput{orgSlug: orgSlug, include: []string{"missing-*"}})
+		})
+
+		assert.ErrorIs(t, err, ErrNoApps)
+		out, _ := io.ReadAll(stdout)
+		assert.Contains(t, string(out), "match the --include and --exclude patterns")
+	})
+
+	t.Run("returns error if organization has no apps", func(t *testing.T) {
+		lister := &AppListerMock{}
+		lister.On("List", mock.Anything, orgSlug).Return([]app.ListApp{}, nil)
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return allLogs(context.TODO(), lister, nil, allLogsInput{orgSlug: orgSlug})
+		})
+
+		assert.ErrorIs(t, err, ErrNoApps)
+		out, _ := io.ReadAll(stdout)
+		assert.Contains(t, string(out), "There are no apps in the organization")
+		assert.NotContains(t, string(out), "--include")
+	})
+
+	t.Run("returns error without organization", func(t *testing.T) {
+		err := allLogs(context.TODO(), nil, nil, allLogsInput{})
+
+		assert.ErrorIs(t, err, appident.ErrMissingOrganizationSlug)
+	})
+
+	t.Run("returns list error", func(t *testing.T) {
+		lister := &AppListerMock{}
+		lister.On("List", mock.Anything, orgSlug).Return([]app.ListApp(nil), app.ErrOrganizationNotFound)
+
+		err := allLogs(context.TODO(), lister, nil, allLogsInput{orgSlug: orgSlug})
+
+		assert.ErrorIs(t, err, app.ErrOrganizationNotFound)
+	})
+}
+
+func TestForwardAppLogs(t *testing.T) {
+	ts := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("drains remaining entries after until", func(t *testing.T) {
+		ch := make(chan app.AppDeployLogEntry)
+		until := ts
+		filter := logfilter.New(logfilter.Options{Until: &until})
+		merged := make(chan appLogEntry, 1)
+
+		done := make(chan error, 1)
+		go func() { done <- forwardAppLogs(context.TODO(), "app", ch, filter, nil, merged) }()
+		ch <- app.AppDeployLogEntry{Timestamp: ts.Add(time.Second), Text: "after until"}
+		require.NoError(t, <-done)
+
+		select {
+		case ch <- app.AppDeployLogEntry{Timestamp: ts.Add(2 * time.Second), Text: "later"}:
+		case <-time.After(time.Second):
+			t.Fatal("expected remaining entries to be drained")
+		}
+		close(ch)
+		assert.Empty(t, merged)
+	})
+}
+
+func TestPrintMerged(t *testing.T) {
+	t.Run("prints entries held back when context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		merged := make(chan appLogEntry)
+		var printed []string
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			printMerged(ctx, merged, func(e appLogEntry) { printed = append(printed, e.entry.Text) })
+		}()
+		merged <- appLogEntry{appSlug: "app", entry: app.AppDeployLogEntry{Text: "held back"}, received: time.Now()}
+		cancel()
+		<-done
+
+		assert.Equal(t, []string{"held back"}, printed)
+	})
+}
+
+func TestSelectApps(t *testing.T) {
+	apps := []app.ListApp{{Slug: "api"}, {Slug: "api-worker"}, {Slug: "dashboard"}}
+
+	t.Run("selects all apps without patterns", func(t *testing.T) {
+		slugs, err := selectApps(apps, nil, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"api", "api-worker", "dashboard"}, slugs)
+	})
+
+	t.Run("selects included apps that are not excluded", func(t *testing.T) {
+		slugs, err := selectApps(apps, []string{"api*", "dashboard"}, []string{"*-worker"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"api", "dashboard"}, slugs)
+	})
+
+	t.Run("returns error for invalid pattern", func(t *testing.T) {
+		_, err := selectApps(apps, []string{"["}, nil)
+
+		assert.ErrorIs(t, err, path.ErrBadPattern)
+	})
+}
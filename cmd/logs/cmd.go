@@ -9,8 +9,11 @@ import (
 	"numerous.com/cli/cmd/group"
 	"numerous.com/cli/cmd/usage"
 	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/config"
 	"numerous.com/cli/internal/dir"
 	"numerous.com/cli/internal/gql"
+	"numerous.com/cli/internal/logfilter"
+	"numerous.com/cli/internal/logstore"
 	"numerous.com/cli/internal/output"
 
 	"github.com/spf13/cobra"
@@ -39,6 +42,35 @@ To get logs without following (one-time read):
 
     numerous logs --organization "organization-slug-a2ecf59b" --app "my-app" --follow=false
 
+To find errors in the logs of the last day, with 5 lines of context:
+
+    numerous logs --since 1d --follow=false --level error --context 5
+
+To find lines matching a regular expression, excluding health checks:
+
+    numerous logs --grep "Exception|Timeout" --grep-v "GET /healthz"
+
+To only show the "path" and "status" fields of JSON log lines, or to show JSON
+log lines as they are:
+
+    numerous logs --fields path,status
+    numerous logs --raw
+
+To print log entries as JSON objects, one per line, e.g. for processing with jq:
+
+    numerous logs --output json | jq -r .text
+
+To read the logs of all apps in an organization, except apps with slugs
+starting with "test-":
+
+    numerous logs --all --organization "organization-slug-a2ecf59b" --exclude "test-*"
+
+To record the logs in a local archive, and later search the logs recorded in
+the last 7 days:
+
+    numerous logs --record
+    numerous logs search "Exception|Timeout" --since 7d
+
 Otherwise, assuming an app has been initialized in the directory
 "my_project/my_app" and has a default deployment defined in its manifest:
 
@@ -55,12 +87,21 @@ var Cmd = &cobra.Command{
 	Args:    args.OptionalAppDir(&cmdArgs.appDir),
 }
 
-var cmdArgs struct {
+var cmdArgs = struct {
 	appIdent   args.AppIdentifierArg
 	timestamps bool
 	tail       int
 	follow     bool
 	appDir     string
+	filter     FilterArgs
+	format     FormatArgs
+	output     args.OutputFormatArg
+	all        bool
+	include    []string
+	exclude    []string
+	record     bool
+}{
+	output: args.NewOutputFormatArg(args.OutputFormatText, args.OutputFormatJSON),
 }
 
 func run(cmd *cobra.Command, args []string) error {
@@ -72,32 +113,104 @@ func run(cmd *cobra.Command, args []string) error {
 		println()
 	}
 
-	var printer func(app.AppDeployLogEntry)
-	if cmdArgs.timestamps {
-		printer = TimestampPrinter
-	} else {
-		printer = TextPrinter
+	printer := Printer(cmdArgs.format.Options(), cmdArgs.timestamps)
+
+	filter, err := cmdArgs.filter.Options()
+	if err != nil {
+		output.PrintErrorDetails("Invalid log filter", err)
+		return errorhandling.ErrorAlreadyPrinted(err)
+	}
+
+	if cmdArgs.all {
+		return runAll(cmd, args, filter)
 	}
+
+	var streamErr error
 	sc := gql.NewSubscriptionClient().WithSyncMode(true)
-	service := app.New(gql.NewClient(), sc, http.DefaultClient)
+	service := app.New(gql.NewClient(), sc, http.DefaultClient).WithReconnect(ReconnectOptions(func(err error) { streamErr = err }))
 
 	input := logsInput{
 		appDir:  cmdArgs.appDir,
 		orgSlug: cmdArgs.appIdent.OrganizationSlug,
+		env:     cmdArgs.appIdent.Environment,
 		appSlug: cmdArgs.appIdent.AppSlug,
 		tail:    cmdArgs.tail,
 		follow:  cmdArgs.follow,
+		filter:  filter,
+		format:  cmdArgs.output.Format,
 		printer: printer,
+		archive: archive(),
+	}
+	err = logs(cmd.Context(), service, input)
+	if err == nil && streamErr != nil {
+		PrintConnectionLost(streamErr)
+		err = streamErr
 	}
-	err := logs(cmd.Context(), service, input)
 
 	return errorhandling.ErrorAlreadyPrinted(err)
 }
 
+func runAll(cmd *cobra.Command, args []string, filter logfilter.Options) error {
+	if cmdArgs.appIdent.AppSlug != "" || len(args) > 0 {
+		output.PrintError("Cannot specify an app with --all", "The --all flag reads the logs of all apps in the organization.")
+		return errorhandling.ErrAlreadyPrinted
+	}
+
+	if cmdArgs.appIdent.Environment != "" {
+		output.PrintError("Cannot specify an environment with --all", "The --all flag reads the logs of all apps in the organization given with --organization.")
+		return errorhandling.ErrAlreadyPrinted
+	}
+
+	orgSlug := cmdArgs.appIdent.OrganizationSlug
+	if orgSlug == "" {
+		orgSlug = config.OrganizationSlug()
+	}
+
+	lister := app.New(gql.NewClient(), nil, http.DefaultClient)
+	newService := func(reconnect app.ReconnectOptions) appService {
+		return app.New(gql.NewClient(), gql.NewSubscriptionClient().WithSyncMode(true), http.DefaultClient).WithReconnect(reconnect)
+	}
+
+	input := allLogsInput{
+		orgSlug:    orgSlug,
+		include:    cmdArgs.include,
+		exclude:    cmdArgs.exclude,
+		tail:       cmdArgs.tail,
+		follow:     cmdArgs.follow,
+		timestamps: cmdArgs.timestamps,
+		filter:     filter,
+		format:     cmdArgs.output.Format,
+		lineFormat: cmdArgs.format.Options(),
+		archive:    archive(),
+	}
+	err := allLogs(cmd.Context(), lister, newService, input)
+
+	return errorhandling.ErrorAlreadyPrinted(err)
+}
+
+// archive returns the local log archive, if log entries are recorded.
+func archive() *logstore.Store {
+	if !cmdArgs.record {
+		return nil
+	}
+
+	return logstore.New(config.LogArchiveDir())
+}
+
 func init() {
 	flags := Cmd.Flags()
 	cmdArgs.appIdent.AddAppIdentifierFlags(flags, cmdActionText)
 	flags.BoolVarP(&cmdArgs.timestamps, "timestamps", "t", false, "Print a timestamp for each log entry.")
 	flags.IntVarP(&cmdArgs.tail, "tail", "n", 0, "Number of lines to show from the end")
 	flags.BoolVarP(&cmdArgs.follow, "follow", "f", true, "Continue streaming new log entries (default: true)")
+	cmdArgs.filter.AddFlags(flags)
+	cmdArgs.format.AddFlags(flags)
+	flags.BoolVar(&cmdArgs.all, "all", false, "Read the logs of all apps in the organization, or the configured default organization, merged into one stream where each line is prefixed with the app slug.")
+	flags.StringArrayVar(&cmdArgs.include, "include", nil, `With --all, only read the logs of apps with slugs matching this pattern, where "*" matches any characters, e.g. "api-*". Can be repeated.`)
+	flags.StringArrayVar(&cmdArgs.exclude, "exclude", nil, "With --all, do not read the logs of apps with slugs matching this pattern. Can be repeated.")
+	flags.BoolVar(&cmdArgs.record, "record", false, "Record all received log entries, before filtering, in a local log archive, which can be searched with 'numerous logs search'.")
+	flags.Var(&cmdArgs.output, "output", `The output format, either "text" or "json". With "json", each log entry is printed as a JSON object on a separate line, with its timestamp, text, organization and app.`)
+
+	Cmd.AddCommand(searchCmd)
+	Cmd.AddCommand(forwardCmd)
 }
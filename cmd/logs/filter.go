@@ -0,0 +1,80 @@
+package logs
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/internal/logfilter"
+	"numerous.com/cli/internal/logging"
+	"numerous.com/cli/internal/output"
+
+	"github.com/spf13/pflag"
+)
+
+var ErrInvalidPattern = errors.New("invalid pattern")
+
+// FilterArgs holds the log filtering flags, which are shared by the commands
+// reading logs.
+type FilterArgs struct {
+	since   args.Since
+	until   args.Since
+	grep    []string
+	grepV   []string
+	level   logging.Level
+	context int
+}
+
+const timeFlagFormats = `Can be an RFC3339 timestamp (e.g. "2024-01-01T12:00:00Z"), a date (e.g. "2024-06-06"), or a duration of seconds, minutes, hours or days ago (e.g. "1s", "10m", "5h", "2d").`
+
+func (f *FilterArgs) AddFlags(flags *pflag.FlagSet) {
+	flags.Var(&f.since, "since", "Only show log entries since this time. "+timeFlagFormats)
+	flags.Var(&f.until, "until", "Only show log entries until this time, and stop reading logs after it. "+timeFlagFormats)
+	flags.StringArrayVar(&f.grep, "grep", nil, "Only show log entries matching this regular expression. Can be repeated to require several matches.")
+	flags.StringArrayVar(&f.grepV, "grep-v", nil, "Do not show log entries matching this regular expression. Can be repeated.")
+	flags.Var(&f.level, "level", `Only show log entries with at least this log level, one of "debug", "info", "warning" or "error". The level is detected from markers like "ERROR" or "level=error", and lines without a marker, like traceback lines, have the level of the previous line.`)
+	flags.IntVarP(&f.context, "context", "C", 0, "Number of log entries to show before and after each matching entry.")
+}
+
+// Options returns the filter options of the flags, or an error if a pattern
+// is not a valid regular expression.
+func (f *FilterArgs) Options() (logfilter.Options, error) {
+	grep, err := compilePatterns(f.grep)
+	if err != nil {
+		return logfilter.Options{}, err
+	}
+
+	grepV, err := compilePatterns(f.grepV)
+	if err != nil {
+		return logfilter.Options{}, err
+	}
+
+	return logfilter.Options{
+		Since:   f.since.Time(),
+		Until:   f.until.Time(),
+		Grep:    grep,
+		GrepV:   grepV,
+		Level:   f.level,
+		Context: f.context,
+	}, nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidPattern, pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+
+	return compiled, nil
+}
+
+// PrintSeparator prints the separator between log entries that are not
+// consecutive, when showing context around matching entries.
+func PrintSeparator() {
+	fmt.Println(output.AnsiFaint + "--" + output.AnsiReset)
+}
@@ -0,0 +1,40 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"numerous.com/cli/internal/logging"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterArgs(t *testing.T) {
+	t.Run("returns options of the flags", func(t *testing.T) {
+		var args FilterArgs
+		flags := pflag.NewFlagSet("logs", pflag.ContinueOnError)
+		args.AddFlags(flags)
+
+		err := flags.Parse([]string{"--since", "2024-03-01", "--grep", "GET", "--grep-v", "/healthz", "--level", "warning", "-C", "2"})
+		require.NoError(t, err)
+		opts, err := args.Options()
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), *opts.Since)
+		assert.Nil(t, opts.Until)
+		assert.Equal(t, "GET", opts.Grep[0].String())
+		assert.Equal(t, "/healthz", opts.GrepV[0].String())
+		assert.Equal(t, logging.LevelWarning, opts.Level)
+		assert.Equal(t, 2, opts.Context)
+	})
+
+	t.Run("returns error for invalid pattern", func(t *testing.T) {
+		args := FilterArgs{grepV: []string{"("}}
+
+		_, err := args.Options()
+
+		assert.ErrorIs(t, err, ErrInvalidPattern)
+	})
+}
@@ -0,0 +1,36 @@
+package logs
+
+import (
+	"time"
+
+	"numerous.com/cli/internal/logformat"
+
+	"github.com/spf13/pflag"
+)
+
+// FormatArgs holds the flags for formatting JSON log lines, which are shared
+// by the commands reading logs.
+type FormatArgs struct {
+	fields []string
+	raw    bool
+}
+
+func (f *FormatArgs) AddFlags(flags *pflag.FlagSet) {
+	flags.StringSliceVar(&f.fields, "fields", nil, `Only show these fields of JSON log lines, in addition to the level and message, e.g. "user,path".`)
+	flags.BoolVar(&f.raw, "raw", false, "Print JSON log lines as they are, instead of formatting them as level, message and fields.")
+}
+
+func (f *FormatArgs) Options() logformat.Options {
+	return logformat.Options{Fields: f.fields, Raw: f.raw}
+}
+
+// FormatLine returns the text to print for the log line, and the timestamp to
+// show, which is the timestamp of the log line itself, if it has one.
+func FormatLine(timestamp time.Time, text string, opts logformat.Options) (time.Time, string) {
+	formatted, lineTimestamp := logformat.Format(text, opts)
+	if lineTimestamp != nil {
+		timestamp = *lineTimestamp
+	}
+
+	return timestamp, formatted
+}
@@ -0,0 +1,74 @@
+package logs
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/logformat"
+	"numerous.com/cli/internal/output"
+	"numerous.com/cli/internal/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatLine(t *testing.T) {
+	platformTimestamp := time.Date(2024, time.March, 1, 12, 0, 1, 0, time.UTC)
+
+	t.Run("prefers the timestamp of JSON log lines", func(t *testing.T) {
+		timestamp, text := FormatLine(platformTimestamp, `{"time": "2024-03-01T11:59:59Z", "msg": "started"}`, logformat.Options{})
+
+		assert.Equal(t, time.Date(2024, time.March, 1, 11, 59, 59, 0, time.UTC), timestamp)
+		assert.Equal(t, "started", text)
+	})
+
+	t.Run("keeps plain text lines and their timestamp", func(t *testing.T) {
+		timestamp, text := FormatLine(platformTimestamp, "started", logformat.Options{})
+
+		assert.Equal(t, platformTimestamp, timestamp)
+		assert.Equal(t, "started", text)
+	})
+}
+
+func TestPrinter(t *testing.T) {
+	entry := app.AppDeployLogEntry{
+		Timestamp: time.Date(2024, time.March, 1, 12, 0, 1, 0, time.UTC),
+		Text:      `{"level": "warning", "msg": "slow request", "path": "/", "duration": 1.5, "time": "2024-03-01T12:00:00Z"}`,
+	}
+
+	for _, tc := range []struct {
+		name       string
+		opts       logformat.Options
+		timestamps bool
+		expected   string
+	}{
+		{
+			name:     "formats JSON log lines",
+			expected: output.AnsiYellow + "WARNING" + output.AnsiReset + " slow request " + output.AnsiFaint + "path=" + output.AnsiReset + "/ " + output.AnsiFaint + "duration=" + output.AnsiReset + "1.5\n",
+		},
+		{
+			name:       "prints the timestamp of the log line and selected fields",
+			opts:       logformat.Options{Fields: []string{"duration"}},
+			timestamps: true,
+			expected:   output.AnsiFaint + "2024-03-01T12:00:00Z" + output.AnsiReset + " " + output.AnsiYellow + "WARNING" + output.AnsiReset + " slow request " + output.AnsiFaint + "duration=" + output.AnsiReset + "1.5\n",
+		},
+		{
+			name:     "prints raw log lines",
+			opts:     logformat.Options{Raw: true},
+			expected: entry.Text + "\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, err := test.RunEWithPatchedStdout(t, func() error {
+				Printer(tc.opts, tc.timestamps)(entry)
+				return nil
+			})
+
+			require.NoError(t, err)
+			out, _ := io.ReadAll(stdout)
+			assert.Equal(t, tc.expected, string(out))
+		})
+	}
+}
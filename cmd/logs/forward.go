@@ -0,0 +1,262 @@
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/cmd/errorhandling"
+	"numerous.com/cli/cmd/usage"
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/appident"
+	"numerous.com/cli/internal/config"
+	"numerous.com/cli/internal/gql"
+	"numerous.com/cli/internal/logforward"
+	"numerous.com/cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+// resubscribeDelay is how long to wait before subscribing to the logs again,
+// after reconnecting to the subscription gave up.
+var resubscribeDelay = 30 * time.Second
+
+var (
+	ErrNoSinks         = errors.New("no log sinks")
+	ErrInvalidHeader   = errors.New("invalid header")
+	ErrInvalidBatching = errors.New("invalid batching")
+	errLogsEnded       = errors.New("the logs subscription ended")
+)
+
+const forwardLongFormat string = `Forward the logs of an application to external sinks, continuously.
+
+The command runs until it is stopped, sending log entries in batches, and
+retrying batches which could not be sent. If the connection to the logs is
+lost, it is established again. After each batch, the position of the last
+forwarded entry is saved in a checkpoint, so forwarding resumes where it
+stopped when the command is restarted. Entries may be sent more than once, if
+a sink fails after accepting part of a batch.
+
+Sinks are given as URLs with --sink, which can be repeated:
+
+  syslog+udp://host:514, syslog+tcp://host:601
+    RFC 5424 syslog messages over UDP, or TCP with octet counting framing.
+
+  otlp+http://host:4318/v1/logs, otlp+https://...
+    OpenTelemetry logs, as OTLP/HTTP JSON requests.
+
+  loki+http://host:3100/loki/api/v1/push, loki+https://...
+    Loki push requests, with "organization" and "app" labels.
+
+  file:///var/log/numerous/app.log?max-size=100MB&max-files=5
+    JSON lines in a file, which is rotated when it reaches the maximum size.
+
+%s
+
+%s
+`
+
+var forwardLong string = fmt.Sprintf(forwardLongFormat, usage.AppIdentifier(forwardActionText), usage.AppDirectoryArgument)
+
+const forwardActionText string = "to forward logs from"
+
+const forwardExample string = `To forward the logs of an app to a Loki instance with a tenant ID:
+
+    numerous logs forward --organization "organization-slug-a2ecf59b" --app "my-app" \
+        --sink "loki+https://loki.example.com" --header "X-Scope-OrgID: my-tenant"
+
+To forward the logs to a syslog server and to rotating files:
+
+    numerous logs forward --sink "syslog+tcp://syslog.example.com:601" \
+        --sink "file:///var/log/numerous/my-app.log?max-size=50MB"
+`
+
+var forwardCmd = &cobra.Command{
+	Use:     "forward [app directory]",
+	RunE:    runForward,
+	Short:   "Forward application logs to external sinks",
+	Long:    forwardLong,
+	Example: forwardExample,
+	Args:    args.OptionalAppDir(&forwardArgs.appDir),
+}
+
+var forwardArgs = struct {
+	appIdent      args.AppIdentifierArg
+	appDir        string
+	sinks         []string
+	headers       []string
+	tail          int
+	batchSize     int
+	flushInterval time.Duration
+	checkpoint    string
+}{
+	batchSize:     100,
+	flushInterval: time.Second,
+}
+
+func runForward(cmd *cobra.Command, args []string) error {
+	headers, err := parseHeaders(forwardArgs.headers)
+	if err != nil {
+		output.PrintErrorDetails("Invalid header", err)
+		return errorhandling.ErrorAlreadyPrinted(err)
+	}
+
+	newService := func(reconnect app.ReconnectOptions) appService {
+		return app.New(gql.NewClient(), gql.NewSubscriptionClient().WithSyncMode(true), http.DefaultClient).WithReconnect(reconnect)
+	}
+
+	input := forwardInput{
+		appDir:         forwardArgs.appDir,
+		orgSlug:        forwardArgs.appIdent.OrganizationSlug,
+		env:            forwardArgs.appIdent.Environment,
+		appSlug:        forwardArgs.appIdent.AppSlug,
+		sinks:          forwardArgs.sinks,
+		headers:        headers,
+		tail:           forwardArgs.tail,
+		batchSize:      forwardArgs.batchSize,
+		flushInterval:  forwardArgs.flushInterval,
+		checkpointPath: forwardArgs.checkpoint,
+	}
+	err = forward(cmd.Context(), newService, input)
+
+	return errorhandling.ErrorAlreadyPrinted(err)
+}
+
+// parseHeaders parses headers of the form "Name: value".
+func parseHeaders(values []string) (http.Header, error) {
+	headers := http.Header{}
+	for _, v := range values {
+		name, value, ok := strings.Cut(v, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf(`%w %q: must be of the form "Name: value"`, ErrInvalidHeader, v)
+		}
+		headers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	return headers, nil
+}
+
+type forwardInput struct {
+	appDir         string
+	orgSlug        string
+	env            string
+	appSlug        string
+	sinks          []string
+	headers        http.Header
+	tail           int
+	batchSize      int
+	flushInterval  time.Duration
+	checkpointPath string
+}
+
+// forward forwards the logs of the app to the sinks, until the context is
+// cancelled, or a batch cannot be sent because of a permanent error. When
+// reconnecting to the logs gives up, it subscribes to the logs again after a
+// delay, so it keeps running through longer outages.
+func forward(ctx context.Context, newService func(app.ReconnectOptions) appService, input forwardInput) error {
+	ai, err := appident.GetAppIdentifier(input.appDir, nil, input.env, input.orgSlug, input.appSlug)
+	if err != nil {
+		appident.PrintGetAppIdentifierError(err, input.appDir, ai)
+		return err
+	}
+
+	if len(input.sinks) == 0 {
+		output.PrintError("Missing log sink", "Specify where to forward logs with --sink, e.g. --sink \"loki+http://localhost:3100\".")
+		return ErrNoSinks
+	}
+
+	if input.batchSize < 1 || input.flushInterval <= 0 {
+		output.PrintError("Invalid batching", "The --batch-size and --flush-interval must be greater than zero.")
+		return ErrInvalidBatching
+	}
+
+	labels := logforward.Labels{Organization: ai.OrganizationSlug, App: ai.AppSlug}
+	sinks := make([]logforward.Sink, 0, len(input.sinks))
+	for _, spec := range input.sinks {
+		sink, err := logforward.ParseSink(spec, labels, input.headers)
+		if err != nil {
+			output.PrintErrorDetails("Invalid log sink", err)
+			return err
+		}
+		defer sink.Close()
+
+		sinks = append(sinks, sink)
+	}
+
+	checkpointPath := input.checkpointPath
+	if checkpointPath == "" {
+		checkpointPath = config.LogForwardCheckpointPath(ai.OrganizationSlug, ai.AppSlug)
+	}
+
+	checkpoint, err := logforward.LoadCheckpoint(checkpointPath)
+	if err != nil {
+		output.PrintErrorDetails("Error reading the log forwarding checkpoint %q", err, checkpointPath)
+		return err
+	}
+
+	forwarder := logforward.New(logforward.Options{
+		Sinks:         sinks,
+		Checkpoint:    checkpoint,
+		BatchSize:     input.batchSize,
+		FlushInterval: input.flushInterval,
+		OnRetry: func(sink logforward.Sink, err error, delay time.Duration) {
+			output.PrintWarning("Error sending logs to "+sink.Name(), fmt.Sprintf("Retrying in %s. Details: %s", delay, err))
+		},
+	})
+
+	// only the first subscription is limited by tail, later subscriptions
+	// resume from the checkpoint
+	var tail *int
+	if checkpoint.IsEmpty() && input.tail > 0 {
+		tail = &input.tail
+	}
+
+	output.Notify("Forwarding logs of the app %q", "Organization: %s\nCheckpoint: %s\n", ai.AppSlug, ai.OrganizationSlug, checkpointPath)
+	for subscribed := false; ; subscribed = true {
+		var lostErr error
+		ch, err := newService(ReconnectOptions(func(err error) { lostErr = err })).AppDeployLogs(ai, tail, true)
+		switch {
+		case err != nil && !subscribed:
+			app.PrintAppError(err, ai)
+			return err
+		case err != nil:
+			lostErr = err
+		default:
+			if err := forwarder.Run(ctx, ch); err != nil {
+				output.PrintErrorDetails("Error forwarding logs", err)
+				return err
+			}
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if lostErr == nil {
+			lostErr = errLogsEnded
+		}
+		output.PrintWarning("Lost the connection to the logs", fmt.Sprintf("Subscribing again in %s. Details: %s", resubscribeDelay, lostErr))
+
+		select {
+		case <-time.After(resubscribeDelay):
+		case <-ctx.Done():
+			return nil
+		}
+		tail = nil
+	}
+}
+
+func init() {
+	flags := forwardCmd.Flags()
+	forwardArgs.appIdent.AddAppIdentifierFlags(flags, forwardActionText)
+	flags.StringArrayVar(&forwardArgs.sinks, "sink", nil, "A URL of a sink to forward logs to. Can be repeated to forward logs to several sinks.")
+	flags.StringArrayVar(&forwardArgs.headers, "header", nil, `A header to send with requests to OTLP and Loki sinks, of the form "Name: value", e.g. for authentication. Can be repeated.`)
+	flags.IntVarP(&forwardArgs.tail, "tail", "n", 0, "When there is no checkpoint, only forward this number of entries from the end of the logs, before new entries.")
+	flags.IntVar(&forwardArgs.batchSize, "batch-size", forwardArgs.batchSize, "The maximum number of log entries sent to the sinks at once.")
+	flags.DurationVar(&forwardArgs.flushInterval, "flush-interval", forwardArgs.flushInterval, "How often log entries are sent to the sinks, if a batch is not full.")
+	flags.StringVar(&forwardArgs.checkpoint, "checkpoint", "", "The file, where the position of the last forwarded log entry is saved. Defaults to a file per app in the Numerous configuration directory.")
+}
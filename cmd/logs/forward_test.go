@@ -0,0 +1,122 @@
+package logs
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/appident"
+	"numerous.com/cli/internal/logforward"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForward(t *testing.T) {
+	const orgSlug = "organization-slug"
+	const appSlug = "app-slug"
+	ai := appident.AppIdentifier{OrganizationSlug: orgSlug, AppSlug: appSlug}
+	entry1 := app.AppDeployLogEntry{Timestamp: time.Date(2024, time.March, 1, 1, 0, 0, 0, time.UTC), Text: "entry 1"}
+	entry2 := app.AppDeployLogEntry{Timestamp: time.Date(2024, time.March, 1, 2, 0, 0, 0, time.UTC), Text: "entry 2"}
+
+	input := func(dir string) forwardInput {
+		return forwardInput{
+			orgSlug:        orgSlug,
+			appSlug:        appSlug,
+			sinks:          []string{"file://" + filepath.Join(dir, "app.log")},
+			batchSize:      10,
+			flushInterval:  time.Millisecond,
+			checkpointPath: filepath.Join(dir, "checkpoint.json"),
+		}
+	}
+
+	t.Run("forwards entries and resubscribes after the subscription ends", func(t *testing.T) {
+		origDelay := resubscribeDelay
+		resubscribeDelay = time.Millisecond
+		t.Cleanup(func() { resubscribeDelay = origDelay })
+
+		dir := t.TempDir()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		first := make(chan app.AppDeployLogEntry, 1)
+		first <- entry1
+		close(first)
+		second := make(chan app.AppDeployLogEntry, 2)
+		second <- entry1
+		second <- entry2
+
+		tail := 10
+		service := &AppServiceMock{}
+		service.On("AppDeployLogs", ai, &tail, true).Return(first, nil).Once()
+		service.On("AppDeployLogs", ai, (*int)(nil), true).Return(second, nil).Once().Run(func(_ mock.Arguments) {
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+				cancel()
+			}()
+		})
+		newService := func(app.ReconnectOptions) appService { return service }
+
+		in := input(dir)
+		in.tail = tail
+		err := forward(ctx, newService, in)
+
+		require.NoError(t, err)
+		service.AssertExpectations(t)
+		data, err := os.ReadFile(filepath.Join(dir, "app.log"))
+		require.NoError(t, err)
+		expected := `{"timestamp":"2024-03-01T01:00:00Z","text":"entry 1","organization":"organization-slug","app":"app-slug"}` + "\n" +
+			`{"timestamp":"2024-03-01T02:00:00Z","text":"entry 2","organization":"organization-slug","app":"app-slug"}` + "\n"
+		assert.Equal(t, expected, string(data))
+		checkpoint, err := logforward.LoadCheckpoint(in.checkpointPath)
+		require.NoError(t, err)
+		assert.Equal(t, entry2.Timestamp, checkpoint.Timestamp)
+	})
+
+	t.Run("given no sinks it returns error", func(t *testing.T) {
+		in := input(t.TempDir())
+		in.sinks = nil
+
+		err := forward(context.TODO(), nil, in)
+
+		assert.ErrorIs(t, err, ErrNoSinks)
+	})
+
+	t.Run("given invalid sink it returns error", func(t *testing.T) {
+		in := input(t.TempDir())
+		in.sinks = []string{"ftp://localhost"}
+
+		err := forward(context.TODO(), nil, in)
+
+		assert.ErrorIs(t, err, logforward.ErrInvalidSink)
+	})
+
+	t.Run("given app service error it returns error", func(t *testing.T) {
+		service := &AppServiceMock{}
+		service.On("AppDeployLogs", ai, (*int)(nil), true).Return((chan app.AppDeployLogEntry)(nil), app.ErrAccessDenied)
+
+		err := forward(context.TODO(), func(app.ReconnectOptions) appService { return service }, input(t.TempDir()))
+
+		assert.ErrorIs(t, err, app.ErrAccessDenied)
+	})
+}
+
+func TestParseHeaders(t *testing.T) {
+	t.Run("parses headers", func(t *testing.T) {
+		headers, err := parseHeaders([]string{"Authorization: Bearer token", "X-Scope-OrgID:tenant"})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.Header{"Authorization": {"Bearer token"}, "X-Scope-Orgid": {"tenant"}}, headers)
+	})
+
+	t.Run("returns error for invalid header", func(t *testing.T) {
+		_, err := parseHeaders([]string{"Authorization"})
+
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+}
@@ -2,11 +2,18 @@ package logs
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"time"
 
+	"numerous.com/cli/cmd/args"
 	"numerous.com/cli/internal/app"
 	"numerous.com/cli/internal/appident"
+	"numerous.com/cli/internal/logfilter"
+	"numerous.com/cli/internal/logformat"
+	"numerous.com/cli/internal/logstore"
 	"numerous.com/cli/internal/output"
 )
 
@@ -17,14 +24,19 @@ type appService interface {
 type logsInput struct {
 	appDir  string
 	orgSlug string
+	env     string
 	appSlug string
 	tail    int
 	follow  bool
+	filter  logfilter.Options
+	format  args.OutputFormat
 	printer func(app.AppDeployLogEntry)
+	// archive records all received log entries, before filtering, if not nil.
+	archive *logstore.Store
 }
 
 func logs(ctx context.Context, apps appService, input logsInput) error {
-	ai, err := appident.GetAppIdentifier(input.appDir, nil, input.orgSlug, input.appSlug)
+	ai, err := appident.GetAppIdentifier(input.appDir, nil, input.env, input.orgSlug, input.appSlug)
 	if err != nil {
 		appident.PrintGetAppIdentifierError(err, input.appDir, ai)
 		return err
@@ -35,30 +47,114 @@ func logs(ctx context.Context, apps appService, input logsInput) error {
 		tail = &input.tail
 	}
 
+	var recorder *logstore.Recorder
+	if input.archive != nil {
+		recorder, err = input.archive.Recorder(ai.OrganizationSlug, ai.AppSlug)
+		if err != nil {
+			printArchiveError(err)
+			return err
+		}
+		defer recorder.Close()
+	}
+
 	ch, err := apps.AppDeployLogs(ai, tail, input.follow)
 	if err != nil {
 		app.PrintAppError(err, ai)
 		return err
 	}
 
+	printer := input.printer
+	if input.format == args.OutputFormatJSON {
+		printer = JSONPrinter(os.Stdout, ai)
+	}
+
+	filter := logfilter.New(input.filter)
 	for {
 		select {
 		case entry, ok := <-ch:
 			if !ok {
 				return nil
 			}
-			input.printer(entry)
+
+			if err := recordEntry(recorder, entry); err != nil {
+				printArchiveError(err)
+				return err
+			}
+
+			if filter.PastUntil(logfilter.Entry(entry)) {
+				return nil
+			}
+
+			entries, separate := filter.Add(logfilter.Entry(entry))
+			if separate && input.format != args.OutputFormatJSON {
+				PrintSeparator()
+			}
+
+			for _, e := range entries {
+				printer(app.AppDeployLogEntry(e))
+			}
 		case <-ctx.Done():
 			return nil
 		}
 	}
 }
 
+// recordEntry records the entry in the log archive, if recording.
+func recordEntry(recorder *logstore.Recorder, entry app.AppDeployLogEntry) error {
+	if recorder == nil {
+		return nil
+	}
+
+	return recorder.Record(logstore.Entry(entry))
+}
+
+func printArchiveError(err error) {
+	output.PrintErrorDetails("Error recording logs in the local log archive", err)
+}
+
 func TimestampPrinter(entry app.AppDeployLogEntry) {
-	ts := output.AnsiFaint + entry.Timestamp.Format(time.RFC3339) + output.AnsiReset
-	fmt.Println(ts + " " + entry.Text)
+	printEntry(entry, logformat.Options{}, true)
 }
 
 func TextPrinter(entry app.AppDeployLogEntry) {
-	fmt.Println(entry.Text)
+	printEntry(entry, logformat.Options{}, false)
+}
+
+// Printer returns a printer of entries, which formats JSON log lines with the
+// options, and prints timestamps if enabled.
+func Printer(opts logformat.Options, timestamps bool) func(app.AppDeployLogEntry) {
+	return func(entry app.AppDeployLogEntry) {
+		printEntry(entry, opts, timestamps)
+	}
+}
+
+func printEntry(entry app.AppDeployLogEntry, opts logformat.Options, timestamps bool) {
+	timestamp, text := FormatLine(entry.Timestamp, entry.Text, opts)
+	if timestamps {
+		text = output.AnsiFaint + timestamp.Format(time.RFC3339) + output.AnsiReset + " " + text
+	}
+
+	fmt.Println(text)
+}
+
+type jsonLogEntry struct {
+	Timestamp    string `json:"timestamp"`
+	Text         string `json:"text"`
+	Organization string `json:"organization"`
+	App          string `json:"app"`
+}
+
+// JSONPrinter returns a printer writing each entry of the app logs as a JSON
+// object on a separate line.
+func JSONPrinter(w io.Writer, ai appident.AppIdentifier) func(app.AppDeployLogEntry) {
+	enc := json.NewEncoder(w)
+
+	return func(entry app.AppDeployLogEntry) {
+		enc.Encode(jsonLogEntry{ // nolint:errcheck
+			Timestamp:    entry.Timestamp.Format(time.RFC3339Nano),
+			Text:         entry.Text,
+			Organization: ai.OrganizationSlug,
+			App:          ai.AppSlug,
+		})
+	}
 }
@@ -3,12 +3,17 @@ package logs
 import (
 	"context"
 	"errors"
+	"io"
 	"testing"
 	"time"
 
+	"numerous.com/cli/cmd/args"
 	"numerous.com/cli/internal/app"
 	"numerous.com/cli/internal/appident"
 	"numerous.com/cli/internal/config"
+	"numerous.com/cli/internal/logfilter"
+	"numerous.com/cli/internal/logging"
+	"numerous.com/cli/internal/logstore"
 	"numerous.com/cli/internal/test"
 
 	"github.com/stretchr/testify/assert"
@@ -135,6 +140,70 @@ func TestLogs(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 
+	t.Run("prints filtered entries and stops after until time", func(t *testing.T) {
+		ch := make(chan app.AppDeployLogEntry)
+		apps := &AppServiceMock{}
+		apps.On("AppDeployLogs", ai, (*int)(nil), true).Return(ch, nil)
+
+		entry1 := app.AppDeployLogEntry{Timestamp: time.Date(2024, time.March, 1, 1, 0, 0, 0, time.UTC), Text: "ERROR: failed"}
+		entry2 := app.AppDeployLogEntry{Timestamp: time.Date(2024, time.March, 1, 2, 0, 0, 0, time.UTC), Text: "INFO: started"}
+		entry3 := app.AppDeployLogEntry{Timestamp: time.Date(2024, time.March, 1, 3, 0, 0, 0, time.UTC), Text: "ERROR: failed again"}
+		until := time.Date(2024, time.March, 1, 2, 30, 0, 0, time.UTC)
+		actual := []app.AppDeployLogEntry{}
+		printer := func(e app.AppDeployLogEntry) {
+			actual = append(actual, e)
+		}
+		go func() {
+			ch <- entry1
+			ch <- entry2
+			ch <- entry3
+		}()
+		filter := logfilter.Options{Until: &until, Level: logging.LevelError}
+		err := logs(context.TODO(), apps, logsInput{appDir: "", orgSlug: orgSlug, appSlug: appSlug, follow: true, filter: filter, printer: printer})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []app.AppDeployLogEntry{entry1}, actual)
+	})
+
+	t.Run("prints entries as JSON", func(t *testing.T) {
+		ch := make(chan app.AppDeployLogEntry, 1)
+		ch <- app.AppDeployLogEntry{Timestamp: time.Date(2024, time.March, 1, 1, 1, 1, 1, time.UTC), Text: "started"}
+		close(ch)
+		apps := &AppServiceMock{}
+		apps.On("AppDeployLogs", ai, (*int)(nil), true).Return(ch, nil)
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return logs(context.TODO(), apps, logsInput{orgSlug: orgSlug, appSlug: appSlug, follow: true, format: args.OutputFormatJSON, printer: dummyPrinter})
+		})
+
+		assert.NoError(t, err)
+		out, _ := io.ReadAll(stdout)
+		assert.JSONEq(t, `{"timestamp": "2024-03-01T01:01:01.000000001Z", "text": "started", "organization": "organization-slug", "app": "app-slug"}`, string(out))
+	})
+
+	t.Run("records all entries before filtering", func(t *testing.T) {
+		entry1 := app.AppDeployLogEntry{Timestamp: time.Date(2024, time.March, 1, 1, 0, 0, 0, time.UTC), Text: "ERROR: failed"}
+		entry2 := app.AppDeployLogEntry{Timestamp: time.Date(2024, time.March, 1, 2, 0, 0, 0, time.UTC), Text: "INFO: started"}
+		ch := make(chan app.AppDeployLogEntry, 2)
+		ch <- entry1
+		ch <- entry2
+		close(ch)
+		apps := &AppServiceMock{}
+		apps.On("AppDeployLogs", ai, (*int)(nil), true).Return(ch, nil)
+		archive := logstore.New(t.TempDir())
+
+		filter := logfilter.Options{Level: logging.LevelError}
+		err := logs(context.TODO(), apps, logsInput{orgSlug: orgSlug, appSlug: appSlug, follow: true, filter: filter, printer: dummyPrinter, archive: archive})
+
+		assert.NoError(t, err)
+		recorded, err := archive.Search(logstore.SearchOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, []logstore.Result{
+			{OrganizationSlug: orgSlug, AppSlug: appSlug, Entry: logstore.Entry(entry1)},
+			{OrganizationSlug: orgSlug, AppSlug: appSlug, Entry: logstore.Entry(entry2)},
+		}, recorded)
+	})
+
 	t.Run("calls service with correct tail and follow parameters", func(t *testing.T) {
 		closedCh := make(chan app.AppDeployLogEntry)
 		close(closedCh)
@@ -1,6 +1,8 @@
 package logs
 
 import (
+	"context"
+
 	"numerous.com/cli/internal/app"
 	"numerous.com/cli/internal/appident"
 
@@ -18,3 +20,15 @@ func (m *AppServiceMock) AppDeployLogs(ai appident.AppIdentifier, tail *int, fol
 	args := m.Called(ai, tail, follow)
 	return args.Get(0).(chan app.AppDeployLogEntry), args.Error(1)
 }
+
+var _ appLister = &AppListerMock{}
+
+type AppListerMock struct {
+	mock.Mock
+}
+
+// List implements appLister.
+func (m *AppListerMock) List(ctx context.Context, organizationSlug string) ([]app.ListApp, error) {
+	args := m.Called(ctx, organizationSlug)
+	return args.Get(0).([]app.ListApp), args.Error(1)
+}
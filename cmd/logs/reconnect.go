@@ -0,0 +1,33 @@
+package logs
+
+import (
+	"fmt"
+	"os"
+
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/gql"
+	"numerous.com/cli/internal/output"
+)
+
+// ReconnectOptions returns the options for resubscribing to followed logs,
+// when the connection is lost, which print a marker to standard error when
+// reconnected. If reconnecting gives up, onGiveUp is called with the error.
+func ReconnectOptions(onGiveUp func(err error)) app.ReconnectOptions {
+	return app.ReconnectOptions{
+		NewSubscription: func() app.SubscriptionClient {
+			return gql.NewSubscriptionClient().WithSyncMode(true)
+		},
+		OnReconnect: func() { PrintReconnected("") },
+		OnGiveUp:    onGiveUp,
+	}
+}
+
+// PrintReconnected prints a marker showing that the connection to the logs
+// was lost and established again, where entries may have been missed.
+func PrintReconnected(prefix string) {
+	fmt.Fprintln(os.Stderr, prefix+output.AnsiFaint+"-- reconnected, entries may have been missed --"+output.AnsiReset)
+}
+
+func PrintConnectionLost(err error) {
+	output.PrintErrorDetails("Lost the connection to the logs", err)
+}
@@ -0,0 +1,138 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/cmd/errorhandling"
+	"numerous.com/cli/internal/config"
+	"numerous.com/cli/internal/logstore"
+	"numerous.com/cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+const searchLong string = `Search the logs recorded in the local log archive with 'numerous logs --record'.
+
+The query is a regular expression, which is matched against the text of each
+recorded log entry. Matching entries of all recorded apps are printed in the
+order of their timestamps, prefixed with the organization and app slugs.
+`
+
+const searchExample string = `To search the logs recorded in the last 7 days for errors:
+
+    numerous logs search "ERROR|Traceback" --since 7d
+
+To search the recorded logs of a specific app, ignoring case:
+
+    numerous logs search "timeout" --ignore-case --organization "organization-slug-a2ecf59b" --app "my-app"
+`
+
+var searchCmd = &cobra.Command{
+	Use:     "search <query>",
+	RunE:    runSearch,
+	Short:   "Search the logs recorded in the local log archive",
+	Long:    searchLong,
+	Example: searchExample,
+	Args:    cobra.ExactArgs(1),
+}
+
+var searchArgs = struct {
+	since      args.Since
+	until      args.Since
+	orgSlug    string
+	appSlug    string
+	ignoreCase bool
+	output     args.OutputFormatArg
+}{
+	output: args.NewOutputFormatArg(args.OutputFormatText, args.OutputFormatJSON),
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	input := searchInput{
+		query:      args[0],
+		ignoreCase: searchArgs.ignoreCase,
+		since:      searchArgs.since.Time(),
+		until:      searchArgs.until.Time(),
+		orgSlug:    searchArgs.orgSlug,
+		appSlug:    searchArgs.appSlug,
+		format:     searchArgs.output.Format,
+	}
+	err := search(os.Stdout, logstore.New(config.LogArchiveDir()), input)
+
+	return errorhandling.ErrorAlreadyPrinted(err)
+}
+
+type searchInput struct {
+	query      string
+	ignoreCase bool
+	since      *time.Time
+	until      *time.Time
+	orgSlug    string
+	appSlug    string
+	format     args.OutputFormat
+}
+
+func search(w io.Writer, archive *logstore.Store, input searchInput) error {
+	query := input.query
+	if input.ignoreCase {
+		query = "(?i)" + query
+	}
+
+	patterns, err := compilePatterns([]string{query})
+	if err != nil {
+		output.PrintErrorDetails("Invalid search query", err)
+		return err
+	}
+
+	results, err := archive.Search(logstore.SearchOptions{
+		Query:            patterns[0],
+		Since:            input.since,
+		Until:            input.until,
+		OrganizationSlug: input.orgSlug,
+		AppSlug:          input.appSlug,
+	})
+	if err != nil {
+		output.PrintErrorDetails("Error searching the local log archive", err)
+		return err
+	}
+
+	if len(results) == 0 && input.format != args.OutputFormatJSON {
+		output.Notify("No recorded log entries match the query", "Logs are recorded in the archive when reading them with 'numerous logs --record'.")
+		return nil
+	}
+
+	enc := json.NewEncoder(w)
+	for _, r := range results {
+		if input.format == args.OutputFormatJSON {
+			enc.Encode(jsonLogEntry{ // nolint:errcheck
+				Timestamp:    r.Timestamp.Format(time.RFC3339Nano),
+				Text:         r.Text,
+				Organization: r.OrganizationSlug,
+				App:          r.AppSlug,
+			})
+
+			continue
+		}
+
+		ts := output.AnsiFaint + r.Timestamp.Format(time.RFC3339) + output.AnsiReset
+		prefix := output.AnsiCyan + "[" + r.OrganizationSlug + "/" + r.AppSlug + "]" + output.AnsiReset
+		fmt.Fprintln(w, ts+" "+prefix+" "+r.Text)
+	}
+
+	return nil
+}
+
+func init() {
+	flags := searchCmd.Flags()
+	flags.Var(&searchArgs.since, "since", "Only search log entries since this time. "+timeFlagFormats)
+	flags.Var(&searchArgs.until, "until", "Only search log entries until this time. "+timeFlagFormats)
+	flags.StringVarP(&searchArgs.orgSlug, "organization", "o", "", "Only search the recorded logs of apps in this organization.")
+	flags.StringVarP(&searchArgs.appSlug, "app", "a", "", "Only search the recorded logs of apps with this slug.")
+	flags.BoolVarP(&searchArgs.ignoreCase, "ignore-case", "i", false, "Match the query regardless of case.")
+	flags.Var(&searchArgs.output, "output", `The output format, either "text" or "json". With "json", each matching log entry is printed as a JSON object on a separate line, with its timestamp, text, organization and app.`)
+}
@@ -0,0 +1,51 @@
+package logs
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/internal/logstore"
+	"numerous.com/cli/internal/output"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	ts := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	archive := logstore.New(t.TempDir())
+	for _, appSlug := range []string{"app-1", "app-2"} {
+		r, err := archive.Recorder("org", appSlug)
+		require.NoError(t, err)
+		require.NoError(t, r.Record(logstore.Entry{Timestamp: ts, Text: appSlug + " started"}))
+		require.NoError(t, r.Record(logstore.Entry{Timestamp: ts.Add(time.Hour), Text: appSlug + " ERROR: failed"}))
+		require.NoError(t, r.Close())
+	}
+
+	t.Run("prints matching entries", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := search(&buf, archive, searchInput{query: "error", ignoreCase: true, appSlug: "app-2"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, output.AnsiFaint+"2024-03-01T13:00:00Z"+output.AnsiReset+" "+output.AnsiCyan+"[org/app-2]"+output.AnsiReset+" app-2 ERROR: failed\n", buf.String())
+	})
+
+	t.Run("prints matching entries as JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		since := ts.Add(time.Minute)
+
+		err := search(&buf, archive, searchInput{query: "app-1", since: &since, format: args.OutputFormatJSON})
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"timestamp": "2024-03-01T13:00:00Z", "text": "app-1 ERROR: failed", "organization": "org", "app": "app-1"}`, buf.String())
+	})
+
+	t.Run("returns error for invalid query", func(t *testing.T) {
+		err := search(&bytes.Buffer{}, archive, searchInput{query: "("})
+
+		assert.ErrorIs(t, err, ErrInvalidPattern)
+	})
+}
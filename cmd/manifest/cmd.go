@@ -0,0 +1,26 @@
+package manifest
+
+import (
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/cmd/group"
+	"numerous.com/cli/cmd/manifest/migrate"
+	"numerous.com/cli/cmd/manifest/schema"
+	"numerous.com/cli/cmd/manifest/show"
+	"numerous.com/cli/cmd/manifest/validate"
+
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "manifest",
+	Short:   "Inspect, validate and migrate the app manifest",
+	Args:    args.SubCommandRequired,
+	GroupID: group.AdditionalCommandsGroupID,
+}
+
+func init() {
+	Cmd.AddCommand(show.Cmd)
+	Cmd.AddCommand(validate.Cmd)
+	Cmd.AddCommand(schema.Cmd)
+	Cmd.AddCommand(migrate.Cmd)
+}
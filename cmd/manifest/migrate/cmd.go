@@ -0,0 +1,50 @@
+package migrate
+
+import (
+	"github.com/spf13/cobra"
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/cmd/errorhandling"
+)
+
+const long = `Upgrade the app manifest file "numerous.toml" from a deprecated format to the
+current format.
+
+The changes to the manifest are shown as a diff, and must be confirmed before
+the manifest is written. Comments and formatting in the manifest are kept where
+possible.
+
+If [app directory] is specified, the manifest in that directory is migrated,
+otherwise the manifest in the current working directory is migrated.
+`
+
+var cmdArgs struct {
+	appDir string
+	yes    bool
+	dryRun bool
+}
+
+var Cmd = &cobra.Command{
+	Use:   "migrate [app directory]",
+	Short: "Upgrade the app manifest to the current format",
+	Long:  long,
+	Args:  args.OptionalAppDir(&cmdArgs.appDir),
+	RunE:  run,
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	input := migrateInput{
+		appDir:    cmdArgs.appDir,
+		dryRun:    cmdArgs.dryRun,
+		yes:       cmdArgs.yes,
+		confirmer: surveyConfirmWrite,
+	}
+	err := migrate(input)
+
+	return errorhandling.ErrorAlreadyPrinted(err)
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.BoolVarP(&cmdArgs.yes, "yes", "y", false, "Write the migrated manifest without asking for confirmation.")
+	flags.BoolVar(&cmdArgs.dryRun, "dry-run", false, "Only show the changes to the manifest, without writing them.")
+}
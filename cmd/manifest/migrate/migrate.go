@@ -0,0 +1,120 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/pmezard/go-difflib/difflib"
+	"numerous.com/cli/internal/manifest"
+	"numerous.com/cli/internal/output"
+)
+
+const diffContextLines int = 3
+
+var errMigrationCancelled = errors.New("migration cancelled")
+
+type migrateInput struct {
+	appDir    string
+	dryRun    bool
+	yes       bool
+	confirmer func(manifestPath string) bool
+}
+
+func migrate(input migrateInput) error {
+	manifestPath := filepath.Join(input.appDir, manifest.ManifestFileName)
+
+	info, err := os.Stat(manifestPath)
+	if errors.Is(err, os.ErrNotExist) {
+		output.PrintErrorAppNotInitialized(input.appDir)
+		return err
+	}
+
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		output.PrintErrorDetails("Error reading manifest %q", err, manifestPath)
+		return err
+	}
+
+	migrated, format, err := manifest.Migrate(string(content))
+	if err != nil {
+		if output.IsManifestTOMLError(err) {
+			output.PrintManifestTOMLError(err)
+		} else {
+			output.PrintErrorDetails("Error migrating manifest %q", err, manifestPath)
+		}
+
+		return err
+	}
+
+	if !format.IsDeprecated() {
+		output.PrintlnOK("The manifest %q already uses the current format", manifestPath)
+		return nil
+	}
+
+	fmt.Printf("Migrating manifest %q from the %s format to the current format.\n\n", manifestPath, format)
+	printDiff(string(content), migrated)
+
+	if input.dryRun {
+		output.Notify("Dry run", "No changes were written to %q.", manifestPath)
+		return nil
+	}
+
+	if !input.yes && !input.confirmer(manifestPath) {
+		output.PrintError("Migration cancelled", "The manifest %q was not changed.", manifestPath)
+		return errMigrationCancelled
+	}
+
+	if err := os.WriteFile(manifestPath, []byte(migrated), info.Mode().Perm()); err != nil {
+		output.PrintErrorDetails("Error writing manifest %q", err, manifestPath)
+		return err
+	}
+
+	output.PrintlnOK("Migrated manifest %q to the current format", manifestPath)
+
+	return nil
+}
+
+func printDiff(original, migrated string) {
+	diff := difflib.UnifiedDiff{
+		A:        difflib.SplitLines(original),
+		B:        difflib.SplitLines(migrated),
+		FromFile: "original/" + manifest.ManifestFileName,
+		ToFile:   "migrated/" + manifest.ManifestFileName,
+		Context:  diffContextLines,
+	}
+
+	text, err := difflib.GetUnifiedDiffString(diff)
+	if err != nil {
+		return
+	}
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			fmt.Print(output.AnsiFaint + line + output.AnsiReset)
+		case strings.HasPrefix(line, "+"):
+			fmt.Print(output.AnsiGreen + line + output.AnsiReset)
+		case strings.HasPrefix(line, "-"):
+			fmt.Print(output.AnsiRed + line + output.AnsiReset)
+		case strings.HasPrefix(line, "@@"):
+			fmt.Print(output.AnsiBlue + line + output.AnsiReset)
+		default:
+			fmt.Print(line)
+		}
+	}
+	fmt.Println()
+}
+
+func surveyConfirmWrite(manifestPath string) bool {
+	confirmed := false
+	msg := fmt.Sprintf("Write the migrated manifest to %q?", manifestPath)
+	if err := survey.AskOne(&survey.Confirm{Message: msg}, &confirmed); err != nil {
+		return false
+	}
+
+	return confirmed
+}
@@ -0,0 +1,129 @@
+package migrate
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"numerous.com/cli/internal/test"
+)
+
+const v1Manifest = `name = "App"
+# the library
+library = "streamlit"
+app_file = "app.py"
+port = 80
+`
+
+const migratedManifest = `name = "App"
+port = 80
+
+[python]
+  # the library
+  library = "streamlit"
+  app_file = "app.py"
+`
+
+func confirmAlways(string) bool { return true }
+
+func confirmNever(string) bool { return false }
+
+func TestMigrate(t *testing.T) {
+	t.Run("writes migrated manifest after confirmation", func(t *testing.T) {
+		appDir := t.TempDir()
+		manifestPath := filepath.Join(appDir, "numerous.toml")
+		test.WriteFile(t, manifestPath, []byte(v1Manifest))
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return migrate(migrateInput{appDir: appDir, confirmer: confirmAlways})
+		})
+
+		require.NoError(t, err)
+		test.AssertFileContent(t, manifestPath, []byte(migratedManifest))
+		out, err := io.ReadAll(stdout)
+		require.NoError(t, err)
+		assert.Contains(t, string(out), "+[python]")
+		assert.Contains(t, string(out), "-library = \"streamlit\"")
+	})
+
+	t.Run("writes without confirmation given yes", func(t *testing.T) {
+		appDir := t.TempDir()
+		manifestPath := filepath.Join(appDir, "numerous.toml")
+		test.WriteFile(t, manifestPath, []byte(v1Manifest))
+
+		_, err := test.RunEWithPatchedStdout(t, func() error {
+			return migrate(migrateInput{appDir: appDir, yes: true, confirmer: confirmNever})
+		})
+
+		require.NoError(t, err)
+		test.AssertFileContent(t, manifestPath, []byte(migratedManifest))
+	})
+
+	t.Run("does not write if not confirmed", func(t *testing.T) {
+		appDir := t.TempDir()
+		manifestPath := filepath.Join(appDir, "numerous.toml")
+		test.WriteFile(t, manifestPath, []byte(v1Manifest))
+
+		_, err := test.RunEWithPatchedStdout(t, func() error {
+			return migrate(migrateInput{appDir: appDir, confirmer: confirmNever})
+		})
+
+		assert.ErrorIs(t, err, errMigrationCancelled)
+		test.AssertFileContent(t, manifestPath, []byte(v1Manifest))
+	})
+
+	t.Run("does not write in dry run", func(t *testing.T) {
+		appDir := t.TempDir()
+		manifestPath := filepath.Join(appDir, "numerous.toml")
+		test.WriteFile(t, manifestPath, []byte(v1Manifest))
+
+		_, err := test.RunEWithPatchedStdout(t, func() error {
+			return migrate(migrateInput{appDir: appDir, dryRun: true, confirmer: confirmAlways})
+		})
+
+		require.NoError(t, err)
+		test.AssertFileContent(t, manifestPath, []byte(v1Manifest))
+	})
+
+	t.Run("leaves current format manifest unchanged", func(t *testing.T) {
+		appDir := t.TempDir()
+		manifestPath := filepath.Join(appDir, "numerous.toml")
+		test.WriteFile(t, manifestPath, []byte(migratedManifest))
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return migrate(migrateInput{appDir: appDir, confirmer: confirmNever})
+		})
+
+		require.NoError(t, err)
+		test.AssertFileContent(t, manifestPath, []byte(migratedManifest))
+		out, err := io.ReadAll(stdout)
+		require.NoError(t, err)
+		assert.Contains(t, string(out), "already uses the current format")
+	})
+
+	t.Run("returns error if app is not initialized", func(t *testing.T) {
+		_, err := test.RunEWithPatchedStdout(t, func() error {
+			return migrate(migrateInput{appDir: t.TempDir(), confirmer: confirmAlways})
+		})
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("prints invalid TOML error once", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.WriteFile(t, filepath.Join(appDir, "numerous.toml"), []byte("name = \"App\"\nlibrary = \n"))
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return migrate(migrateInput{appDir: appDir, confirmer: confirmAlways})
+		})
+
+		assert.Error(t, err)
+		out, _ := io.ReadAll(stdout)
+		assert.Equal(t, 1, strings.Count(string(out), err.Error()))
+		assert.NotContains(t, string(out), "Error migrating manifest")
+	})
+}
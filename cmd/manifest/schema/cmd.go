@@ -0,0 +1,39 @@
+package schema
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"numerous.com/cli/cmd/errorhandling"
+	"numerous.com/cli/internal/manifest"
+	"numerous.com/cli/internal/output"
+)
+
+const long = `Print the JSON schema of the app manifest file "numerous.toml".
+
+The schema can be used by editors to provide completion and validation of the
+manifest. For example, editors supporting TOML schemas can be pointed to it by
+adding the following comment at the top of "numerous.toml":
+
+  #:schema ./numerous.toml.schema.json
+`
+
+var Cmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON schema of the app manifest",
+	Long:  long,
+	Args:  cobra.NoArgs,
+	RunE:  run,
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	data, err := manifest.MarshalManifestJSONSchema()
+	if err != nil {
+		output.PrintUnknownError(err)
+		return errorhandling.ErrAlreadyPrinted
+	}
+
+	_, err = os.Stdout.Write(data)
+
+	return err
+}
@@ -0,0 +1,65 @@
+package show
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/cmd/errorhandling"
+	"numerous.com/cli/cmd/usage"
+)
+
+const cmdActionText = "to resolve the configuration of"
+
+const longFormat = `Print the app manifest file "numerous.toml".
+
+With --resolved, the effective configuration used when deploying the app is
+printed instead, where each value is annotated with its source: the manifest,
+a deploy environment, a library default, the CLI configuration, or a command
+line flag. Secret values are masked.
+
+%s
+
+%s
+`
+
+var long = fmt.Sprintf(longFormat, usage.AppIdentifier(cmdActionText), usage.AppDirectoryArgument)
+
+var cmdArgs = struct {
+	appIdent args.AppIdentifierArg
+	appDir   string
+	resolved bool
+	output   args.OutputFormatArg
+}{
+	output: args.NewOutputFormatArg(args.OutputFormatTOML, args.OutputFormatJSON),
+}
+
+var Cmd = &cobra.Command{
+	Use:   "show [app directory]",
+	Short: "Print the app manifest, or the resolved app configuration",
+	Long:  long,
+	Args:  args.OptionalAppDir(&cmdArgs.appDir),
+	RunE:  run,
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	input := showInput{
+		appDir:   cmdArgs.appDir,
+		orgSlug:  cmdArgs.appIdent.OrganizationSlug,
+		appSlug:  cmdArgs.appIdent.AppSlug,
+		env:      cmdArgs.appIdent.Environment,
+		resolved: cmdArgs.resolved,
+		format:   cmdArgs.output.Format,
+	}
+	err := show(os.Stdout, input)
+
+	return errorhandling.ErrorAlreadyPrinted(err)
+}
+
+func init() {
+	flags := Cmd.Flags()
+	cmdArgs.appIdent.AddAppIdentifierFlags(flags, cmdActionText)
+	flags.BoolVar(&cmdArgs.resolved, "resolved", false, "Print the resolved configuration, with the source of each value.")
+	flags.Var(&cmdArgs.output, "output", `The output format, either "toml" or "json".`)
+}
@@ -0,0 +1,216 @@
+package show
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/BurntSushi/toml"
+	"numerous.com/cli/internal/appident"
+	"numerous.com/cli/internal/config"
+	"numerous.com/cli/internal/dotenv"
+	"numerous.com/cli/internal/manifest"
+)
+
+// resolvedValue is a configuration value along with a description of where
+// it comes from.
+type resolvedValue struct {
+	Value  any    `json:"value"`
+	Source string `json:"source"`
+}
+
+// resolvedEntry is a resolved value of a key, which is in the given table,
+// or at the top level if table is empty.
+type resolvedEntry struct {
+	table string
+	key   string
+	resolvedValue
+}
+
+type resolver struct {
+	input   showInput
+	m       *manifest.Manifest
+	raw     map[string]any
+	source  string
+	entries []resolvedEntry
+	envFile string
+}
+
+// resolve returns the effective configuration of the app, in the order the
+// values are printed.
+func resolve(input showInput, manifestPath string, content string, m *manifest.Manifest) ([]resolvedEntry, error) {
+	format, err := manifest.DetectFormat(content)
+	if err != nil {
+		return nil, err
+	}
+
+	r := resolver{input: input, m: m, source: manifest.ManifestFileName}
+	if format.IsDeprecated() {
+		r.source = fmt.Sprintf("%s (converted from the deprecated %s format)", manifest.ManifestFileName, format)
+	}
+
+	if _, err := toml.Decode(content, &r.raw); err != nil {
+		return nil, err
+	}
+
+	r.resolveApp()
+	r.resolvePython(filepath.Dir(manifestPath))
+	r.resolveDocker()
+
+	if err := r.resolveDeploy(); err != nil {
+		return nil, err
+	}
+
+	if err := r.resolveEnv(); err != nil {
+		return nil, err
+	}
+
+	return r.entries, nil
+}
+
+func (r *resolver) add(table string, key string, value any, source string) {
+	r.entries = append(r.entries, resolvedEntry{table: table, key: key, resolvedValue: resolvedValue{Value: value, Source: source}})
+}
+
+func (r *resolver) resolveApp() {
+	r.add("", "name", r.m.Name, r.source)
+	r.add("", "description", r.m.Description, r.source)
+	r.add("", "cover_image", r.m.CoverImage, r.source)
+	r.add("", "exclude", r.m.Exclude, r.source)
+
+	switch {
+	case r.m.Port != 0:
+		r.add("", "port", r.m.Port, r.source)
+	case r.m.Python != nil:
+		r.add("", "port", r.m.Python.Library.Port, fmt.Sprintf("default port of the %s library", r.m.Python.Library.Name))
+	}
+}
+
+func (r *resolver) resolvePython(appDir string) {
+	p := r.m.Python
+	if p == nil {
+		return
+	}
+
+	r.add("python", "library", p.Library.Key, r.source)
+
+	if _, defined := r.lookupRaw("python", "version"); defined || r.source != manifest.ManifestFileName {
+		r.add("python", "version", p.Version, r.source)
+	} else if p.Version != "" {
+		r.add("python", "version", p.Version, fmt.Sprintf("requires-python in %s", filepath.Join(appDir, p.ProjectFile)))
+	}
+
+	r.add("python", "app_file", p.AppFile, r.source)
+
+	files := []struct{ key, value string }{
+		{"requirements_file", p.RequirementsFile},
+		{"project_file", p.ProjectFile},
+		{"lock_file", p.LockFile},
+	}
+	for _, f := range files {
+		if f.value != "" {
+			r.add("python", f.key, f.value, r.source)
+		}
+	}
+}
+
+func (r *resolver) resolveDocker() {
+	d := r.m.Docker
+	if d == nil {
+		return
+	}
+
+	r.add("docker", "dockerfile", d.Dockerfile, r.source)
+	if d.Context != "" {
+		r.add("docker", "context", d.Context, r.source)
+	}
+}
+
+func (r *resolver) resolveDeploy() error {
+	deployment, err := r.m.ResolveDeployEnvironment(r.input.env)
+	if err != nil {
+		return err
+	}
+
+	var env manifest.DeployEnvironment
+	envSource := ""
+	if r.input.env != "" {
+		env = r.m.Deployment.Environments[r.input.env]
+		envSource = fmt.Sprintf("%s [deploy.%s]", manifest.ManifestFileName, r.input.env)
+		r.add("deploy", "environment", r.input.env, "--env flag")
+	}
+	deploySource := manifest.ManifestFileName + " [deploy]"
+
+	switch {
+	case r.input.orgSlug != "":
+		r.add("deploy", "organization", r.input.orgSlug, "--organization flag")
+	case env.OrganizationSlug != "":
+		r.add("deploy", "organization", env.OrganizationSlug, envSource)
+	case deployment.OrganizationSlug != "":
+		r.add("deploy", "organization", deployment.OrganizationSlug, deploySource)
+	default:
+		if orgSlug := config.OrganizationSlug(); orgSlug != "" {
+			r.add("deploy", "organization", orgSlug, "default organization in the CLI configuration")
+		}
+	}
+
+	switch {
+	case r.input.appSlug != "":
+		r.add("deploy", "app", r.input.appSlug, "--app flag")
+	case env.AppSlug != "":
+		r.add("deploy", "app", env.AppSlug, envSource)
+	case deployment.AppSlug != "":
+		r.add("deploy", "app", deployment.AppSlug, deploySource)
+	default:
+		r.add("deploy", "app", appident.GetAppSlug(r.m, deployment.AppSlug), fmt.Sprintf("derived from the app name %q", r.m.Name))
+	}
+
+	switch {
+	case env.Size != nil:
+		r.add("deploy", "size", *env.Size, envSource)
+	case deployment.Size != nil:
+		r.add("deploy", "size", *deployment.Size, r.source)
+	}
+
+	if env.EnvFile != "" {
+		r.add("deploy", "env_file", env.EnvFile, envSource)
+	} else {
+		r.add("deploy", "env_file", deployment.EnvFile, "default")
+	}
+	r.envFile = deployment.EnvFile
+
+	return nil
+}
+
+func (r *resolver) resolveEnv() error {
+	secrets, err := dotenv.Load(filepath.Join(r.input.appDir, r.envFile))
+	if err != nil && (!errors.Is(err, os.ErrNotExist) || r.envFile != manifest.EnvFileName) {
+		return err
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(r.m.Env)) {
+		if _, isSecret := secrets[key]; !isSecret {
+			r.add("env", key, r.m.Env[key], r.source+" [env]")
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(secrets)) {
+		r.add("env", key, manifest.MaskedSecretValue, "secret in "+r.envFile)
+	}
+
+	return nil
+}
+
+func (r *resolver) lookupRaw(table string, key string) (any, bool) {
+	values, ok := r.raw[table].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	value, ok := values[key]
+
+	return value, ok
+}
@@ -0,0 +1,148 @@
+package show
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/internal/appident"
+	"numerous.com/cli/internal/manifest"
+	"numerous.com/cli/internal/output"
+)
+
+type showInput struct {
+	appDir   string
+	orgSlug  string
+	appSlug  string
+	env      string
+	resolved bool
+	format   args.OutputFormat
+}
+
+func show(w io.Writer, input showInput) error {
+	manifestPath := filepath.Join(input.appDir, manifest.ManifestFileName)
+	content, err := os.ReadFile(manifestPath)
+	if errors.Is(err, os.ErrNotExist) {
+		output.PrintErrorAppNotInitialized(input.appDir)
+		return err
+	} else if err != nil {
+		output.PrintErrorDetails("Error reading manifest %q", err, manifestPath)
+		return err
+	}
+
+	m, err := manifest.Load(manifestPath)
+	if err != nil {
+		output.PrintErrorDetails("Error loading manifest %q", err, manifestPath)
+		output.PrintManifestTOMLError(err)
+
+		return err
+	}
+
+	if !input.resolved {
+		return writeManifest(w, m, input.format)
+	}
+
+	entries, err := resolve(input, manifestPath, string(content), m)
+	if errors.Is(err, manifest.ErrDeployEnvironmentNotFound) {
+		appident.PrintGetAppIdentifierError(err, input.appDir, appident.AppIdentifier{})
+		return err
+	} else if err != nil {
+		output.PrintErrorDetails("Error resolving the app configuration", err)
+		return err
+	}
+
+	if input.format == args.OutputFormatJSON {
+		return writeResolvedJSON(w, entries)
+	}
+
+	return writeResolvedTOML(w, entries)
+}
+
+func writeManifest(w io.Writer, m *manifest.Manifest, format args.OutputFormat) error {
+	if format == args.OutputFormatJSON {
+		return writeJSON(w, m)
+	}
+
+	data, err := m.ToTOML()
+	if err != nil {
+		output.PrintErrorDetails("Error encoding manifest", err)
+		return err
+	}
+
+	_, err = io.WriteString(w, data)
+
+	return err
+}
+
+// writeResolvedTOML writes the entries as TOML, with the source of each value
+// in a comment after it.
+func writeResolvedTOML(w io.Writer, entries []resolvedEntry) error {
+	var b strings.Builder
+	table := ""
+	for _, e := range entries {
+		if e.table != table {
+			table = e.table
+			b.WriteString("\n[" + table + "]\n")
+		}
+
+		line, err := encodeKeyValue(e.key, e.Value)
+		if err != nil {
+			output.PrintErrorDetails("Error encoding value of %q", err, e.key)
+			return err
+		}
+		b.WriteString(line + " # " + e.Source + "\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+func encodeKeyValue(key string, value any) (string, error) {
+	buf := new(bytes.Buffer)
+	if err := toml.NewEncoder(buf).Encode(map[string]any{key: value}); err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(buf.String(), "\n"), nil
+}
+
+// writeResolvedJSON writes the entries as a JSON object, where each value is
+// an object with the value and its source.
+func writeResolvedJSON(w io.Writer, entries []resolvedEntry) error {
+	root := map[string]any{}
+	for _, e := range entries {
+		if e.table == "" {
+			root[e.key] = e.resolvedValue
+			continue
+		}
+
+		table, ok := root[e.table].(map[string]resolvedValue)
+		if !ok {
+			table = map[string]resolvedValue{}
+			root[e.table] = table
+		}
+		table[e.key] = e.resolvedValue
+	}
+
+	return writeJSON(w, root)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		output.PrintErrorDetails("Error encoding JSON", err)
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, string(data))
+
+	return err
+}
@@ -0,0 +1,162 @@
+package show
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/internal/config"
+	"numerous.com/cli/internal/manifest"
+	"numerous.com/cli/internal/test"
+)
+
+const manifestTOML = `name = "My App"
+description = "An app"
+cover_image = "cover.png"
+exclude = ["venv"]
+port = 0
+
+[python]
+library = "streamlit"
+app_file = "app.py"
+project_file = "pyproject.toml"
+
+[env]
+LOG_LEVEL = "debug"
+API_KEY = "not-secret"
+
+[deploy]
+organization = "manifest-org"
+
+[deploy.staging]
+app = "staging-app"
+size = "large"
+env_file = ".env.staging"
+`
+
+func writeApp(t *testing.T) string {
+	t.Helper()
+
+	appDir := t.TempDir()
+	test.WriteFile(t, filepath.Join(appDir, manifest.ManifestFileName), []byte(manifestTOML))
+	test.WriteFile(t, filepath.Join(appDir, "pyproject.toml"), []byte("[project]\nrequires-python = \">=3.12\"\ndependencies = [\"streamlit\"]\n"))
+	test.WriteFile(t, filepath.Join(appDir, ".env"), []byte("API_KEY=secret-value\n"))
+	test.WriteFile(t, filepath.Join(appDir, ".env.staging"), []byte("STAGING_KEY=staging-secret\n"))
+
+	return appDir
+}
+
+func TestShow(t *testing.T) {
+	t.Run("prints resolved configuration with sources", func(t *testing.T) {
+		appDir := writeApp(t)
+		buf := new(bytes.Buffer)
+
+		err := show(buf, showInput{appDir: appDir, resolved: true, format: args.OutputFormatTOML})
+
+		require.NoError(t, err)
+		expected := `name = "My App" # numerous.toml
+description = "An app" # numerous.toml
+cover_image = "cover.png" # numerous.toml
+exclude = ["venv"] # numerous.toml
+port = 80 # default port of the Streamlit library
+
+[python]
+library = "streamlit" # numerous.toml
+version = "3.12" # requires-python in ` + filepath.Join(appDir, "pyproject.toml") + `
+app_file = "app.py" # numerous.toml
+project_file = "pyproject.toml" # numerous.toml
+
+[deploy]
+organization = "manifest-org" # numerous.toml [deploy]
+app = "my-app" # derived from the app name "My App"
+env_file = ".env" # default
+
+[env]
+LOG_LEVEL = "debug" # numerous.toml [env]
+API_KEY = "********" # secret in .env
+`
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("prints resolved deploy environment and flags", func(t *testing.T) {
+		appDir := writeApp(t)
+		buf := new(bytes.Buffer)
+
+		err := show(buf, showInput{appDir: appDir, env: "staging", orgSlug: "flag-org", resolved: true, format: args.OutputFormatTOML})
+
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), `[deploy]
+environment = "staging" # --env flag
+organization = "flag-org" # --organization flag
+app = "staging-app" # numerous.toml [deploy.staging]
+size = "large" # numerous.toml [deploy.staging]
+env_file = ".env.staging" # numerous.toml [deploy.staging]
+
+[env]
+API_KEY = "not-secret" # numerous.toml [env]
+LOG_LEVEL = "debug" # numerous.toml [env]
+STAGING_KEY = "********" # secret in .env.staging
+`)
+	})
+
+	t.Run("uses organization from config", func(t *testing.T) {
+		oldConfigBaseDir := config.OverrideConfigBaseDir(t.TempDir())
+		t.Cleanup(func() { config.OverrideConfigBaseDir(oldConfigBaseDir) })
+		require.NoError(t, (&config.Config{OrganizationSlug: "config-org"}).Save())
+
+		appDir := t.TempDir()
+		test.WriteFile(t, filepath.Join(appDir, manifest.ManifestFileName), []byte("name = \"My App\"\n[docker]\ndockerfile = \"Dockerfile\"\n"))
+		buf := new(bytes.Buffer)
+
+		err := show(buf, showInput{appDir: appDir, resolved: true, format: args.OutputFormatTOML})
+
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), `organization = "config-org" # default organization in the CLI configuration`)
+	})
+
+	t.Run("prints resolved configuration as JSON", func(t *testing.T) {
+		appDir := writeApp(t)
+		buf := new(bytes.Buffer)
+
+		err := show(buf, showInput{appDir: appDir, appSlug: "flag-app", resolved: true, format: args.OutputFormatJSON})
+
+		require.NoError(t, err)
+		var actual map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+		assert.Equal(t, map[string]any{"value": "My App", "source": "numerous.toml"}, actual["name"])
+		assert.Equal(t, map[string]any{"value": "flag-app", "source": "--app flag"}, actual["deploy"].(map[string]any)["app"])
+		assert.Equal(t, map[string]any{"value": "********", "source": "secret in .env"}, actual["env"].(map[string]any)["API_KEY"])
+	})
+
+	t.Run("prints manifest when not resolved", func(t *testing.T) {
+		appDir := writeApp(t)
+		buf := new(bytes.Buffer)
+
+		err := show(buf, showInput{appDir: appDir, format: args.OutputFormatTOML})
+
+		require.NoError(t, err)
+		m, err := manifest.Load(filepath.Join(appDir, manifest.ManifestFileName))
+		require.NoError(t, err)
+		expected, err := m.ToTOML()
+		require.NoError(t, err)
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("returns error for unknown deploy environment", func(t *testing.T) {
+		appDir := writeApp(t)
+
+		err := show(new(bytes.Buffer), showInput{appDir: appDir, env: "unknown", resolved: true})
+
+		assert.ErrorIs(t, err, manifest.ErrDeployEnvironmentNotFound)
+	})
+
+	t.Run("returns error for missing manifest", func(t *testing.T) {
+		err := show(new(bytes.Buffer), showInput{appDir: t.TempDir(), resolved: true})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,35 @@
+package validate
+
+import (
+	"github.com/spf13/cobra"
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/cmd/errorhandling"
+)
+
+const long = `Validate the app manifest file "numerous.toml".
+
+Reports the detected manifest format version, keys which are not part of the
+manifest format, values of the wrong type, and missing required values, along
+with the line numbers they occur on.
+
+If [app directory] is specified, the manifest in that directory is validated,
+otherwise the manifest in the current working directory is validated.
+`
+
+var cmdArgs struct {
+	appDir string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "validate [app directory]",
+	Short: "Validate the app manifest",
+	Long:  long,
+	Args:  args.OptionalAppDir(&cmdArgs.appDir),
+	RunE:  run,
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	err := validate(cmdArgs.appDir)
+
+	return errorhandling.ErrorAlreadyPrinted(err)
+}
@@ -0,0 +1,62 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"numerous.com/cli/internal/manifest"
+	"numerous.com/cli/internal/output"
+)
+
+var errInvalidManifest = errors.New("invalid manifest")
+
+func validate(appDir string) error {
+	manifestPath := filepath.Join(appDir, manifest.ManifestFileName)
+
+	result, err := manifest.Validate(manifestPath)
+	if errors.Is(err, os.ErrNotExist) {
+		output.PrintErrorAppNotInitialized(appDir)
+		return err
+	} else if err != nil {
+		output.PrintErrorDetails("Error reading manifest %q", err, manifestPath)
+		return err
+	}
+
+	if result.Format.IsDeprecated() {
+		fmt.Printf("Detected manifest format: %s (deprecated)\n", result.Format)
+		output.PrintWarning("The manifest uses a deprecated format.", "Run \"numerous manifest migrate\" to upgrade it to the current format.")
+	} else if result.Format != manifest.FormatUnknown {
+		fmt.Printf("Detected manifest format: %s\n", result.Format)
+	}
+
+	if result.Valid() {
+		output.PrintlnOK("The manifest %q is valid", manifestPath)
+		return nil
+	}
+
+	output.PrintError("The manifest %q has %d problem(s)", formatIssues(manifestPath, result.Issues), manifestPath, len(result.Issues))
+
+	return errInvalidManifest
+}
+
+func formatIssues(manifestPath string, issues []manifest.ValidationIssue) string {
+	var b strings.Builder
+
+	for _, issue := range issues {
+		location := manifestPath
+		if issue.Line != 0 {
+			location = fmt.Sprintf("%s:%d", manifestPath, issue.Line)
+		}
+
+		if issue.Key != "" {
+			fmt.Fprintf(&b, "  %s: %s: %s\n", location, issue.Key, issue.Message)
+		} else {
+			fmt.Fprintf(&b, "  %s: %s\n", location, issue.Message)
+		}
+	}
+
+	return strings.ReplaceAll(b.String(), "%", "%%")
+}
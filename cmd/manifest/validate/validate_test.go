@@ -0,0 +1,67 @@
+package validate
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"numerous.com/cli/internal/test"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("succeeds for valid manifest", func(t *testing.T) {
+		appDir := t.TempDir()
+		content := "name = \"app\"\nport = 80\n\n[python]\nlibrary = \"streamlit\"\napp_file = \"app.py\"\n"
+		test.WriteFile(t, filepath.Join(appDir, "numerous.toml"), []byte(content))
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error { return validate(appDir) })
+		require.NoError(t, err)
+
+		out, err := io.ReadAll(stdout)
+		require.NoError(t, err)
+		assert.Contains(t, string(out), "Detected manifest format: current")
+		assert.Contains(t, string(out), "is valid")
+	})
+
+	t.Run("prints issues with line numbers for invalid manifest", func(t *testing.T) {
+		appDir := t.TempDir()
+		content := "name = \"app\"\nport = \"80\"\n\n[python]\nlibrary = \"streamlit\"\napp-file = \"app.py\"\n"
+		test.WriteFile(t, filepath.Join(appDir, "numerous.toml"), []byte(content))
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error { return validate(appDir) })
+		assert.ErrorIs(t, err, errInvalidManifest)
+
+		out, err := io.ReadAll(stdout)
+		require.NoError(t, err)
+		manifestPath := filepath.Join(appDir, "numerous.toml")
+		assert.Contains(t, string(out), "has 3 problem(s)")
+		assert.Contains(t, string(out), manifestPath+":2: port: must be an integer, not string")
+		assert.Contains(t, string(out), manifestPath+":4: python: missing required key \"app_file\"")
+		assert.Contains(t, string(out), manifestPath+":6: python.app-file: unknown key \"app-file\", did you mean \"app_file\"?")
+	})
+
+	t.Run("warns about deprecated format", func(t *testing.T) {
+		appDir := t.TempDir()
+		content := "name = \"app\"\nlibrary = \"streamlit\"\napp_file = \"app.py\"\nport = \"80\"\n"
+		test.WriteFile(t, filepath.Join(appDir, "numerous.toml"), []byte(content))
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error { return validate(appDir) })
+		require.NoError(t, err)
+
+		out, err := io.ReadAll(stdout)
+		require.NoError(t, err)
+		assert.Contains(t, string(out), "Detected manifest format: v0 (deprecated)")
+		assert.Contains(t, string(out), "deprecated format")
+	})
+
+	t.Run("returns error if app is not initialized", func(t *testing.T) {
+		appDir := t.TempDir()
+
+		_, err := test.RunEWithPatchedStdout(t, func() error { return validate(appDir) })
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,20 @@
+package metrics
+
+import (
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/cmd/group"
+	"numerous.com/cli/cmd/metrics/serve"
+
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "metrics",
+	Short:   "Export metrics of app workloads",
+	Args:    args.SubCommandRequired,
+	GroupID: group.AdditionalCommandsGroupID,
+}
+
+func init() {
+	Cmd.AddCommand(serve.Cmd)
+}
@@ -0,0 +1,117 @@
+package serve
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"numerous.com/cli/cmd/errorhandling"
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/config"
+	"numerous.com/cli/internal/gql"
+	"numerous.com/cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var ErrInvalidInterval = errors.New("invalid interval")
+
+const long = `Serve metrics of the workloads of apps to Prometheus.
+
+The command runs until it is stopped, and refreshes the workloads of the apps
+on an interval. The metrics are served at /metrics in the Prometheus text
+format, labeled with the organization, app and subscription of each workload:
+
+  numerous_app_workload_cpu_usage, numerous_app_workload_cpu_limit
+    CPU usage and limit, in 1024ths of a vCPU.
+
+  numerous_app_workload_memory_usage_megabytes,
+  numerous_app_workload_memory_limit_megabytes
+    Memory usage and limit, in megabytes.
+
+  numerous_app_workload_status
+    1 for the current status in the "status" label, and 0 for statuses the
+    workload had before.
+
+  numerous_app_workload_start_time_seconds, numerous_app_workload_uptime_seconds
+    Start time and uptime of the workload.
+
+  numerous_app_workload_restarts_total
+    Number of times the workload started again while the command was running.
+
+  numerous_app_workloads_last_refresh_timestamp_seconds,
+  numerous_app_workloads_refresh_errors_total
+    Time of the last successful refresh, and number of failed refreshes, of
+    each app.
+
+Without --app, the workloads of all apps in the organization are exported.
+`
+
+const example = `To export the metrics of all apps in an organization on port 9100:
+
+    numerous metrics serve --listen :9100 --organization "my-org"
+
+To export the metrics of two apps, refreshed every minute:
+
+    numerous metrics serve --organization "my-org" --app "app-a" --app "app-b" --interval 1m
+`
+
+var cmdArgs = struct {
+	listen           string
+	organizationSlug string
+	appSlugs         []string
+	interval         time.Duration
+}{
+	listen:   ":9100",
+	interval: 30 * time.Second, // nolint:mnd
+}
+
+var Cmd = &cobra.Command{
+	Use:     "serve",
+	Short:   "Serve metrics of app workloads to Prometheus",
+	Long:    long,
+	Example: example,
+	Args:    cobra.NoArgs,
+	RunE:    run,
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	orgSlug := cmdArgs.organizationSlug
+	if orgSlug == "" {
+		orgSlug = config.OrganizationSlug()
+	}
+
+	if orgSlug == "" {
+		output.PrintError(
+			"No organization provided or configured",
+			"Specify an organization with the --organization flag, or configure one with \"numerous config\".",
+		)
+		cmd.Usage() // nolint:errcheck
+
+		return errorhandling.ErrAlreadyPrinted
+	}
+
+	if cmdArgs.interval <= 0 {
+		output.PrintError("Invalid interval", "The --interval flag must be a positive duration, e.g. \"30s\" or \"1m\".")
+		return errorhandling.ErrorAlreadyPrinted(ErrInvalidInterval)
+	}
+
+	service := app.New(gql.NewClient(), nil, http.DefaultClient)
+	input := serveInput{
+		listen:   cmdArgs.listen,
+		orgSlug:  orgSlug,
+		appSlugs: cmdArgs.appSlugs,
+		interval: cmdArgs.interval,
+	}
+	err := serve(cmd.Context(), service, input)
+
+	return errorhandling.ErrorAlreadyPrinted(err)
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.StringVar(&cmdArgs.listen, "listen", cmdArgs.listen, "The address to serve the metrics on.")
+	flags.StringVarP(&cmdArgs.organizationSlug, "organization", "o", "", "The organization slug identifier of the apps to export metrics for. List available organizations with 'numerous organization list'.")
+	flags.StringArrayVarP(&cmdArgs.appSlugs, "app", "a", nil, "The app slug identifier of an app to export metrics for. Can be repeated. Defaults to all apps in the organization.")
+	flags.DurationVar(&cmdArgs.interval, "interval", cmdArgs.interval, "How often to refresh the workloads of the apps.")
+}
@@ -0,0 +1,30 @@
+package serve
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"numerous.com/cli/internal/app"
+)
+
+var _ appService = &mockAppService{}
+
+type mockAppService struct{ mock.Mock }
+
+// List implements appService.
+func (m *mockAppService) List(ctx context.Context, organizationSlug string) ([]app.ListApp, error) {
+	args := m.Called(ctx, organizationSlug)
+	return args.Get(0).([]app.ListApp), args.Error(1)
+}
+
+// ReadApp implements appService.
+func (m *mockAppService) ReadApp(ctx context.Context, input app.ReadAppInput) (app.ReadAppOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(app.ReadAppOutput), args.Error(1)
+}
+
+// ListAppWorkloads implements appService.
+func (m *mockAppService) ListAppWorkloads(ctx context.Context, input app.ListAppWorkloadsInput) ([]app.AppWorkload, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).([]app.AppWorkload), args.Error(1)
+}
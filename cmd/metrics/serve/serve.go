@@ -0,0 +1,138 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/appmetrics"
+	"numerous.com/cli/internal/output"
+)
+
+const (
+	// metricsWindow is how far back resource usage is read on each refresh.
+	// Only the current usage is exported, so it is kept short.
+	metricsWindow     = 5 * time.Minute
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 5 * time.Second
+)
+
+type appService interface {
+	List(ctx context.Context, organizationSlug string) ([]app.ListApp, error)
+	ReadApp(ctx context.Context, input app.ReadAppInput) (app.ReadAppOutput, error)
+	ListAppWorkloads(ctx context.Context, input app.ListAppWorkloadsInput) ([]app.AppWorkload, error)
+}
+
+type serveInput struct {
+	listen   string
+	orgSlug  string
+	appSlugs []string
+	interval time.Duration
+}
+
+func serve(ctx context.Context, service appService, input serveInput) error {
+	listener, err := net.Listen("tcp", input.listen)
+	if err != nil {
+		output.PrintErrorDetails("Cannot listen on %q", err, input.listen)
+		return err
+	}
+
+	collector := appmetrics.New()
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", collector)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: readHeaderTimeout}
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- server.Serve(listener) }()
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		server.Shutdown(shutdownCtx) // nolint:errcheck
+	}()
+
+	apps := "all apps"
+	if len(input.appSlugs) > 0 {
+		apps = "the apps " + strings.Join(input.appSlugs, ", ")
+	}
+	output.Notify("Serving metrics on http://%s/metrics", "Refreshing the workloads of %s in %q every %s.", listener.Addr(), apps, input.orgSlug, input.interval)
+
+	r := refresher{service: service, collector: collector, input: input, appIDs: make(map[string]string)}
+	r.refresh(ctx)
+
+	ticker := time.NewTicker(input.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-serveErr:
+			if errors.Is(err, http.ErrServerClosed) {
+				return nil
+			}
+			output.PrintErrorDetails("Error serving metrics", err)
+
+			return err
+		case <-ticker.C:
+			r.refresh(ctx)
+		}
+	}
+}
+
+// refresher reads the workloads of the apps into the collector.
+type refresher struct {
+	service   appService
+	collector *appmetrics.Collector
+	input     serveInput
+	appIDs    map[string]string
+}
+
+// refresh updates the collector with the workloads of each app. Errors are
+// printed as warnings and counted, so the exporter keeps running.
+func (r *refresher) refresh(ctx context.Context) {
+	appSlugs := r.input.appSlugs
+	if len(appSlugs) == 0 {
+		apps, err := r.service.List(ctx, r.input.orgSlug)
+		if err != nil {
+			output.PrintWarning("Error listing apps in "+r.input.orgSlug, err.Error())
+			return
+		}
+
+		for _, a := range apps {
+			appSlugs = append(appSlugs, a.Slug)
+		}
+		r.collector.Retain(r.input.orgSlug, appSlugs)
+	}
+
+	for _, appSlug := range appSlugs {
+		if err := r.refreshApp(ctx, appSlug); err != nil {
+			r.collector.RecordError(r.input.orgSlug, appSlug)
+			output.PrintWarning("Error refreshing the workloads of "+r.input.orgSlug+"/"+appSlug, err.Error())
+		}
+	}
+}
+
+func (r *refresher) refreshApp(ctx context.Context, appSlug string) error {
+	appID, ok := r.appIDs[appSlug]
+	if !ok {
+		readOutput, err := r.service.ReadApp(ctx, app.ReadAppInput{OrganizationSlug: r.input.orgSlug, AppSlug: appSlug})
+		if err != nil {
+			return err
+		}
+		appID = readOutput.AppID
+		r.appIDs[appSlug] = appID
+	}
+
+	since := time.Now().Add(-metricsWindow)
+	workloads, err := r.service.ListAppWorkloads(ctx, app.ListAppWorkloadsInput{AppID: appID, MetricsSince: &since})
+	if err != nil {
+		return err
+	}
+	r.collector.Update(r.input.orgSlug, appSlug, workloads, time.Now())
+
+	return nil
+}
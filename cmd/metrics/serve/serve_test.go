@@ -0,0 +1,88 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/appmetrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("test error")
+
+func TestRefresh(t *testing.T) {
+	ctx := context.Background()
+	workload := app.AppWorkload{OrganizationSlug: "acme", Status: "RUNNING", StartedAt: time.Now(), CPUUsage: app.AppWorkloadResourceUsage{Current: 42}}
+	matchAppID := func(appID string) any {
+		return mock.MatchedBy(func(input app.ListAppWorkloadsInput) bool { return input.AppID == appID && input.MetricsSince != nil })
+	}
+
+	metrics := func(t *testing.T, c *appmetrics.Collector) string {
+		t.Helper()
+
+		var b strings.Builder
+		require.NoError(t, c.Write(&b))
+
+		return b.String()
+	}
+
+	t.Run("refreshes all apps in the organization", func(t *testing.T) {
+		service := &mockAppService{}
+		service.On("List", ctx, "acme").Return([]app.ListApp{{Slug: "app-a"}, {Slug: "app-b"}}, nil)
+		service.On("ReadApp", ctx, app.ReadAppInput{OrganizationSlug: "acme", AppSlug: "app-a"}).Return(app.ReadAppOutput{AppID: "id-a"}, nil)
+		service.On("ReadApp", ctx, app.ReadAppInput{OrganizationSlug: "acme", AppSlug: "app-b"}).Return(app.ReadAppOutput{AppID: "id-b"}, nil)
+		service.On("ListAppWorkloads", ctx, matchAppID("id-a")).Return([]app.AppWorkload{workload}, nil)
+		service.On("ListAppWorkloads", ctx, matchAppID("id-b")).Return([]app.AppWorkload{workload}, nil)
+		r := refresher{service: service, collector: appmetrics.New(), input: serveInput{orgSlug: "acme"}, appIDs: map[string]string{}}
+
+		r.refresh(ctx)
+		r.refresh(ctx)
+
+		m := metrics(t, r.collector)
+		assert.Contains(t, m, `numerous_app_workload_cpu_usage{organization="acme",app="app-a",subscription=""} 42`)
+		assert.Contains(t, m, `numerous_app_workload_cpu_usage{organization="acme",app="app-b",subscription=""} 42`)
+		service.AssertNumberOfCalls(t, "ReadApp", 2)
+		service.AssertNumberOfCalls(t, "ListAppWorkloads", 4)
+	})
+
+	t.Run("refreshes only the given apps", func(t *testing.T) {
+		service := &mockAppService{}
+		service.On("ReadApp", ctx, app.ReadAppInput{OrganizationSlug: "acme", AppSlug: "app-a"}).Return(app.ReadAppOutput{AppID: "id-a"}, nil)
+		service.On("ListAppWorkloads", ctx, matchAppID("id-a")).Return([]app.AppWorkload{workload}, nil)
+		r := refresher{service: service, collector: appmetrics.New(), input: serveInput{orgSlug: "acme", appSlugs: []string{"app-a"}}, appIDs: map[string]string{}}
+
+		r.refresh(ctx)
+
+		assert.Contains(t, metrics(t, r.collector), `app="app-a"`)
+		service.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+
+	t.Run("counts errors and keeps refreshing other apps", func(t *testing.T) {
+		service := &mockAppService{}
+		service.On("ReadApp", ctx, app.ReadAppInput{OrganizationSlug: "acme", AppSlug: "app-a"}).Return(app.ReadAppOutput{}, errTest)
+		service.On("ReadApp", ctx, app.ReadAppInput{OrganizationSlug: "acme", AppSlug: "app-b"}).Return(app.ReadAppOutput{AppID: "id-b"}, nil)
+		service.On("ListAppWorkloads", ctx, matchAppID("id-b")).Return([]app.AppWorkload{workload}, nil)
+		r := refresher{service: service, collector: appmetrics.New(), input: serveInput{orgSlug: "acme", appSlugs: []string{"app-a", "app-b"}}, appIDs: map[string]string{}}
+
+		r.refresh(ctx)
+
+		m := metrics(t, r.collector)
+		assert.Contains(t, m, `numerous_app_workloads_refresh_errors_total{organization="acme",app="app-a"} 1`)
+		assert.Contains(t, m, `numerous_app_workload_cpu_usage{organization="acme",app="app-b",subscription=""} 42`)
+	})
+}
+
+func TestServe(t *testing.T) {
+	t.Run("returns error for invalid listen address", func(t *testing.T) {
+		err := serve(context.Background(), &mockAppService{}, serveInput{listen: "invalid:address:1", orgSlug: "acme", interval: time.Second})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,78 @@
+package mockserver
+
+import (
+	"time"
+
+	"numerous.com/cli/cmd/errorhandling"
+	"numerous.com/cli/cmd/group"
+	"numerous.com/cli/internal/mockserver"
+
+	"github.com/spf13/cobra"
+)
+
+const long string = `Serve an in-memory mock of the Numerous platform API.
+
+The mock server implements the GraphQL queries, mutations, and subscriptions
+used by the CLI to create, deploy, and delete apps, to read the app status and
+logs, and to start and stop tasks. App source archives are uploaded to and
+downloaded from the mock server. All state is kept in memory, and is lost when
+the mock server stops.
+
+Deployments start as soon as they are requested, and deployed apps are
+reported as running. Log lines can be appended to the logs of an app by
+posting them to the path "/logs/<organization slug>/<app slug>" of the mock
+server.
+
+Point the CLI at the mock server with the NUMEROUS_GRAPHQL_HTTP_URL and
+NUMEROUS_GRAPHQL_WS_URL environment variables, and set NUMEROUS_ACCESS_TOKEN
+to any value, to run deploy, logs, and task commands without network access,
+for example in CI.
+`
+
+const example string = `To serve the mock server and deploy an app to it:
+
+    numerous mock-server --listen localhost:8080 &
+    export NUMEROUS_GRAPHQL_HTTP_URL=http://localhost:8080/query
+    export NUMEROUS_GRAPHQL_WS_URL=ws://localhost:8080/query
+    export NUMEROUS_ACCESS_TOKEN=mock
+    numerous deploy --organization my-org --app my-app
+
+To define a task which finishes after 10 seconds:
+
+    numerous mock-server --task "train=python train.py" --task-duration 10s
+`
+
+var Cmd = &cobra.Command{
+	Use:     "mock-server",
+	RunE:    run,
+	Short:   "Serve a mock of the Numerous platform API for testing",
+	Long:    long,
+	Example: example,
+	GroupID: group.AdditionalCommandsGroupID,
+	Args:    cobra.NoArgs,
+}
+
+var cmdArgs struct {
+	listen       string
+	tasks        []string
+	taskDuration time.Duration
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	input := serveInput{
+		listen:       cmdArgs.listen,
+		tasks:        cmdArgs.tasks,
+		taskDuration: cmdArgs.taskDuration,
+	}
+
+	err := serve(cmd.Context(), input)
+
+	return errorhandling.ErrorAlreadyPrinted(err)
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.StringVar(&cmdArgs.listen, "listen", "localhost:8080", "The address to listen on.")
+	flags.StringArrayVar(&cmdArgs.tasks, "task", nil, `A task which can be started for all deployed apps, formatted as "<name>=<command>". Can be repeated.`)
+	flags.DurationVar(&cmdArgs.taskDuration, "task-duration", mockserver.DefaultTaskDuration, "The time task instances run before finishing.")
+}
@@ -0,0 +1,87 @@
+package mockserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"numerous.com/cli/internal/mockserver"
+	"numerous.com/cli/internal/output"
+)
+
+var ErrInvalidTask = errors.New("invalid task")
+
+const shutdownTimeout = 5 * time.Second
+
+type serveInput struct {
+	listen       string
+	tasks        []string
+	taskDuration time.Duration
+}
+
+func serve(ctx context.Context, input serveInput) error {
+	tasks, err := parseTasks(input.tasks)
+	if err != nil {
+		output.PrintErrorDetails("Invalid task definition", err)
+		return err
+	}
+
+	handler, err := mockserver.New(mockserver.Options{Tasks: tasks, TaskDuration: input.taskDuration})
+	if err != nil {
+		output.PrintErrorDetails("Error loading the GraphQL schema", err)
+		return err
+	}
+
+	listener, err := net.Listen("tcp", input.listen)
+	if err != nil {
+		output.PrintErrorDetails("Error listening on %q", err, input.listen)
+		return err
+	}
+
+	addr := listener.Addr().String()
+	output.Notify(
+		"Serving the mock server on "+addr,
+		`Use it with the environment variables:
+  NUMEROUS_GRAPHQL_HTTP_URL=http://%s/query
+  NUMEROUS_GRAPHQL_WS_URL=ws://%s/query
+  NUMEROUS_ACCESS_TOKEN=mock`,
+		addr, addr,
+	)
+
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: shutdownTimeout}
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		server.Shutdown(shutdownCtx) // nolint:errcheck
+	}()
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		output.PrintErrorDetails("Error serving the mock server", err)
+		return err
+	}
+
+	return nil
+}
+
+// parseTasks parses task definitions formatted as "<name>=<command>".
+func parseTasks(definitions []string) ([]mockserver.TaskDefinition, error) {
+	tasks := make([]mockserver.TaskDefinition, 0, len(definitions))
+	for _, definition := range definitions {
+		name, command, ok := strings.Cut(definition, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.TrimSpace(command) == "" {
+			return nil, fmt.Errorf("%w: %q must be formatted as \"<name>=<command>\"", ErrInvalidTask, definition)
+		}
+
+		tasks = append(tasks, mockserver.TaskDefinition{Name: name, Command: strings.Fields(command)})
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,55 @@
+package mockserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"numerous.com/cli/internal/mockserver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTasks(t *testing.T) {
+	t.Run("parses task definitions", func(t *testing.T) {
+		tasks, err := parseTasks([]string{"train=python train.py --epochs 2", " report = python report.py"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []mockserver.TaskDefinition{
+			{Name: "train", Command: []string{"python", "train.py", "--epochs", "2"}},
+			{Name: "report", Command: []string{"python", "report.py"}},
+		}, tasks)
+	})
+
+	for _, definition := range []string{"train", "=python train.py", "train="} {
+		t.Run("returns error for "+definition, func(t *testing.T) {
+			_, err := parseTasks([]string{definition})
+
+			assert.ErrorIs(t, err, ErrInvalidTask)
+		})
+	}
+}
+
+func TestServe(t *testing.T) {
+	t.Run("returns when context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- serve(ctx, serveInput{listen: "127.0.0.1:0"}) }()
+
+		cancel()
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected serve to return")
+		}
+	})
+
+	t.Run("returns error for invalid task", func(t *testing.T) {
+		err := serve(context.Background(), serveInput{listen: "127.0.0.1:0", tasks: []string{"train"}})
+
+		assert.ErrorIs(t, err, ErrInvalidTask)
+	})
+}
@@ -10,14 +10,20 @@ import (
 	"numerous.com/cli/cmd/config"
 	"numerous.com/cli/cmd/deletecmd"
 	"numerous.com/cli/cmd/deploy"
+	"numerous.com/cli/cmd/diff"
 	"numerous.com/cli/cmd/download"
+	"numerous.com/cli/cmd/eject"
 	"numerous.com/cli/cmd/errorhandling"
 	cmdinit "numerous.com/cli/cmd/init"
 	"numerous.com/cli/cmd/legacy"
 	"numerous.com/cli/cmd/login"
 	"numerous.com/cli/cmd/logout"
 	"numerous.com/cli/cmd/logs"
+	"numerous.com/cli/cmd/manifest"
+	"numerous.com/cli/cmd/metrics"
+	"numerous.com/cli/cmd/mockserver"
 	"numerous.com/cli/cmd/organization"
+	"numerous.com/cli/cmd/run"
 	"numerous.com/cli/cmd/status"
 	"numerous.com/cli/cmd/task"
 	"numerous.com/cli/cmd/token"
@@ -74,12 +80,18 @@ func init() {
 		deploy.Cmd,
 		logs.Cmd,
 		download.Cmd,
+		diff.Cmd,
+		run.Cmd,
+		eject.Cmd,
 		token.Cmd,
 		cmdversion.Cmd,
 		app.Cmd,
 		config.Cmd,
 		status.Cmd,
 		task.Cmd,
+		manifest.Cmd,
+		metrics.Cmd,
+		mockserver.Cmd,
 
 		// dummy commands to display helpful messages for legacy commands
 		dummyLegacyCmd("push"),
@@ -2,13 +2,16 @@ package root
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 
 	"numerous.com/cli/cmd/errorhandling"
 	cmdversion "numerous.com/cli/cmd/version"
 	"numerous.com/cli/internal/auth"
+	"numerous.com/cli/internal/config"
 	"numerous.com/cli/internal/gql"
+	"numerous.com/cli/internal/manifest"
 	"numerous.com/cli/internal/output"
 	"numerous.com/cli/internal/version"
 
@@ -22,6 +25,7 @@ var (
 
 func prerun(cmd *cobra.Command, args []string) error {
 	output.NotifyFeedbackMaybe()
+	loadCustomLibraries()
 
 	if !cmdversion.Check(version.NewService(gql.NewClient())) {
 		return errorhandling.ErrorAlreadyPrinted(ErrIncompatibleVersion)
@@ -48,6 +52,16 @@ func prerun(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func loadCustomLibraries() {
+	librariesPath := config.CustomLibrariesFilePath()
+	if err := manifest.LoadCustomLibraries(librariesPath); err != nil {
+		output.PrintWarning(
+			fmt.Sprintf("Could not load custom library definitions from %q", librariesPath),
+			err.Error(),
+		)
+	}
+}
+
 func commandRequiresAuthentication(invokedCommandName string) bool {
 	commandsWithAuthRequired := []string{
 		"numerous legacy list",
@@ -58,7 +72,9 @@ func commandRequiresAuthentication(invokedCommandName string) bool {
 		"numerous deploy",
 		"numerous delete",
 		"numerous download",
+		"numerous diff",
 		"numerous logs",
+		"numerous logs forward",
 		"numerous token create",
 		"numerous token list",
 		"numerous token revoke",
@@ -66,6 +82,7 @@ func commandRequiresAuthentication(invokedCommandName string) bool {
 		"numerous app share",
 		"numerous app unshare",
 		"numerous status",
+		"numerous metrics serve",
 	}
 
 	for _, cmd := range commandsWithAuthRequired {
@@ -0,0 +1,82 @@
+package run
+
+import (
+	"os/exec"
+
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/cmd/errorhandling"
+	"numerous.com/cli/cmd/group"
+	"numerous.com/cli/cmd/usage"
+
+	"github.com/spf13/cobra"
+)
+
+const long string = `Run the app locally, the same way it is served when deployed.
+
+Reads the app manifest, and starts the app with the serve command of its
+library, e.g. "streamlit run app.py --server.port 80" for a Streamlit app,
+listening on the port defined in the manifest. Environment variables defined
+in the [env] table of the manifest, and the secrets in the ".env" file of the
+app directory, are available to the app.
+
+The app is run with the python environment the command is executed in, so the
+app requirements must already be installed. A warning is printed if the
+version of the python interpreter differs from the version configured in the
+manifest.
+
+For apps built from a Dockerfile, the equivalent "docker build" and "docker
+run" commands are printed instead.
+
+` + usage.AppDirectoryArgument + `
+`
+
+const example string = `To run the app in the current directory:
+
+    numerous run
+
+To run the app in another directory on port 8080:
+
+    numerous run --port 8080 my_project/my_app
+
+To run the app with the secrets of the "staging" deploy environment:
+
+    numerous run --env staging
+`
+
+var Cmd = &cobra.Command{
+	Use:     "run [app directory]",
+	RunE:    runCmd,
+	Short:   "Run the app locally",
+	Long:    long,
+	Example: example,
+	GroupID: group.AppCommandsGroupID,
+	Args:    args.OptionalAppDir(&cmdArgs.appDir),
+}
+
+var cmdArgs struct {
+	appDir string
+	env    string
+	port   uint
+}
+
+func runCmd(cmd *cobra.Command, args []string) error {
+	input := runInput{
+		appDir: cmdArgs.appDir,
+		env:    cmdArgs.env,
+		port:   cmdArgs.port,
+	}
+
+	err := run(cmd.Context(), execCommand, input)
+
+	return errorhandling.ErrorAlreadyPrinted(err)
+}
+
+func execCommand(cmd *exec.Cmd) error {
+	return cmd.Run()
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.StringVar(&cmdArgs.env, "env", "", "The deploy environment, defined in a \"deploy.<environment>\" table in the app manifest, whose environment file is used for secrets.")
+	flags.UintVarP(&cmdArgs.port, "port", "p", 0, "The port to serve the app on, instead of the port defined in the app manifest.")
+}
@@ -0,0 +1,195 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"numerous.com/cli/internal/appident"
+	"numerous.com/cli/internal/manifest"
+	"numerous.com/cli/internal/output"
+	"numerous.com/cli/internal/python"
+)
+
+var ErrNoPythonConfiguration = errors.New("no python configuration")
+
+type runInput struct {
+	appDir string
+	env    string
+	port   uint
+}
+
+type commandExecutor func(cmd *exec.Cmd) error
+
+// detectPythonVersion returns the version of the local python interpreter.
+var detectPythonVersion = python.LocalVersion
+
+func run(ctx context.Context, execute commandExecutor, input runInput) error {
+	manifestPath := filepath.Join(input.appDir, manifest.ManifestFileName)
+	m, format, err := manifest.LoadWithFormat(manifestPath)
+	if err != nil {
+		output.PrintErrorAppNotInitialized(input.appDir)
+		output.PrintManifestTOMLError(err)
+
+		return err
+	}
+	manifest.WarnIfDeprecated(manifestPath, format)
+
+	deployment, err := m.ResolveDeployEnvironment(input.env)
+	if err != nil {
+		output.PrintErrorDetails("Error resolving the deploy environment", err)
+		return err
+	}
+
+	port := m.ServePort()
+	if input.port != 0 {
+		port = input.port
+	}
+
+	if m.Docker != nil {
+		printDockerCommands(input.appDir, m, deployment, port)
+		return nil
+	}
+
+	secrets, err := deployment.LoadSecrets(input.appDir)
+	if err != nil {
+		output.PrintErrorDetails("Error reading secrets from environment file %q", err, deployment.EnvFile)
+		return err
+	}
+
+	return runPython(ctx, execute, input.appDir, m, secrets, port)
+}
+
+func runPython(ctx context.Context, execute commandExecutor, appDir string, m *manifest.Manifest, secrets map[string]string, port uint) error {
+	if m.Python == nil {
+		output.PrintError("The app manifest does not define a python app", "")
+		return ErrNoPythonConfiguration
+	}
+
+	lib := m.Python.Library
+	serveArgs := lib.ServeArgs(m.Python.AppFile, port)
+	if len(serveArgs) == 0 {
+		output.PrintError(
+			"The %s library has no serve command",
+			"Define a \"serve_command\" for the library in the custom library definitions, to run apps with it locally.",
+			lib.Name,
+		)
+
+		return manifest.ErrNoServeCommand
+	}
+
+	warnIfPythonVersionMismatch(m.Python.Version)
+
+	cmd := exec.CommandContext(ctx, serveArgs[0], serveArgs[1:]...) // #nosec G204
+	cmd.Dir = appDir
+	cmd.Env = commandEnvironment(os.Environ(), m.Env, secrets, lib.ServeEnvironment(m.Python.AppFile, port))
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	output.Notify("Running the app on port %d", "%s\n", port, shellCommand(serveArgs))
+
+	if err := execute(cmd); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			output.PrintErrorDetails(
+				"Could not find the %q command. Make sure the app requirements are installed in the python environment.",
+				err, serveArgs[0],
+			)
+		} else {
+			output.PrintErrorDetails("Error running the app", err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Returns the environment of the serve command, where manifest environment
+// variables override the process environment, secrets override the manifest
+// environment variables, and the library serve environment overrides all.
+func commandEnvironment(environ []string, env map[string]string, secrets map[string]string, serveEnv []string) []string {
+	result := slices.Clone(environ)
+	for _, vars := range []map[string]string{env, secrets} {
+		for _, key := range slices.Sorted(maps.Keys(vars)) {
+			result = append(result, key+"="+vars[key])
+		}
+	}
+
+	return append(result, serveEnv...)
+}
+
+func warnIfPythonVersionMismatch(configured string) {
+	if configured == "" {
+		return
+	}
+
+	local, err := detectPythonVersion()
+	if err != nil {
+		output.PrintWarning("Could not detect the local python version", "The app is configured to use Python "+configured+".")
+		return
+	}
+
+	if minorVersion(local) != minorVersion(configured) {
+		output.PrintWarning(
+			"The local python version differs from the configured version",
+			"The app is configured to use Python "+configured+", but Python "+local+" is used locally.",
+		)
+	}
+}
+
+// Returns the major and minor components of the version, e.g. "3.11" for
+// "3.11.4".
+func minorVersion(version string) string {
+	parts := strings.SplitN(version, ".", 3) // nolint:mnd
+	if len(parts) < 2 {                      // nolint:mnd
+		return version
+	}
+
+	return parts[0] + "." + parts[1]
+}
+
+func printDockerCommands(appDir string, m *manifest.Manifest, deployment manifest.DeployEnvironment, port uint) {
+	image := appident.GetAppSlug(m, deployment.AppSlug)
+	portMapping := strconv.FormatUint(uint64(port), 10) + ":" + strconv.FormatUint(uint64(port), 10)
+
+	build := []string{"docker", "build", "-t", image, "-f", filepath.Join(appDir, m.Docker.Dockerfile), filepath.Join(appDir, m.Docker.Context)}
+	run := []string{"docker", "run", "--rm", "-p", portMapping}
+
+	envFilePath := filepath.Join(appDir, deployment.EnvFile)
+	if _, err := os.Stat(envFilePath); err == nil {
+		run = append(run, "--env-file", envFilePath)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(m.Env)) {
+		run = append(run, "-e", key+"="+m.Env[key])
+	}
+
+	run = append(run, image)
+
+	output.Notify(
+		"The app is built from a Dockerfile",
+		"To run the app locally, build and run the Docker image with:\n\n    %s\n    %s\n",
+		shellCommand(build), shellCommand(run),
+	)
+}
+
+// Returns the arguments as a command line, where arguments are quoted if they
+// contain characters interpreted by the shell.
+func shellCommand(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'$`\\|&;<>()*?#~") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		quoted[i] = arg
+	}
+
+	return strings.Join(quoted, " ")
+}
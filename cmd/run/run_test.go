@@ -0,0 +1,207 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"numerous.com/cli/internal/manifest"
+	"numerous.com/cli/internal/test"
+)
+
+const pythonManifestTOML = `name = "My App"
+port = 80
+
+[python]
+library = "streamlit"
+version = "3.11"
+app_file = "app.py"
+
+[env]
+LOG_LEVEL = "debug"
+API_KEY = "not-secret"
+
+[deploy.staging]
+env_file = ".env.staging"
+`
+
+const dockerManifestTOML = `name = "My Docker App"
+port = 8080
+
+[docker]
+dockerfile = "Dockerfile"
+context = "."
+
+[env]
+LOG_LEVEL = "debug"
+`
+
+func writeApp(t *testing.T, manifestTOML string) string {
+	t.Helper()
+
+	appDir := t.TempDir()
+	test.WriteFile(t, filepath.Join(appDir, manifest.ManifestFileName), []byte(manifestTOML))
+	test.WriteFile(t, filepath.Join(appDir, ".env"), []byte("API_KEY=secret-value\n"))
+	test.WriteFile(t, filepath.Join(appDir, ".env.staging"), []byte("STAGING_KEY=staging-secret\n"))
+
+	return appDir
+}
+
+func patchPythonVersion(t *testing.T, version string, err error) {
+	t.Helper()
+
+	original := detectPythonVersion
+	detectPythonVersion = func() (string, error) { return version, err }
+	t.Cleanup(func() { detectPythonVersion = original })
+}
+
+func TestRun(t *testing.T) {
+	t.Run("runs the library serve command in the app directory", func(t *testing.T) {
+		patchPythonVersion(t, "3.11", nil)
+		appDir := writeApp(t, pythonManifestTOML)
+		var executed *exec.Cmd
+		execute := func(cmd *exec.Cmd) error {
+			executed = cmd
+			return nil
+		}
+
+		err := run(context.TODO(), execute, runInput{appDir: appDir})
+
+		require.NoError(t, err)
+		require.NotNil(t, executed)
+		assert.Equal(t, []string{"streamlit", "run", "app.py", "--server.port", "80", "--server.address", "0.0.0.0"}, executed.Args)
+		assert.Equal(t, appDir, executed.Dir)
+		assert.Contains(t, executed.Env, "LOG_LEVEL=debug")
+		assert.Equal(t, "API_KEY=secret-value", lastEnv(executed.Env, "API_KEY"))
+	})
+
+	t.Run("uses the port flag", func(t *testing.T) {
+		patchPythonVersion(t, "3.11", nil)
+		appDir := writeApp(t, pythonManifestTOML)
+		var executed *exec.Cmd
+		execute := func(cmd *exec.Cmd) error {
+			executed = cmd
+			return nil
+		}
+
+		err := run(context.TODO(), execute, runInput{appDir: appDir, port: 8501})
+
+		require.NoError(t, err)
+		assert.Contains(t, executed.Args, "8501")
+	})
+
+	t.Run("uses the default port of the library when the manifest has no port", func(t *testing.T) {
+		patchPythonVersion(t, "3.11", nil)
+		appDir := writeApp(t, "name = \"My App\"\n\n[python]\nlibrary = \"fastapi\"\nversion = \"3.11\"\napp_file = \"app.py\"\n")
+		var executed *exec.Cmd
+		execute := func(cmd *exec.Cmd) error {
+			executed = cmd
+			return nil
+		}
+
+		err := run(context.TODO(), execute, runInput{appDir: appDir})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"uvicorn", "app:app", "--host", "0.0.0.0", "--port", "8000"}, executed.Args)
+	})
+
+	t.Run("loads the env file of the deploy environment", func(t *testing.T) {
+		patchPythonVersion(t, "3.11", nil)
+		appDir := writeApp(t, pythonManifestTOML)
+		var executed *exec.Cmd
+		execute := func(cmd *exec.Cmd) error {
+			executed = cmd
+			return nil
+		}
+
+		err := run(context.TODO(), execute, runInput{appDir: appDir, env: "staging"})
+
+		require.NoError(t, err)
+		assert.Contains(t, executed.Env, "STAGING_KEY=staging-secret")
+		assert.Equal(t, "API_KEY=not-secret", lastEnv(executed.Env, "API_KEY"))
+	})
+
+	t.Run("warns about python version mismatch", func(t *testing.T) {
+		patchPythonVersion(t, "3.12", nil)
+		appDir := writeApp(t, pythonManifestTOML)
+		execute := func(cmd *exec.Cmd) error { return nil }
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return run(context.TODO(), execute, runInput{appDir: appDir})
+		})
+
+		require.NoError(t, err)
+		out, _ := io.ReadAll(stdout)
+		assert.Contains(t, string(out), "The local python version differs from the configured version")
+		assert.Contains(t, string(out), "The app is configured to use Python 3.11, but Python 3.12 is used locally.")
+	})
+
+	t.Run("returns error if serve command fails", func(t *testing.T) {
+		patchPythonVersion(t, "3.11", nil)
+		appDir := writeApp(t, pythonManifestTOML)
+		expected := errors.New("exit status 1")
+		execute := func(cmd *exec.Cmd) error { return expected }
+
+		err := run(context.TODO(), execute, runInput{appDir: appDir})
+
+		assert.ErrorIs(t, err, expected)
+	})
+
+	t.Run("prints docker commands for docker apps", func(t *testing.T) {
+		appDir := writeApp(t, dockerManifestTOML)
+		execute := func(cmd *exec.Cmd) error {
+			t.Fatal("no command should be executed")
+			return nil
+		}
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return run(context.TODO(), execute, runInput{appDir: appDir})
+		})
+
+		require.NoError(t, err)
+		out, _ := io.ReadAll(stdout)
+		assert.Contains(t, string(out), "docker build -t my-docker-app -f "+filepath.Join(appDir, "Dockerfile")+" "+appDir)
+		assert.Contains(t, string(out), "docker run --rm -p 8080:8080 --env-file "+filepath.Join(appDir, ".env")+" -e LOG_LEVEL=debug my-docker-app")
+	})
+
+	t.Run("prints docker commands with the app and env file of the environment", func(t *testing.T) {
+		appDir := writeApp(t, dockerManifestTOML+"\n[deploy.staging]\napp = \"my-staging-app\"\nenv_file = \".env.staging\"\n")
+		execute := func(cmd *exec.Cmd) error {
+			t.Fatal("no command should be executed")
+			return nil
+		}
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return run(context.TODO(), execute, runInput{appDir: appDir, env: "staging"})
+		})
+
+		require.NoError(t, err)
+		out, _ := io.ReadAll(stdout)
+		assert.Contains(t, string(out), "docker build -t my-staging-app -f ")
+		assert.Contains(t, string(out), "--env-file "+filepath.Join(appDir, ".env.staging")+" -e LOG_LEVEL=debug my-staging-app")
+	})
+}
+
+func TestShellCommand(t *testing.T) {
+	actual := shellCommand([]string{"docker", "run", "-e", "GREETING=hello world", "-e", "QUOTE=it's"})
+
+	assert.Equal(t, `docker run -e 'GREETING=hello world' -e 'QUOTE=it'\''s'`, actual)
+}
+
+// Returns the last definition of the key in the environment, which is the
+// one used by the executed command.
+func lastEnv(env []string, key string) string {
+	found := ""
+	for _, kv := range env {
+		if len(kv) > len(key) && kv[:len(key)+1] == key+"=" {
+			found = kv
+		}
+	}
+
+	return found
+}
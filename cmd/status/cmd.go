@@ -1,8 +1,10 @@
 package status
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/spf13/cobra"
 	"numerous.com/cli/cmd/args"
@@ -11,12 +13,23 @@ import (
 	"numerous.com/cli/cmd/usage"
 	"numerous.com/cli/internal/app"
 	"numerous.com/cli/internal/gql"
+	"numerous.com/cli/internal/manifest"
+	"numerous.com/cli/internal/output"
 )
 
 const cmdActionText = "to see the status of"
 
 const longFormat = `Get an overview of the status of all workloads related to an app.
 
+If an organization is given with --organization, but no app is given, and the
+app directory does not contain an app, the status of all apps in the
+organization is shown in a table instead. Use --sort to sort the apps by their
+current CPU or memory usage.
+
+With --watch, the status is shown in a dashboard, which is refreshed on an
+interval, until you press "q". Use the arrow keys, tab or the numbers 1-9 to
+switch between workloads, and "c" or "m" to plot the CPU or memory usage.
+
 %s
 
 %s
@@ -24,10 +37,18 @@ const longFormat = `Get an overview of the status of all workloads related to an
 
 var long = fmt.Sprintf(longFormat, usage.AppIdentifier(cmdActionText), usage.AppDirectoryArgument)
 
-var cmdArgs struct {
+var ErrWatchWithOutput = errors.New("--watch cannot be used with --output")
+
+var cmdArgs = struct {
 	appIdent     args.AppIdentifierArg
 	appDir       string
-	metricsSince Since
+	metricsSince args.Since
+	watch        time.Duration
+	output       args.OutputFormatArg
+	sortBy       SortBy
+}{
+	output: args.NewOutputFormatArg(args.OutputFormatText, args.OutputFormatJSON, args.OutputFormatYAML),
+	sortBy: SortByApp,
 }
 
 var Cmd = &cobra.Command{
@@ -39,23 +60,59 @@ var Cmd = &cobra.Command{
 	GroupID: group.AppCommandsGroupID,
 }
 
-func run(cmd *cobra.Command, args []string) error {
+func run(cmd *cobra.Command, _ []string) error {
 	service := app.New(gql.NewClient(), nil, http.DefaultClient)
 
 	input := statusInput{
 		appDir:       cmdArgs.appDir,
 		appSlug:      cmdArgs.appIdent.AppSlug,
 		orgSlug:      cmdArgs.appIdent.OrganizationSlug,
+		env:          cmdArgs.appIdent.Environment,
 		metricsSince: cmdArgs.metricsSince.Time(),
+		format:       cmdArgs.output.Format,
+	}
+
+	if cmdArgs.watch > 0 && cmdArgs.output.Format != args.OutputFormatText {
+		output.PrintError("Cannot watch the status with structured output", "The --watch flag can only be used with text output.")
+		return errorhandling.ErrorAlreadyPrinted(ErrWatchWithOutput)
+	}
+
+	var err error
+	if cmdArgs.watch == 0 && isOrganizationOverview(cmdArgs.appIdent, cmdArgs.appDir) {
+		err = overview(cmd.Context(), service, overviewInput{
+			orgSlug:      cmdArgs.appIdent.OrganizationSlug,
+			metricsSince: cmdArgs.metricsSince.Time(),
+			sortBy:       cmdArgs.sortBy,
+			format:       cmdArgs.output.Format,
+		})
+	} else if cmdArgs.watch > 0 {
+		err = watch(cmd.Context(), service, input, cmdArgs.watch)
+	} else {
+		err = status(cmd.Context(), service, input)
 	}
-	err := status(cmd.Context(), service, input)
 
 	return errorhandling.ErrorAlreadyPrinted(err)
 }
 
+// isOrganizationOverview returns true if only an organization is given, and
+// the app directory does not contain an app to show the status of.
+func isOrganizationOverview(ai args.AppIdentifierArg, appDir string) bool {
+	if ai.OrganizationSlug == "" || ai.AppSlug != "" || ai.Environment != "" {
+		return false
+	}
+
+	exists, _ := manifest.ManifestExists(appDir)
+
+	return !exists
+}
+
 func init() {
 	flags := Cmd.Flags()
 	cmdArgs.appIdent.AddAppIdentifierFlags(flags, cmdActionText)
 	f := flags.VarPF(&cmdArgs.metricsSince, "metrics-since", "", `Read metrics since this time. Can be an RFC3339 timestamp (e.g. "2024-01-01T12:00:00Z"), a date (e.g. "2024-06-06"), or a duration of seconds, minutes, hours or days (e.g. "1s", "10m", "5h", "2d").`)
 	f.DefValue = `"1h"` // Hack to display correct default value in the help text
+	flags.DurationVar(&cmdArgs.watch, "watch", 0, "Show a live dashboard of the status, refreshed on this interval.")
+	flags.Lookup("watch").NoOptDefVal = "5s"
+	flags.Var(&cmdArgs.sortBy, "sort", `How to sort the apps in the organization overview, one of "app", "cpu" or "memory". With "cpu" or "memory", the apps using the most are shown first.`)
+	flags.Var(&cmdArgs.output, "output", `The output format, one of "text", "json" or "yaml". With "json" or "yaml", the app name and description, and each workload with its status, start time, subscription, resource usage with the full timeseries, and recent logs are printed as a document.`)
 }
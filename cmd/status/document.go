@@ -0,0 +1,118 @@
+package status
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/appident"
+
+	"gopkg.in/yaml.v3"
+)
+
+// statusDocument is the status of an app, as written with --output json or
+// --output yaml.
+type statusDocument struct {
+	Organization string             `json:"organization" yaml:"organization"`
+	App          string             `json:"app" yaml:"app"`
+	Name         string             `json:"name" yaml:"name"`
+	Description  string             `json:"description" yaml:"description"`
+	Workloads    []workloadDocument `json:"workloads" yaml:"workloads"`
+}
+
+type workloadDocument struct {
+	Organization  string                `json:"organization,omitempty" yaml:"organization,omitempty"`
+	Subscription  *subscriptionDocument `json:"subscription,omitempty" yaml:"subscription,omitempty"`
+	Status        string                `json:"status" yaml:"status"`
+	StartedAt     string                `json:"started_at" yaml:"started_at"`
+	CPUUsage      usageDocument         `json:"cpu_usage" yaml:"cpu_usage"`
+	MemoryUsageMB usageDocument         `json:"memory_usage_mb" yaml:"memory_usage_mb"`
+	Logs          []logEntryDocument    `json:"logs" yaml:"logs"`
+}
+
+type subscriptionDocument struct {
+	Organization     string `json:"organization" yaml:"organization"`
+	SubscriptionUUID string `json:"subscription_uuid" yaml:"subscription_uuid"`
+}
+
+type usageDocument struct {
+	Current    float64         `json:"current" yaml:"current"`
+	Limit      *float64        `json:"limit" yaml:"limit"`
+	Timeseries []pointDocument `json:"timeseries" yaml:"timeseries"`
+}
+
+type pointDocument struct {
+	Timestamp string  `json:"timestamp" yaml:"timestamp"`
+	Value     float64 `json:"value" yaml:"value"`
+}
+
+type logEntryDocument struct {
+	Timestamp string `json:"timestamp" yaml:"timestamp"`
+	Text      string `json:"text" yaml:"text"`
+}
+
+func newStatusDocument(ai appident.AppIdentifier, readOutput app.ReadAppOutput, workloads []app.AppWorkload) statusDocument {
+	doc := statusDocument{
+		Organization: ai.OrganizationSlug,
+		App:          ai.AppSlug,
+		Name:         readOutput.AppDisplayName,
+		Description:  readOutput.AppDescription,
+		Workloads:    make([]workloadDocument, 0, len(workloads)),
+	}
+
+	for _, w := range workloads {
+		wd := workloadDocument{
+			Organization:  w.OrganizationSlug,
+			Status:        w.Status,
+			StartedAt:     w.StartedAt.Format(time.RFC3339),
+			CPUUsage:      newUsageDocument(w.CPUUsage),
+			MemoryUsageMB: newUsageDocument(w.MemoryUsageMB),
+			Logs:          make([]logEntryDocument, 0, len(w.LogEntries)),
+		}
+
+		if sub := w.Subscription; sub != nil {
+			wd.Subscription = &subscriptionDocument{Organization: sub.OrganizationSlug, SubscriptionUUID: sub.SubscriptionUUID}
+		}
+
+		for _, entry := range w.LogEntries {
+			wd.Logs = append(wd.Logs, logEntryDocument{Timestamp: entry.Timestamp.Format(time.RFC3339Nano), Text: entry.Text})
+		}
+
+		doc.Workloads = append(doc.Workloads, wd)
+	}
+
+	return doc
+}
+
+func newUsageDocument(usage app.AppWorkloadResourceUsage) usageDocument {
+	doc := usageDocument{
+		Current:    usage.Current,
+		Limit:      usage.Limit,
+		Timeseries: make([]pointDocument, 0, len(usage.Timeseries)),
+	}
+
+	for _, p := range usage.Timeseries {
+		doc.Timeseries = append(doc.Timeseries, pointDocument{Timestamp: p.Timestamp.Format(time.RFC3339), Value: p.Value})
+	}
+
+	return doc
+}
+
+func writeDocument(w io.Writer, format args.OutputFormat, doc any) error {
+	if format == args.OutputFormatYAML {
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2) // nolint:mnd
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+
+		return enc.Close()
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(doc)
+}
@@ -44,3 +44,23 @@ func humanizeDuration(since time.Duration) string {
 
 	return fmt.Sprintf("%d seconds", seconds)
 }
+
+// shortDuration returns the duration in its two largest units, e.g. "3d 4h",
+// "5h 12m" or "45s", for display in tables.
+func shortDuration(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	minutes := seconds / secondsPerMinute
+	hours := minutes / minutesPerHour
+	days := hours / hoursPerDay
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours%hoursPerDay)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes%minutesPerHour)
+	case minutes > 0:
+		return fmt.Sprintf("%dm %ds", minutes, seconds%secondsPerMinute)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
@@ -45,3 +45,14 @@ func TestHumanizeDuration(t *testing.T) {
 		assert.Equal(t, tc.expected, actual)
 	}
 }
+
+func TestShortDuration(t *testing.T) {
+	for duration, expected := range map[time.Duration]string{
+		45*time.Second + 400*time.Millisecond: "45s",
+		12*time.Minute + 5*time.Second:        "12m 5s",
+		5*time.Hour + 12*time.Minute:          "5h 12m",
+		76*time.Hour + 30*time.Minute:         "3d 4h",
+	} {
+		assert.Equal(t, expected, shortDuration(duration), duration)
+	}
+}
@@ -7,7 +7,10 @@ import (
 	"numerous.com/cli/internal/app"
 )
 
-var _ appReaderWorkloadLister = &mockAppReaderWorkloadLister{}
+var (
+	_ appReaderWorkloadLister       = &mockAppReaderWorkloadLister{}
+	_ appListerReaderWorkloadLister = &mockAppReaderWorkloadLister{}
+)
 
 type mockAppReaderWorkloadLister struct{ mock.Mock }
 
@@ -22,3 +25,9 @@ func (m *mockAppReaderWorkloadLister) ReadApp(ctx context.Context, input app.Rea
 	args := m.Called(ctx, input)
 	return args.Get(0).(app.ReadAppOutput), args.Error(1)
 }
+
+// List implements appListerReaderWorkloadLister.
+func (m *mockAppReaderWorkloadLister) List(ctx context.Context, organizationSlug string) ([]app.ListApp, error) {
+	args := m.Called(ctx, organizationSlug)
+	return args.Get(0).([]app.ListApp), args.Error(1)
+}
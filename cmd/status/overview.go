@@ -0,0 +1,312 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/output"
+	"numerous.com/cli/internal/timeseries"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+)
+
+const (
+	// overviewConcurrency is the number of apps, for which workloads are read
+	// at the same time.
+	overviewConcurrency = 8
+	// overviewSparklineWidth is the width of the usage sparklines in the table.
+	overviewSparklineWidth = 12
+)
+
+var (
+	overviewBorderStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("8"))
+	overviewHeaderStyle = lipgloss.NewStyle().
+				Border(lipgloss.NormalBorder(), false, false, true, false).
+				Foreground(lipgloss.Color("2")).
+				PaddingLeft(1).
+				PaddingRight(1)
+	overviewRowStyle = lipgloss.NewStyle().Padding(0, 1)
+)
+
+type SortBy string
+
+const (
+	SortByApp    SortBy = "app"
+	SortByCPU    SortBy = "cpu"
+	SortByMemory SortBy = "memory"
+)
+
+var errInvalidSortBy error = errors.New(`must be one of "app", "cpu" or "memory"`)
+
+func (s *SortBy) String() string {
+	return string(*s)
+}
+
+func (s *SortBy) Set(v string) error {
+	v = strings.ToLower(v)
+	switch v {
+	case "app", "cpu", "memory":
+		*s = SortBy(v)
+		return nil
+	default:
+		return errInvalidSortBy
+	}
+}
+
+func (s *SortBy) Type() string {
+	return "Sort order"
+}
+
+type appListerReaderWorkloadLister interface {
+	appReaderWorkloadLister
+	List(ctx context.Context, organizationSlug string) ([]app.ListApp, error)
+}
+
+type overviewInput struct {
+	orgSlug      string
+	metricsSince *time.Time
+	sortBy       SortBy
+	format       args.OutputFormat
+}
+
+// appOverview is the summary of the workloads of an app.
+type appOverview struct {
+	app       app.ListApp
+	workloads []app.AppWorkload
+	err       error
+}
+
+// overview shows the status of the workloads of all apps in the organization.
+func overview(ctx context.Context, apps appListerReaderWorkloadLister, input overviewInput) error {
+	listed, err := apps.List(ctx, input.orgSlug)
+	if err != nil {
+		output.PrintErrorDetails("Error listing apps in organization %q", err, input.orgSlug)
+		return err
+	}
+
+	overviews := readOverviews(ctx, apps, input, listed)
+	sortOverviews(overviews, input.sortBy)
+
+	if input.format == args.OutputFormatJSON || input.format == args.OutputFormatYAML {
+		if err := writeDocument(os.Stdout, input.format, newOverviewDocument(input.orgSlug, overviews, time.Now())); err != nil {
+			output.PrintErrorDetails("Error writing the status", err)
+			return err
+		}
+
+		return nil
+	}
+
+	if len(overviews) == 0 {
+		fmt.Printf("No apps found in organization %q\n", input.orgSlug)
+		return nil
+	}
+
+	fmt.Println(overviewTable(overviews, time.Now()))
+	for _, o := range overviews {
+		if o.err != nil {
+			output.PrintWarning("Error reading the workloads of "+o.app.Slug, o.err.Error())
+		}
+	}
+
+	return nil
+}
+
+// readOverviews reads the workloads of the apps, with a bounded number of
+// apps read at the same time.
+func readOverviews(ctx context.Context, apps appReaderWorkloadLister, input overviewInput, listed []app.ListApp) []appOverview {
+	overviews := make([]appOverview, len(listed))
+	sem := make(chan struct{}, overviewConcurrency)
+
+	var wg sync.WaitGroup
+	for i, a := range listed {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem }()
+			defer wg.Done()
+
+			workloads, err := readWorkloads(ctx, apps, input, a.Slug)
+			overviews[i] = appOverview{app: a, workloads: workloads, err: err}
+		}()
+	}
+	wg.Wait()
+
+	return overviews
+}
+
+func readWorkloads(ctx context.Context, apps appReaderWorkloadLister, input overviewInput, appSlug string) ([]app.AppWorkload, error) {
+	readOutput, err := apps.ReadApp(ctx, app.ReadAppInput{OrganizationSlug: input.orgSlug, AppSlug: appSlug})
+	if err != nil {
+		return nil, err
+	}
+
+	return apps.ListAppWorkloads(ctx, app.ListAppWorkloadsInput{AppID: readOutput.AppID, MetricsSince: input.metricsSince})
+}
+
+// sortOverviews sorts by app slug, or by descending current usage of the
+// resource.
+func sortOverviews(overviews []appOverview, sortBy SortBy) {
+	slices.SortStableFunc(overviews, func(a, b appOverview) int {
+		var usageA, usageB float64
+		switch sortBy {
+		case SortByCPU:
+			usageA, usageB = totalUsage(a.workloads, cpuUsage).Current, totalUsage(b.workloads, cpuUsage).Current
+		case SortByMemory:
+			usageA, usageB = totalUsage(a.workloads, memoryUsage).Current, totalUsage(b.workloads, memoryUsage).Current
+		case SortByApp:
+		}
+
+		if usageA != usageB {
+			if usageA > usageB {
+				return -1
+			}
+
+			return 1
+		}
+
+		return strings.Compare(a.app.Slug, b.app.Slug)
+	})
+}
+
+func cpuUsage(w app.AppWorkload) app.AppWorkloadResourceUsage    { return w.CPUUsage }
+func memoryUsage(w app.AppWorkload) app.AppWorkloadResourceUsage { return w.MemoryUsageMB }
+
+// totalUsage returns the sum of the usage of the workloads. The limit is only
+// set, if all workloads have a limit, and the timeseries sums the values at
+// the same minute.
+func totalUsage(workloads []app.AppWorkload, resource func(app.AppWorkload) app.AppWorkloadResourceUsage) app.AppWorkloadResourceUsage {
+	var total app.AppWorkloadResourceUsage
+	if len(workloads) == 0 {
+		return total
+	}
+
+	limit := 0.0
+	hasLimits := true
+	sums := make(map[time.Time]float64)
+	for _, w := range workloads {
+		usage := resource(w)
+		total.Current += usage.Current
+
+		if usage.Limit == nil {
+			hasLimits = false
+		} else {
+			limit += *usage.Limit
+		}
+
+		for _, p := range usage.Timeseries {
+			sums[p.Timestamp.Truncate(time.Minute)] += p.Value
+		}
+	}
+
+	if hasLimits {
+		total.Limit = &limit
+	}
+
+	for ts, v := range sums {
+		total.Timeseries = append(total.Timeseries, timeseries.TimeseriesPoint{Timestamp: ts, Value: v})
+	}
+	slices.SortFunc(total.Timeseries, func(a, b timeseries.TimeseriesPoint) int { return a.Timestamp.Compare(b.Timestamp) })
+
+	return total
+}
+
+// longestUptime returns the uptime of the workload, which has run the longest.
+func longestUptime(workloads []app.AppWorkload, now time.Time) (time.Duration, bool) {
+	if len(workloads) == 0 {
+		return 0, false
+	}
+
+	var uptime time.Duration
+	for _, w := range workloads {
+		uptime = max(uptime, now.Sub(w.StartedAt))
+	}
+
+	return uptime, true
+}
+
+func overviewTable(overviews []appOverview, now time.Time) *table.Table {
+	columns := []string{"App", "Status", "Workloads", "Uptime", "CPU (1024·vCPU)", "Memory (MB)"}
+	var rows [][]string
+	for _, o := range overviews {
+		if o.err != nil {
+			rows = append(rows, []string{o.app.Slug, o.app.Status, "error", "", "", ""})
+			continue
+		}
+
+		uptime := ""
+		if d, ok := longestUptime(o.workloads, now); ok {
+			uptime = shortDuration(d)
+		}
+
+		cpu, memory := totalUsage(o.workloads, cpuUsage), totalUsage(o.workloads, memoryUsage)
+		rows = append(rows, []string{
+			o.app.Slug,
+			o.app.Status,
+			fmt.Sprint(len(o.workloads)),
+			uptime,
+			strings.TrimSpace(formatUsage(cpu) + " " + output.Sparkline(cpu.Timeseries, overviewSparklineWidth)),
+			strings.TrimSpace(formatUsage(memory) + " " + output.Sparkline(memory.Timeseries, overviewSparklineWidth)),
+		})
+	}
+
+	return table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(overviewBorderStyle).
+		Headers(columns...).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == 0 {
+				return overviewHeaderStyle
+			}
+
+			return overviewRowStyle
+		})
+}
+
+// overviewDocument is the status of the apps in an organization, as written
+// with --output json or --output yaml.
+type overviewDocument struct {
+	Organization string                `json:"organization" yaml:"organization"`
+	Apps         []appOverviewDocument `json:"apps" yaml:"apps"`
+}
+
+type appOverviewDocument struct {
+	App           string         `json:"app" yaml:"app"`
+	Name          string         `json:"name" yaml:"name"`
+	Status        string         `json:"status" yaml:"status"`
+	Workloads     int            `json:"workloads" yaml:"workloads"`
+	UptimeSeconds *float64       `json:"uptime_seconds" yaml:"uptime_seconds"`
+	CPUUsage      *usageDocument `json:"cpu_usage,omitempty" yaml:"cpu_usage,omitempty"`
+	MemoryUsageMB *usageDocument `json:"memory_usage_mb,omitempty" yaml:"memory_usage_mb,omitempty"`
+	Error         string         `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func newOverviewDocument(orgSlug string, overviews []appOverview, now time.Time) overviewDocument {
+	doc := overviewDocument{Organization: orgSlug, Apps: make([]appOverviewDocument, 0, len(overviews))}
+	for _, o := range overviews {
+		ad := appOverviewDocument{App: o.app.Slug, Name: o.app.Name, Status: o.app.Status, Workloads: len(o.workloads)}
+		if o.err != nil {
+			ad.Error = o.err.Error()
+		} else {
+			if d, ok := longestUptime(o.workloads, now); ok {
+				seconds := d.Seconds()
+				ad.UptimeSeconds = &seconds
+			}
+			cpu, memory := newUsageDocument(totalUsage(o.workloads, cpuUsage)), newUsageDocument(totalUsage(o.workloads, memoryUsage))
+			ad.CPUUsage, ad.MemoryUsageMB = &cpu, &memory
+		}
+		doc.Apps = append(doc.Apps, ad)
+	}
+
+	return doc
+}
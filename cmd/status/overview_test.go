@@ -0,0 +1,214 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"numerous.com/cli/cmd/args"
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/manifest"
+	"numerous.com/cli/internal/test"
+	"numerous.com/cli/internal/timeseries"
+)
+
+func TestOverview(t *testing.T) {
+	ctx := context.Background()
+	startedAt := time.Now().Add(-2 * time.Hour)
+	listed := []app.ListApp{{Slug: "app-a", Status: "RUNNING"}, {Slug: "app-b", Status: "RUNNING"}, {Slug: "app-c", Status: "NOT DEPLOYED"}}
+	workloads := map[string][]app.AppWorkload{
+		"id-a": {{OrganizationSlug: "org", StartedAt: startedAt, CPUUsage: app.AppWorkloadResourceUsage{Current: 50}, MemoryUsageMB: app.AppWorkloadResourceUsage{Current: 100, Limit: ref(512.0)}}},
+		"id-b": {{OrganizationSlug: "org", StartedAt: startedAt, CPUUsage: app.AppWorkloadResourceUsage{Current: 10}, MemoryUsageMB: app.AppWorkloadResourceUsage{Current: 400, Limit: ref(512.0)}}},
+		"id-c": {},
+	}
+
+	newMock := func() *mockAppReaderWorkloadLister {
+		m := &mockAppReaderWorkloadLister{}
+		m.On("List", mock.Anything, "org").Return(listed, nil)
+		for _, a := range listed {
+			appID := "id-" + strings.TrimPrefix(a.Slug, "app-")
+			m.On("ReadApp", mock.Anything, app.ReadAppInput{OrganizationSlug: "org", AppSlug: a.Slug}).Return(app.ReadAppOutput{AppID: appID}, nil)
+			m.On("ListAppWorkloads", mock.Anything, app.ListAppWorkloadsInput{AppID: appID}).Return(workloads[appID], nil)
+		}
+
+		return m
+	}
+
+	appOrder := func(t *testing.T, text string) []string {
+		t.Helper()
+
+		var order []string
+		for _, line := range strings.Split(text, "\n") {
+			for _, a := range listed {
+				if strings.Contains(line, " "+a.Slug+" ") {
+					order = append(order, a.Slug)
+				}
+			}
+		}
+
+		return order
+	}
+
+	t.Run("prints table of apps sorted by slug", func(t *testing.T) {
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return overview(ctx, newMock(), overviewInput{orgSlug: "org", sortBy: SortByApp, format: args.OutputFormatText})
+		})
+
+		require.NoError(t, err)
+		data, err := io.ReadAll(stdout)
+		require.NoError(t, err)
+		text := string(data)
+		assert.Equal(t, []string{"app-a", "app-b", "app-c"}, appOrder(t, text))
+		assert.Contains(t, text, "NOT DEPLOYED")
+		assert.Contains(t, text, "2h 0m")
+		assert.Contains(t, text, "400 / 512")
+	})
+
+	t.Run("sorts by memory usage", func(t *testing.T) {
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return overview(ctx, newMock(), overviewInput{orgSlug: "org", sortBy: SortByMemory, format: args.OutputFormatText})
+		})
+
+		require.NoError(t, err)
+		data, err := io.ReadAll(stdout)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"app-b", "app-a", "app-c"}, appOrder(t, string(data)))
+	})
+
+	t.Run("sorts by CPU usage", func(t *testing.T) {
+		overviews := []appOverview{
+			{app: listed[1], workloads: workloads["id-b"]},
+			{app: listed[2], workloads: workloads["id-c"]},
+			{app: listed[0], workloads: workloads["id-a"]},
+		}
+
+		sortOverviews(overviews, SortByCPU)
+
+		assert.Equal(t, "app-a", overviews[0].app.Slug)
+		assert.Equal(t, "app-b", overviews[1].app.Slug)
+		assert.Equal(t, "app-c", overviews[2].app.Slug)
+	})
+
+	t.Run("writes JSON document", func(t *testing.T) {
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return overview(ctx, newMock(), overviewInput{orgSlug: "org", sortBy: SortByCPU, format: args.OutputFormatJSON})
+		})
+
+		require.NoError(t, err)
+		var doc overviewDocument
+		require.NoError(t, json.NewDecoder(stdout).Decode(&doc))
+		assert.Equal(t, "org", doc.Organization)
+		if assert.Len(t, doc.Apps, 3) {
+			assert.Equal(t, "app-a", doc.Apps[0].App)
+			assert.Equal(t, 1, doc.Apps[0].Workloads)
+			assert.InDelta(t, 50.0, doc.Apps[0].CPUUsage.Current, 0.001)
+			assert.Nil(t, doc.Apps[2].UptimeSeconds)
+		}
+	})
+
+	t.Run("shows apps with errors and continues", func(t *testing.T) {
+		m := &mockAppReaderWorkloadLister{}
+		m.On("List", mock.Anything, "org").Return(listed[:2], nil)
+		m.On("ReadApp", mock.Anything, app.ReadAppInput{OrganizationSlug: "org", AppSlug: "app-a"}).Return(app.ReadAppOutput{}, errTest)
+		m.On("ReadApp", mock.Anything, app.ReadAppInput{OrganizationSlug: "org", AppSlug: "app-b"}).Return(app.ReadAppOutput{AppID: "id-b"}, nil)
+		m.On("ListAppWorkloads", mock.Anything, app.ListAppWorkloadsInput{AppID: "id-b"}).Return(workloads["id-b"], nil)
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return overview(ctx, m, overviewInput{orgSlug: "org", sortBy: SortByApp, format: args.OutputFormatText})
+		})
+
+		require.NoError(t, err)
+		data, err := io.ReadAll(stdout)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "Error reading the workloads of app-a")
+		assert.Contains(t, string(data), "400 / 512")
+	})
+
+	t.Run("returns list error", func(t *testing.T) {
+		m := &mockAppReaderWorkloadLister{}
+		m.On("List", mock.Anything, "org").Return([]app.ListApp(nil), errTest)
+
+		err := overview(ctx, m, overviewInput{orgSlug: "org"})
+
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestReadOverviewsIsBounded(t *testing.T) {
+	ctx := context.Background()
+	var listed []app.ListApp
+	for i := range 3 * overviewConcurrency {
+		listed = append(listed, app.ListApp{Slug: "app-" + string(rune('a'+i))})
+	}
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	m := &mockAppReaderWorkloadLister{}
+	m.On("ReadApp", mock.Anything, mock.Anything).Return(app.ReadAppOutput{AppID: "id"}, nil)
+	m.On("ListAppWorkloads", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		mu.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+	}).Return([]app.AppWorkload{}, nil)
+
+	overviews := readOverviews(ctx, m, overviewInput{orgSlug: "org"}, listed)
+
+	assert.Len(t, overviews, len(listed))
+	for i, o := range overviews {
+		assert.Equal(t, listed[i].Slug, o.app.Slug)
+		assert.NoError(t, o.err)
+	}
+	assert.LessOrEqual(t, maxRunning, overviewConcurrency)
+}
+
+func TestTotalUsage(t *testing.T) {
+	ts := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	workloads := []app.AppWorkload{
+		{CPUUsage: app.AppWorkloadResourceUsage{Current: 1, Limit: ref(10.0), Timeseries: timeseries.Timeseries{{Timestamp: ts, Value: 1}, {Timestamp: ts.Add(time.Minute), Value: 2}}}},
+		{CPUUsage: app.AppWorkloadResourceUsage{Current: 2, Limit: ref(20.0), Timeseries: timeseries.Timeseries{{Timestamp: ts.Add(10 * time.Second), Value: 3}}}},
+	}
+
+	t.Run("sums usage, limits and timeseries", func(t *testing.T) {
+		total := totalUsage(workloads, cpuUsage)
+
+		assert.InDelta(t, 3.0, total.Current, 0.001)
+		if assert.NotNil(t, total.Limit) {
+			assert.InDelta(t, 30.0, *total.Limit, 0.001)
+		}
+		assert.Equal(t, timeseries.Timeseries{{Timestamp: ts, Value: 4}, {Timestamp: ts.Add(time.Minute), Value: 2}}, total.Timeseries)
+	})
+
+	t.Run("has no limit if a workload has no limit", func(t *testing.T) {
+		total := totalUsage(append(workloads, app.AppWorkload{}), cpuUsage)
+
+		assert.Nil(t, total.Limit)
+	})
+}
+
+func TestIsOrganizationOverview(t *testing.T) {
+	emptyDir := t.TempDir()
+	appDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(appDir, manifest.ManifestFileName), []byte("name = \"app\"\n"), 0o644))
+
+	assert.True(t, isOrganizationOverview(args.AppIdentifierArg{OrganizationSlug: "org"}, emptyDir))
+	assert.False(t, isOrganizationOverview(args.AppIdentifierArg{OrganizationSlug: "org"}, appDir))
+	assert.False(t, isOrganizationOverview(args.AppIdentifierArg{OrganizationSlug: "org", AppSlug: "app"}, emptyDir))
+	assert.False(t, isOrganizationOverview(args.AppIdentifierArg{OrganizationSlug: "org", Environment: "prod"}, emptyDir))
+	assert.False(t, isOrganizationOverview(args.AppIdentifierArg{}, emptyDir))
+}
@@ -3,8 +3,10 @@ package status
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
+	"numerous.com/cli/cmd/args"
 	"numerous.com/cli/internal/app"
 	"numerous.com/cli/internal/appident"
 	"numerous.com/cli/internal/output"
@@ -15,7 +17,9 @@ type statusInput struct {
 	appDir       string
 	appSlug      string
 	orgSlug      string
+	env          string
 	metricsSince *time.Time
+	format       args.OutputFormat
 }
 
 type appReaderWorkloadLister interface {
@@ -24,7 +28,7 @@ type appReaderWorkloadLister interface {
 }
 
 func status(ctx context.Context, apps appReaderWorkloadLister, input statusInput) error {
-	ai, err := appident.GetAppIdentifier(input.appDir, nil, input.orgSlug, input.appSlug)
+	ai, err := appident.GetAppIdentifier(input.appDir, nil, input.env, input.orgSlug, input.appSlug)
 	if err != nil {
 		appident.PrintGetAppIdentifierError(err, input.appDir, ai)
 		return err
@@ -36,17 +40,26 @@ func status(ctx context.Context, apps appReaderWorkloadLister, input statusInput
 		return err
 	}
 
-	println("Name: " + readOutput.AppDisplayName)
-	if readOutput.AppDescription != "" {
-		println("Description: " + readOutput.AppDescription)
-	}
-
 	workloads, err := apps.ListAppWorkloads(ctx, app.ListAppWorkloadsInput{AppID: readOutput.AppID, MetricsSince: input.metricsSince})
 	if err != nil {
 		app.PrintAppError(err, ai)
 		return err
 	}
 
+	if input.format == args.OutputFormatJSON || input.format == args.OutputFormatYAML {
+		if err := writeDocument(os.Stdout, input.format, newStatusDocument(ai, readOutput, workloads)); err != nil {
+			output.PrintErrorDetails("Error writing the status", err)
+			return err
+		}
+
+		return nil
+	}
+
+	println("Name: " + readOutput.AppDisplayName)
+	if readOutput.AppDescription != "" {
+		println("Description: " + readOutput.AppDescription)
+	}
+
 	println()
 	if len(workloads) == 0 {
 		println("No workloads found")
@@ -69,14 +82,7 @@ func printWorkloads(workloads []app.AppWorkload) {
 }
 
 func printWorkload(workload app.AppWorkload) {
-	if workload.OrganizationSlug != "" {
-		fmt.Printf("Workload in %q:\n", workload.OrganizationSlug)
-	} else if sub := workload.Subscription; sub != nil {
-		fmt.Printf("Workload for subscription %q in %q:\n", sub.SubscriptionUUID, sub.OrganizationSlug)
-	} else {
-		fmt.Println("Workload of unknown origin:")
-	}
-
+	fmt.Println(workloadTitle(workload))
 	fmt.Printf("  Status: %s\n", workload.Status)
 	fmt.Printf("  Started at: %s (up for %s)\n", workload.StartedAt.Format(time.DateTime), humanizeDuration(time.Since(workload.StartedAt)))
 	printCPUUsage(workload.CPUUsage)
@@ -2,13 +2,20 @@ package status
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"numerous.com/cli/cmd/args"
 	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/test"
+	"numerous.com/cli/internal/timeseries"
 )
 
 var errTest = errors.New("test error")
@@ -95,6 +102,87 @@ func TestStatus(t *testing.T) {
 	})
 }
 
+func TestStatusStructuredOutput(t *testing.T) {
+	ctx := context.Background()
+	input := statusInput{appSlug: "app-slug", orgSlug: "org-slug", appDir: t.TempDir()}
+	readAppOutput := app.ReadAppOutput{AppID: "app-id", AppDisplayName: "App Name", AppDescription: "App description"}
+	startedAt := time.Date(2024, time.January, 1, 13, 0, 0, 0, time.UTC)
+	workloads := []app.AppWorkload{
+		{
+			Subscription: &app.AppWorkloadSubscription{OrganizationSlug: "subscriber-slug", SubscriptionUUID: "subscription-id"},
+			StartedAt:    startedAt,
+			Status:       "RUNNING",
+			LogEntries:   []app.AppDeployLogEntry{{Timestamp: startedAt.Add(time.Second), Text: "log entry"}},
+			CPUUsage: app.AppWorkloadResourceUsage{
+				Current:    10,
+				Limit:      ref(20.0),
+				Timeseries: timeseries.Timeseries{{Timestamp: startedAt, Value: 5}, {Timestamp: startedAt.Add(time.Minute), Value: 10}},
+			},
+			MemoryUsageMB: app.AppWorkloadResourceUsage{Current: 30},
+		},
+	}
+
+	expected := statusDocument{
+		Organization: "org-slug",
+		App:          "app-slug",
+		Name:         "App Name",
+		Description:  "App description",
+		Workloads: []workloadDocument{
+			{
+				Subscription: &subscriptionDocument{Organization: "subscriber-slug", SubscriptionUUID: "subscription-id"},
+				Status:       "RUNNING",
+				StartedAt:    "2024-01-01T13:00:00Z",
+				CPUUsage: usageDocument{
+					Current:    10,
+					Limit:      ref(20.0),
+					Timeseries: []pointDocument{{Timestamp: "2024-01-01T13:00:00Z", Value: 5}, {Timestamp: "2024-01-01T13:01:00Z", Value: 10}},
+				},
+				MemoryUsageMB: usageDocument{Current: 30, Timeseries: []pointDocument{}},
+				Logs:          []logEntryDocument{{Timestamp: "2024-01-01T13:00:01Z", Text: "log entry"}},
+			},
+		},
+	}
+
+	for _, format := range []args.OutputFormat{args.OutputFormatJSON, args.OutputFormatYAML} {
+		t.Run("writes status as "+string(format), func(t *testing.T) {
+			mockApps := &mockAppReaderWorkloadLister{}
+			mockApps.On("ReadApp", mock.Anything, mock.Anything).Return(readAppOutput, nil)
+			mockApps.On("ListAppWorkloads", mock.Anything, mock.Anything).Return(workloads, nil)
+			input := input
+			input.format = format
+
+			stdout, err := test.RunEWithPatchedStdout(t, func() error { return status(ctx, mockApps, input) })
+
+			require.NoError(t, err)
+			data, err := io.ReadAll(stdout)
+			require.NoError(t, err)
+
+			var actual statusDocument
+			if format == args.OutputFormatJSON {
+				require.NoError(t, json.Unmarshal(data, &actual))
+			} else {
+				require.NoError(t, yaml.Unmarshal(data, &actual))
+			}
+			assert.Equal(t, expected, actual)
+		})
+	}
+
+	t.Run("writes empty lists", func(t *testing.T) {
+		mockApps := &mockAppReaderWorkloadLister{}
+		mockApps.On("ReadApp", mock.Anything, mock.Anything).Return(readAppOutput, nil)
+		mockApps.On("ListAppWorkloads", mock.Anything, mock.Anything).Return([]app.AppWorkload{}, nil)
+		input := input
+		input.format = args.OutputFormatJSON
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error { return status(ctx, mockApps, input) })
+
+		require.NoError(t, err)
+		data, err := io.ReadAll(stdout)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"workloads": []`)
+	})
+}
+
 func ref[T any](v T) *T {
 	return &v
 }
@@ -0,0 +1,398 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/appident"
+	"numerous.com/cli/internal/output"
+	"numerous.com/cli/internal/timeseries"
+
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+)
+
+var ErrNotTerminal = errors.New("not a terminal")
+
+const (
+	altScreenEnter = "\033[?1049h"
+	altScreenExit  = "\033[?1049l"
+	cursorHide     = "\033[?25l"
+	cursorShow     = "\033[?25h"
+	cursorHome     = "\033[H"
+	clearLineEnd   = "\033[K"
+	clearScreenEnd = "\033[J"
+	ansiReverse    = "\033[7m"
+
+	watchRedrawInterval = time.Second
+	watchDefaultWidth   = 80
+	watchDefaultHeight  = 24
+	watchMaxPlotHeight  = 10
+	watchMinPlotHeight  = 3
+	watchSparklineWidth = 40
+)
+
+type watchAction int
+
+const (
+	watchActionNone watchAction = iota
+	watchActionQuit
+	watchActionRefresh
+)
+
+type watchPlot int
+
+const (
+	watchPlotCPU watchPlot = iota
+	watchPlotMemory
+)
+
+// dashboard is the state of the live-updating status view of an app.
+type dashboard struct {
+	ai          appident.AppIdentifier
+	appName     string
+	interval    time.Duration
+	workloads   []app.AppWorkload
+	selected    int
+	plot        watchPlot
+	refreshedAt time.Time
+	err         error
+}
+
+// watch shows the status of the app workloads in the alternate screen of the
+// terminal, and refreshes it every interval, until the user quits.
+func watch(ctx context.Context, apps appReaderWorkloadLister, input statusInput, interval time.Duration) error {
+	ai, err := appident.GetAppIdentifier(input.appDir, nil, input.env, input.orgSlug, input.appSlug)
+	if err != nil {
+		appident.PrintGetAppIdentifierError(err, input.appDir, ai)
+		return err
+	}
+
+	stdinFd, stdoutFd := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	if !term.IsTerminal(stdinFd) || !term.IsTerminal(stdoutFd) {
+		output.PrintError("Cannot watch the status", "The --watch flag requires an interactive terminal.")
+		return ErrNotTerminal
+	}
+
+	readOutput, err := apps.ReadApp(ctx, app.ReadAppInput{OrganizationSlug: ai.OrganizationSlug, AppSlug: ai.AppSlug})
+	if err != nil {
+		app.PrintAppError(err, ai)
+		return err
+	}
+
+	// the metrics window slides along with the refreshes
+	window := time.Hour
+	if input.metricsSince != nil {
+		window = time.Since(*input.metricsSince)
+	}
+
+	d := &dashboard{ai: ai, appName: readOutput.AppDisplayName, interval: interval}
+	refresh := func() {
+		since := time.Now().Add(-window)
+		workloads, err := apps.ListAppWorkloads(ctx, app.ListAppWorkloadsInput{AppID: readOutput.AppID, MetricsSince: &since})
+		d.update(workloads, err, time.Now())
+	}
+
+	oldState, err := term.MakeRaw(stdinFd)
+	if err != nil {
+		output.PrintErrorDetails("Cannot watch the status", err)
+		return err
+	}
+	defer term.Restore(stdinFd, oldState) // nolint:errcheck
+
+	fmt.Print(altScreenEnter + cursorHide)
+	defer fmt.Print(cursorShow + altScreenExit)
+
+	keys := make(chan []byte)
+	go readKeys(os.Stdin, keys)
+
+	refreshTicker := time.NewTicker(interval)
+	defer refreshTicker.Stop()
+	redrawTicker := time.NewTicker(watchRedrawInterval)
+	defer redrawTicker.Stop()
+
+	refresh()
+	for {
+		width, height, err := term.GetSize(stdoutFd)
+		if err != nil {
+			width, height = watchDefaultWidth, watchDefaultHeight
+		}
+		fmt.Print(cursorHome + d.render(time.Now(), width, height) + clearScreenEnd)
+
+		select {
+		case data, ok := <-keys:
+			if !ok {
+				return nil
+			}
+
+			for _, key := range parseKeys(data) {
+				switch d.handleKey(key) {
+				case watchActionQuit:
+					return nil
+				case watchActionRefresh:
+					refresh()
+				case watchActionNone:
+				}
+			}
+		case <-refreshTicker.C:
+			refresh()
+		case <-redrawTicker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func readKeys(r io.Reader, keys chan<- []byte) {
+	defer close(keys)
+
+	buf := make([]byte, 32) // nolint:mnd
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			return
+		}
+		keys <- append([]byte(nil), buf[:n]...)
+	}
+}
+
+// parseKeys returns the names of the keys pressed in the terminal input.
+func parseKeys(data []byte) []string {
+	var keys []string
+	for i := 0; i < len(data); i++ {
+		switch b := data[i]; {
+		case b == '\033' && i+2 < len(data) && data[i+1] == '[':
+			switch data[i+2] {
+			case 'C':
+				keys = append(keys, "right")
+			case 'D':
+				keys = append(keys, "left")
+			case 'Z':
+				keys = append(keys, "shift+tab")
+			}
+			i += 2
+		case b == 3: // nolint:mnd
+			keys = append(keys, "ctrl+c")
+		case b == '\t':
+			keys = append(keys, "tab")
+		case b >= ' ' && b < 127: // nolint:mnd
+			keys = append(keys, string(b))
+		}
+	}
+
+	return keys
+}
+
+// handleKey updates the dashboard for the key, and returns the action to take.
+func (d *dashboard) handleKey(key string) watchAction {
+	switch key {
+	case "q", "ctrl+c":
+		return watchActionQuit
+	case "r":
+		return watchActionRefresh
+	case "right", "tab", "n", "l":
+		if len(d.workloads) > 0 {
+			d.selected = (d.selected + 1) % len(d.workloads)
+		}
+	case "left", "shift+tab", "p", "h":
+		if len(d.workloads) > 0 {
+			d.selected = (d.selected - 1 + len(d.workloads)) % len(d.workloads)
+		}
+	case "c":
+		d.plot = watchPlotCPU
+	case "m":
+		d.plot = watchPlotMemory
+	default:
+		if n, err := strconv.Atoi(key); err == nil && n >= 1 && n <= len(d.workloads) {
+			d.selected = n - 1
+		}
+	}
+
+	return watchActionNone
+}
+
+// update sets the workloads of a refresh, keeping the selected workload, or
+// keeps the previous workloads and shows the error, if the refresh failed.
+func (d *dashboard) update(workloads []app.AppWorkload, err error, now time.Time) {
+	d.err = err
+	if err != nil {
+		return
+	}
+
+	var selectedKey string
+	if d.selected < len(d.workloads) {
+		selectedKey = workloadKey(d.workloads[d.selected])
+	}
+
+	d.workloads = workloads
+	d.refreshedAt = now
+	d.selected = 0
+	for i, w := range workloads {
+		if workloadKey(w) == selectedKey {
+			d.selected = i
+		}
+	}
+}
+
+func workloadKey(w app.AppWorkload) string {
+	if w.Subscription != nil {
+		return "subscription/" + w.Subscription.SubscriptionUUID
+	}
+
+	return "organization/" + w.OrganizationSlug
+}
+
+// render returns the frame of the dashboard, with lines fitted to the width
+// and height of the terminal.
+func (d *dashboard) render(now time.Time, width, height int) string {
+	lines := []string{
+		output.AnsiCyanBold + d.appName + output.AnsiReset + " " + output.AnsiFaint + d.ai.OrganizationSlug + "/" + d.ai.AppSlug + output.AnsiReset +
+			"  " + output.AnsiFaint + fmt.Sprintf("updated %s, every %s", d.refreshedAt.Format(time.TimeOnly), d.interval) + output.AnsiReset,
+		d.renderTabs(),
+		"",
+	}
+
+	footer := []string{output.AnsiFaint + "←/→ switch workload · 1-9 select · c/m CPU/memory plot · r refresh · q quit" + output.AnsiReset}
+	if d.err != nil {
+		footer = append([]string{output.AnsiRed + "Error refreshing: " + d.err.Error() + output.AnsiReset}, footer...)
+	}
+
+	if len(d.workloads) == 0 {
+		lines = append(lines, "No workloads found")
+	} else {
+		lines = append(lines, d.renderWorkload(d.workloads[d.selected], now, width, height-len(lines)-len(footer))...)
+	}
+
+	return fitLines(lines, footer, width, height)
+}
+
+func (d *dashboard) renderTabs() string {
+	tabs := make([]string, 0, len(d.workloads))
+	for i, w := range d.workloads {
+		tab := fmt.Sprintf(" %d %s %s ", i+1, workloadOrigin(w), colorStatus(w.Status))
+		if i == d.selected {
+			tab = ansiReverse + tab + output.AnsiReset
+		}
+		tabs = append(tabs, tab)
+	}
+
+	return strings.Join(tabs, " ")
+}
+
+// renderWorkload returns the lines of the workload details, with a plot and
+// logs fitted to the available height.
+func (d *dashboard) renderWorkload(w app.AppWorkload, now time.Time, width, available int) []string {
+	sparklineWidth := min(watchSparklineWidth, max(0, width-40)) // nolint:mnd
+	lines := []string{
+		workloadTitle(w),
+		"  Status: " + colorStatus(w.Status),
+		fmt.Sprintf("  Started at: %s (up for %s)", w.StartedAt.Format(time.DateTime), humanizeDuration(now.Sub(w.StartedAt))),
+		fmt.Sprintf("  CPU Usage (1024·vCPU): %-12s %s", formatUsage(w.CPUUsage), output.Sparkline(w.CPUUsage.Timeseries, sparklineWidth)),
+		fmt.Sprintf("  Memory Usage (MB):     %-12s %s", formatUsage(w.MemoryUsageMB), output.Sparkline(w.MemoryUsageMB.Timeseries, sparklineWidth)),
+		"",
+	}
+
+	plotTitle, plotData := "  CPU Usage (1024·vCPU):", w.CPUUsage.Timeseries
+	if d.plot == watchPlotMemory {
+		plotTitle, plotData = "  Memory Usage (MB):", w.MemoryUsageMB.Timeseries
+	}
+
+	// the plot takes its height plus two lines for the x-axis, and the logs
+	// need at least their title and a line
+	plotHeight := min(watchMaxPlotHeight, available-len(lines)-1-2-2) // nolint:mnd
+	if plotHeight >= watchMinPlotHeight && len(plotData) > 0 {
+		lines = append(lines, plotTitle)
+		lines = append(lines, renderPlot(plotData, width, plotHeight)...)
+	}
+
+	// the logs title and at least one entry must fit, for the logs to be shown
+	logLines := max(0, available-len(lines)-1)
+	if logLines == 0 {
+		return lines
+	}
+
+	lines = append(lines, "  Logs:")
+	entries := w.LogEntries[max(0, len(w.LogEntries)-logLines):]
+	for _, entry := range entries {
+		lines = append(lines, "    "+output.AnsiFaint+entry.Timestamp.Format(time.RFC3339)+output.AnsiReset+" "+entry.Text)
+	}
+
+	return lines
+}
+
+// fitLines returns the lines truncated to the width, and the footer at the
+// bottom of the height, separated by "\r\n" since the terminal is in raw mode.
+func fitLines(lines []string, footer []string, width, height int) string {
+	bodyHeight := max(0, height-len(footer))
+	if len(lines) > bodyHeight {
+		lines = lines[:bodyHeight]
+	}
+
+	for len(lines) < bodyHeight {
+		lines = append(lines, "")
+	}
+	lines = append(lines, footer...)
+
+	truncate := lipgloss.NewStyle().MaxWidth(width)
+	for i, line := range lines {
+		lines[i] = truncate.Render(line) + clearLineEnd
+	}
+
+	return strings.Join(lines, "\r\n")
+}
+
+// plotTerminal collects a plot rendered for the given width.
+type plotTerminal struct {
+	width int
+	buf   strings.Builder
+}
+
+func (t *plotTerminal) IsTerminal() bool           { return true }
+func (t *plotTerminal) GetSize() (int, int, error) { return t.width, 0, nil }
+func (t *plotTerminal) Writer() io.Writer          { return &t.buf }
+
+func renderPlot(data timeseries.Timeseries, width, height int) []string {
+	t := &plotTerminal{width: width}
+	output.NewPlotWithTerm(data, t).Display("    ", height)
+
+	return strings.Split(strings.TrimSuffix(t.buf.String(), "\n"), "\n")
+}
+
+func workloadTitle(w app.AppWorkload) string {
+	if w.OrganizationSlug != "" {
+		return fmt.Sprintf("Workload in %q:", w.OrganizationSlug)
+	} else if sub := w.Subscription; sub != nil {
+		return fmt.Sprintf("Workload for subscription %q in %q:", sub.SubscriptionUUID, sub.OrganizationSlug)
+	}
+
+	return "Workload of unknown origin:"
+}
+
+// workloadOrigin returns a short description of where the workload runs.
+func workloadOrigin(w app.AppWorkload) string {
+	if w.OrganizationSlug != "" {
+		return w.OrganizationSlug
+	} else if sub := w.Subscription; sub != nil {
+		return sub.OrganizationSlug + " (subscription)"
+	}
+
+	return "unknown"
+}
+
+func colorStatus(status string) string {
+	switch strings.ToUpper(status) {
+	case "RUNNING":
+		return output.AnsiGreen + status + output.AnsiReset
+	case "ERROR", "FAILED", "CRASHED":
+		return output.AnsiRed + status + output.AnsiReset
+	default:
+		return output.AnsiYellow + status + output.AnsiReset
+	}
+}
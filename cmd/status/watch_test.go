@@ -0,0 +1,141 @@
+package status
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/appident"
+	"numerous.com/cli/internal/timeseries"
+)
+
+func TestParseKeys(t *testing.T) {
+	assert.Equal(t, []string{"right", "left", "shift+tab", "tab", "ctrl+c", "q", "2"}, parseKeys([]byte("\033[C\033[D\033[Z\t\x03q2")))
+}
+
+func TestDashboardHandleKey(t *testing.T) {
+	newDashboard := func() *dashboard {
+		return &dashboard{workloads: []app.AppWorkload{{OrganizationSlug: "a"}, {OrganizationSlug: "b"}, {OrganizationSlug: "c"}}}
+	}
+
+	t.Run("switches between workloads", func(t *testing.T) {
+		d := newDashboard()
+
+		d.handleKey("right")
+		assert.Equal(t, 1, d.selected)
+		d.handleKey("tab")
+		d.handleKey("right")
+		assert.Equal(t, 0, d.selected)
+		d.handleKey("left")
+		assert.Equal(t, 2, d.selected)
+		d.handleKey("2")
+		assert.Equal(t, 1, d.selected)
+		d.handleKey("9")
+		assert.Equal(t, 1, d.selected)
+	})
+
+	t.Run("switches plot", func(t *testing.T) {
+		d := newDashboard()
+
+		d.handleKey("m")
+		assert.Equal(t, watchPlotMemory, d.plot)
+		d.handleKey("c")
+		assert.Equal(t, watchPlotCPU, d.plot)
+	})
+
+	t.Run("returns actions", func(t *testing.T) {
+		d := newDashboard()
+
+		assert.Equal(t, watchActionQuit, d.handleKey("q"))
+		assert.Equal(t, watchActionQuit, d.handleKey("ctrl+c"))
+		assert.Equal(t, watchActionRefresh, d.handleKey("r"))
+		assert.Equal(t, watchActionNone, d.handleKey("x"))
+	})
+}
+
+func TestDashboardUpdate(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 13, 0, 0, 0, time.UTC)
+	sub := &app.AppWorkloadSubscription{OrganizationSlug: "sub-org", SubscriptionUUID: "sub-id"}
+
+	t.Run("keeps the selected workload when the order changes", func(t *testing.T) {
+		d := &dashboard{workloads: []app.AppWorkload{{OrganizationSlug: "a"}, {Subscription: sub}}, selected: 1}
+
+		d.update([]app.AppWorkload{{Subscription: sub}, {OrganizationSlug: "a"}}, nil, now)
+
+		assert.Equal(t, 0, d.selected)
+		assert.Equal(t, now, d.refreshedAt)
+	})
+
+	t.Run("keeps the workloads when the refresh fails", func(t *testing.T) {
+		workloads := []app.AppWorkload{{OrganizationSlug: "a"}}
+		d := &dashboard{workloads: workloads}
+
+		d.update(nil, errTest, now)
+
+		assert.Equal(t, workloads, d.workloads)
+		assert.ErrorIs(t, d.err, errTest)
+	})
+}
+
+func TestDashboardRender(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 14, 0, 0, 0, time.UTC)
+	startedAt := time.Date(2024, time.January, 1, 13, 0, 0, 0, time.UTC)
+	cpu := timeseries.Timeseries{
+		{Timestamp: startedAt, Value: 1},
+		{Timestamp: startedAt.Add(time.Minute), Value: 5},
+		{Timestamp: startedAt.Add(2 * time.Minute), Value: 3},
+	}
+	d := &dashboard{
+		ai:       appident.AppIdentifier{OrganizationSlug: "org", AppSlug: "app"},
+		appName:  "App Name",
+		interval: 5 * time.Second,
+		workloads: []app.AppWorkload{
+			{
+				OrganizationSlug: "org",
+				Status:           "RUNNING",
+				StartedAt:        startedAt,
+				CPUUsage:         app.AppWorkloadResourceUsage{Current: 3, Timeseries: cpu},
+				LogEntries: []app.AppDeployLogEntry{
+					{Timestamp: startedAt, Text: "old log entry"},
+					{Timestamp: startedAt.Add(time.Minute), Text: "latest log entry"},
+				},
+			},
+			{Subscription: &app.AppWorkloadSubscription{OrganizationSlug: "sub-org", SubscriptionUUID: "sub-id"}, Status: "PENDING"},
+		},
+	}
+
+	t.Run("renders the selected workload", func(t *testing.T) {
+		frame := d.render(now, 100, 40)
+
+		assert.Contains(t, frame, "App Name")
+		assert.Contains(t, frame, `Workload in "org":`)
+		assert.Contains(t, frame, "(up for 60 minutes and 0 seconds)")
+		assert.Contains(t, frame, "▁█▄")
+		assert.Contains(t, frame, "latest log entry")
+		assert.Contains(t, frame, "q quit")
+		assert.Len(t, strings.Split(frame, "\r\n"), 40)
+	})
+
+	t.Run("shows only the latest logs that fit", func(t *testing.T) {
+		frame := d.render(now, 100, 12)
+
+		assert.Contains(t, frame, "latest log entry")
+		assert.NotContains(t, frame, "old log entry")
+		assert.Len(t, strings.Split(frame, "\r\n"), 12)
+	})
+
+	t.Run("renders on small terminals", func(t *testing.T) {
+		for height := 0; height <= 12; height++ {
+			assert.NotPanics(t, func() { d.render(now, 80, height) }, "height %d", height)
+		}
+	})
+
+	t.Run("shows refresh error", func(t *testing.T) {
+		d := *d
+		d.err = errTest
+
+		assert.Contains(t, d.render(now, 100, 40), "Error refreshing: test error")
+	})
+}
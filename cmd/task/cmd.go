@@ -6,6 +6,7 @@ import (
 	"numerous.com/cli/cmd/task/instance"
 	"numerous.com/cli/cmd/task/instances"
 	"numerous.com/cli/cmd/task/list"
+	"numerous.com/cli/cmd/task/run"
 
 	"github.com/spf13/cobra"
 )
@@ -21,4 +22,5 @@ func init() {
 	Cmd.AddCommand(list.Cmd)
 	Cmd.AddCommand(instance.Cmd)
 	Cmd.AddCommand(instances.Cmd)
+	Cmd.AddCommand(run.Cmd)
 }
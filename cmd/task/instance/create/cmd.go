@@ -69,6 +69,7 @@ func run(cmd *cobra.Command, args []string) error {
 		AppDir:           cmdArgs.appDir,
 		OrganizationSlug: cmdArgs.appIdent.OrganizationSlug,
 		AppSlug:          cmdArgs.appIdent.AppSlug,
+		Environment:      cmdArgs.appIdent.Environment,
 		TaskName:         taskName,
 		Input:            cmdArgs.input,
 		InputFile:        cmdArgs.inputFile,
@@ -22,13 +22,14 @@ type TaskStartInput struct {
 	AppDir           string
 	OrganizationSlug string
 	AppSlug          string
+	Environment      string
 	TaskName         string
 	Input            string
 	InputFile        string
 }
 
 func startTask(ctx context.Context, service taskStartService, params TaskStartInput) error {
-	ai, err := appident.GetAppIdentifier(params.AppDir, nil, params.OrganizationSlug, params.AppSlug)
+	ai, err := appident.GetAppIdentifier(params.AppDir, nil, params.Environment, params.OrganizationSlug, params.AppSlug)
 	if err != nil {
 		appident.PrintGetAppIdentifierError(err, params.AppDir, ai)
 		return err
@@ -2,10 +2,14 @@ package logs
 
 import (
 	"net/http"
+	"os"
 
+	"numerous.com/cli/cmd/args"
 	"numerous.com/cli/cmd/errorhandling"
+	applogs "numerous.com/cli/cmd/logs"
 	"numerous.com/cli/internal/app"
 	"numerous.com/cli/internal/gql"
+	"numerous.com/cli/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -25,7 +29,15 @@ To tail the last 100 lines and follow new logs:
 
 To get logs without following (one-time read):
 
-    numerous task instance logs ce5aba38-842d-4ee0-877b-4af9d426c848 --follow=false`
+    numerous task instance logs ce5aba38-842d-4ee0-877b-4af9d426c848 --follow=false
+
+To show errors of the last hour, with 5 lines of context:
+
+    numerous task instance logs ce5aba38-842d-4ee0-877b-4af9d426c848 --since 1h --level error --context 5
+
+To print log entries as JSON objects, one per line:
+
+    numerous task instance logs ce5aba38-842d-4ee0-877b-4af9d426c848 --output json`
 
 var Cmd = &cobra.Command{
 	Use:     "logs <instance-id>",
@@ -36,39 +48,63 @@ var Cmd = &cobra.Command{
 	Args:    cobra.ExactArgs(1),
 }
 
-var cmdArgs struct {
+var cmdArgs = struct {
 	timestamps bool
 	tail       int
 	follow     bool
+	filter     applogs.FilterArgs
+	format     applogs.FormatArgs
+	output     args.OutputFormatArg
+}{
+	output: args.NewOutputFormatArg(args.OutputFormatText, args.OutputFormatJSON),
 }
 
 func run(cmd *cobra.Command, args []string) error {
 	instanceID := args[0]
+	printer := entryPrinter(instanceID)
 
-	var printer func(app.WorkloadLogEntry)
-	if cmdArgs.timestamps {
-		printer = TimestampPrinter
-	} else {
-		printer = TextPrinter
+	filter, err := cmdArgs.filter.Options()
+	if err != nil {
+		output.PrintErrorDetails("Invalid log filter", err)
+		return errorhandling.ErrorAlreadyPrinted(err)
 	}
 
+	var streamErr error
 	sc := gql.NewSubscriptionClient().WithSyncMode(true)
-	service := app.New(gql.NewClient(), sc, http.DefaultClient)
+	service := app.New(gql.NewClient(), sc, http.DefaultClient).WithReconnect(applogs.ReconnectOptions(func(err error) { streamErr = err }))
 
 	input := taskLogsInput{
 		instanceID: instanceID,
 		tail:       cmdArgs.tail,
 		follow:     cmdArgs.follow,
+		filter:     filter,
+		format:     cmdArgs.output.Format,
 		printer:    printer,
 	}
-	err := taskLogs(cmd.Context(), service, input)
+	err = taskLogs(cmd.Context(), service, input)
+	if err == nil && streamErr != nil {
+		applogs.PrintConnectionLost(streamErr)
+		err = streamErr
+	}
 
 	return errorhandling.ErrorAlreadyPrinted(err)
 }
 
+// entryPrinter returns the printer of log entries for the output format.
+func entryPrinter(instanceID string) func(app.WorkloadLogEntry) {
+	if cmdArgs.output.Format == args.OutputFormatJSON {
+		return JSONPrinter(os.Stdout, instanceID)
+	}
+
+	return Printer(cmdArgs.format.Options(), cmdArgs.timestamps)
+}
+
 func init() {
 	flags := Cmd.Flags()
 	flags.BoolVarP(&cmdArgs.timestamps, "timestamps", "t", false, "Print a timestamp for each log entry.")
 	flags.IntVarP(&cmdArgs.tail, "tail", "n", 0, "Number of lines to show from the end")
 	flags.BoolVarP(&cmdArgs.follow, "follow", "f", true, "Continue streaming new log entries (default: true)")
+	cmdArgs.filter.AddFlags(flags)
+	cmdArgs.format.AddFlags(flags)
+	flags.Var(&cmdArgs.output, "output", `The output format, either "text" or "json". With "json", each log entry is printed as a JSON object on a separate line, with its timestamp, text and task instance ID.`)
 }
@@ -2,10 +2,16 @@ package logs
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"time"
 
+	"numerous.com/cli/cmd/args"
+	applogs "numerous.com/cli/cmd/logs"
 	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/logfilter"
+	"numerous.com/cli/internal/logformat"
 	"numerous.com/cli/internal/output"
 )
 
@@ -17,6 +23,8 @@ type taskLogsInput struct {
 	instanceID string
 	tail       int
 	follow     bool
+	filter     logfilter.Options
+	format     args.OutputFormat
 	printer    func(app.WorkloadLogEntry)
 }
 
@@ -37,24 +45,61 @@ func taskLogs(ctx context.Context, service taskLogsService, input taskLogsInput)
 		return err
 	}
 
+	filter := logfilter.New(input.filter)
 	for {
 		select {
 		case entry, ok := <-ch:
 			if !ok {
 				return nil
 			}
-			input.printer(entry)
+
+			if filter.PastUntil(logfilter.Entry(entry)) {
+				return nil
+			}
+
+			entries, separate := filter.Add(logfilter.Entry(entry))
+			if separate && input.format != args.OutputFormatJSON {
+				applogs.PrintSeparator()
+			}
+
+			for _, e := range entries {
+				input.printer(app.WorkloadLogEntry(e))
+			}
 		case <-ctx.Done():
 			return nil
 		}
 	}
 }
 
-func TimestampPrinter(entry app.WorkloadLogEntry) {
-	ts := output.AnsiFaint + entry.Timestamp.Format(time.RFC3339) + output.AnsiReset
-	fmt.Println(ts + " " + entry.Text)
+// Printer returns a printer of entries, which formats JSON log lines with the
+// options, and prints timestamps if enabled.
+func Printer(opts logformat.Options, timestamps bool) func(app.WorkloadLogEntry) {
+	return func(entry app.WorkloadLogEntry) {
+		timestamp, text := applogs.FormatLine(entry.Timestamp, entry.Text, opts)
+		if timestamps {
+			text = output.AnsiFaint + timestamp.Format(time.RFC3339) + output.AnsiReset + " " + text
+		}
+
+		fmt.Println(text)
+	}
 }
 
-func TextPrinter(entry app.WorkloadLogEntry) {
-	fmt.Println(entry.Text)
+type jsonLogEntry struct {
+	Timestamp      string `json:"timestamp"`
+	Text           string `json:"text"`
+	TaskInstanceID string `json:"task_instance_id"`
+}
+
+// JSONPrinter returns a printer writing each entry of the task instance logs
+// as a JSON object on a separate line.
+func JSONPrinter(w io.Writer, instanceID string) func(app.WorkloadLogEntry) {
+	enc := json.NewEncoder(w)
+
+	return func(entry app.WorkloadLogEntry) {
+		enc.Encode(jsonLogEntry{ // nolint:errcheck
+			Timestamp:      entry.Timestamp.Format(time.RFC3339Nano),
+			Text:           entry.Text,
+			TaskInstanceID: instanceID,
+		})
+	}
 }
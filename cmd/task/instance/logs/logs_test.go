@@ -1,12 +1,15 @@
 package logs
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"regexp"
 	"testing"
 	"time"
 
 	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/logfilter"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -129,6 +132,42 @@ func TestTaskLogs(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 
+	t.Run("prints filtered entries", func(t *testing.T) {
+		ch := make(chan app.WorkloadLogEntry)
+		service := &TaskLogsServiceMock{}
+
+		expectedInput := app.TaskInstanceLogsInput{
+			InstanceID: instanceID,
+			Tail:       nil,
+			Follow:     true,
+		}
+		service.On("TaskInstanceLogs", expectedInput).Return(ch, nil)
+
+		entry1 := app.WorkloadLogEntry{Timestamp: time.Date(2024, time.March, 1, 1, 1, 1, 1, time.UTC), Text: "Task started"}
+		entry2 := app.WorkloadLogEntry{Timestamp: time.Date(2024, time.March, 1, 2, 2, 2, 2, time.UTC), Text: "Task processing"}
+		actual := []app.WorkloadLogEntry{}
+		printer := func(e app.WorkloadLogEntry) {
+			actual = append(actual, e)
+		}
+
+		go func() {
+			defer close(ch)
+			ch <- entry1
+			ch <- entry2
+		}()
+
+		input := taskLogsInput{
+			instanceID: instanceID,
+			follow:     true,
+			filter:     logfilter.Options{Grep: []*regexp.Regexp{regexp.MustCompile("process")}},
+			printer:    printer,
+		}
+		err := taskLogs(context.TODO(), service, input)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []app.WorkloadLogEntry{entry2}, actual)
+	})
+
 	t.Run("handles channel close gracefully", func(t *testing.T) {
 		ch := make(chan app.WorkloadLogEntry)
 		service := &TaskLogsServiceMock{}
@@ -154,3 +193,15 @@ func TestTaskLogs(t *testing.T) {
 		service.AssertExpectations(t)
 	})
 }
+
+func TestJSONPrinter(t *testing.T) {
+	var buf bytes.Buffer
+	printer := JSONPrinter(&buf, "test-task-instance-id")
+
+	printer(app.WorkloadLogEntry{Timestamp: time.Date(2024, time.March, 1, 1, 1, 1, 1, time.UTC), Text: "Task started"})
+	printer(app.WorkloadLogEntry{Timestamp: time.Date(2024, time.March, 1, 2, 2, 2, 0, time.UTC), Text: "Task processing"})
+
+	assert.Equal(t, `{"timestamp":"2024-03-01T01:01:01.000000001Z","text":"Task started","task_instance_id":"test-task-instance-id"}
+{"timestamp":"2024-03-01T02:02:02Z","text":"Task processing","task_instance_id":"test-task-instance-id"}
+`, buf.String())
+}
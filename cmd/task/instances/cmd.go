@@ -59,6 +59,7 @@ func run(cmd *cobra.Command, args []string) error {
 		AppDir:           cmdArgs.appDir,
 		OrganizationSlug: cmdArgs.appIdent.OrganizationSlug,
 		AppSlug:          cmdArgs.appIdent.AppSlug,
+		Environment:      cmdArgs.appIdent.Environment,
 		TaskID:           taskID,
 	}
 	err := listInstances(cmd.Context(), service, input)
@@ -19,11 +19,12 @@ type TaskInstancesInput struct {
 	AppDir           string
 	OrganizationSlug string
 	AppSlug          string
+	Environment      string
 	TaskID           string
 }
 
 func listInstances(ctx context.Context, service taskInstancesService, params TaskInstancesInput) error {
-	ai, err := appident.GetAppIdentifier(params.AppDir, nil, params.OrganizationSlug, params.AppSlug)
+	ai, err := appident.GetAppIdentifier(params.AppDir, nil, params.Environment, params.OrganizationSlug, params.AppSlug)
 	if err != nil {
 		appident.PrintGetAppIdentifierError(err, params.AppDir, ai)
 		return err
@@ -52,6 +52,7 @@ func run(cmd *cobra.Command, args []string) error {
 		AppDir:           cmdArgs.appDir,
 		OrganizationSlug: cmdArgs.appIdent.OrganizationSlug,
 		AppSlug:          cmdArgs.appIdent.AppSlug,
+		Environment:      cmdArgs.appIdent.Environment,
 	}
 	err := list(cmd.Context(), service, input)
 
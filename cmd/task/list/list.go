@@ -16,10 +16,11 @@ type TaskListInput struct {
 	AppDir           string
 	OrganizationSlug string
 	AppSlug          string
+	Environment      string
 }
 
 func list(ctx context.Context, service tasksService, params TaskListInput) error {
-	ai, err := appident.GetAppIdentifier(params.AppDir, nil, params.OrganizationSlug, params.AppSlug)
+	ai, err := appident.GetAppIdentifier(params.AppDir, nil, params.Environment, params.OrganizationSlug, params.AppSlug)
 	if err != nil {
 		appident.PrintGetAppIdentifierError(err, params.AppDir, ai)
 		return err
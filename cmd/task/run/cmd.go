@@ -0,0 +1,89 @@
+package run
+
+import (
+	"os/exec"
+
+	"numerous.com/cli/cmd/errorhandling"
+	"numerous.com/cli/cmd/usage"
+
+	"github.com/spf13/cobra"
+)
+
+const long string = `Runs a task locally, the same way it is run by the platform.
+
+The task command is read from the "tasks.<task-name>" table of the app
+manifest. If the task is not defined in the manifest, a python file named after
+the task, e.g. "worker.py" for the task "worker", is run with python.
+
+The command is run in the app directory with the environment variables of the
+[env] table of the manifest and the secrets of the ".env" file. The task input
+is passed base64 encoded in the TASK_DATA_INPUT environment variable, like the
+platform does, and is limited to 4KB when encoded.
+
+The standard output of the task is captured as the task output, and printed
+with the exit code of the task when it finishes.
+
+Tasks can only be run locally with this command. To start a task in an app
+deployment, use "numerous task instance create".
+
+` + usage.AppDirectoryArgument + `
+`
+
+var cmdArgs struct {
+	appDir    string
+	local     bool
+	env       string
+	input     string
+	inputFile string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "run <task-name> [app directory]",
+	RunE:  runCmd,
+	Short: "Run a task locally",
+	Long:  long,
+	Args:  cobra.RangeArgs(1, 2), // nolint:mnd
+	Example: `To run the task named "worker" of the app in the current directory:
+
+	numerous task run --local worker
+
+With input data:
+
+	numerous task run --local worker --input '{"user_id": 123, "action": "process"}'
+
+With input from a file, for the app in another directory:
+
+	numerous task run --local worker --input-file config.json my_project/my_app`,
+}
+
+func runCmd(cmd *cobra.Command, args []string) error {
+	cmdArgs.appDir = ""
+	if len(args) > 1 {
+		cmdArgs.appDir = args[1]
+	}
+
+	input := runInput{
+		appDir:    cmdArgs.appDir,
+		local:     cmdArgs.local,
+		env:       cmdArgs.env,
+		taskName:  args[0],
+		input:     cmdArgs.input,
+		inputFile: cmdArgs.inputFile,
+	}
+
+	err := run(cmd.Context(), execCommand, input)
+
+	return errorhandling.ErrorAlreadyPrinted(err)
+}
+
+func execCommand(cmd *exec.Cmd) error {
+	return cmd.Run()
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.BoolVar(&cmdArgs.local, "local", false, "Run the task locally in the app directory.")
+	flags.StringVar(&cmdArgs.env, "env", "", "The deploy environment, defined in a \"deploy.<environment>\" table in the app manifest, whose environment file is used for secrets.")
+	flags.StringVar(&cmdArgs.input, "input", "", "Input data to pass to the task")
+	flags.StringVar(&cmdArgs.inputFile, "input-file", "", "Path to file containing input data to pass to the task")
+}
@@ -0,0 +1,201 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/manifest"
+	"numerous.com/cli/internal/output"
+)
+
+var (
+	ErrNotLocal              = errors.New("tasks can only be run locally")
+	ErrConflictingInputFlags = errors.New("cannot specify both --input and --input-file")
+	ErrTaskNotFound          = errors.New("task not found")
+	ErrTaskFailed            = errors.New("task failed")
+)
+
+// taskInputEnvVar is the environment variable the platform passes the base64
+// encoded task input in.
+const taskInputEnvVar = "TASK_DATA_INPUT"
+
+type runInput struct {
+	appDir    string
+	local     bool
+	env       string
+	taskName  string
+	input     string
+	inputFile string
+}
+
+type commandExecutor func(cmd *exec.Cmd) error
+
+func run(ctx context.Context, execute commandExecutor, input runInput) error {
+	if !input.local {
+		output.PrintError(
+			"Tasks can only be run locally with this command",
+			"Use the --local flag to run the task locally, or start the task in the app deployment with \"numerous task instance create\".",
+		)
+
+		return ErrNotLocal
+	}
+
+	if input.input != "" && input.inputFile != "" {
+		output.PrintError("Cannot specify both --input and --input-file", "")
+		return ErrConflictingInputFlags
+	}
+
+	manifestPath := filepath.Join(input.appDir, manifest.ManifestFileName)
+	m, format, err := manifest.LoadWithFormat(manifestPath)
+	if err != nil {
+		output.PrintErrorAppNotInitialized(input.appDir)
+		output.PrintManifestTOMLError(err)
+
+		return err
+	}
+	manifest.WarnIfDeprecated(manifestPath, format)
+
+	deployment, err := m.ResolveDeployEnvironment(input.env)
+	if err != nil {
+		output.PrintErrorDetails("Error resolving the deploy environment", err)
+		return err
+	}
+
+	command, err := taskCommand(input.appDir, m, input.taskName)
+	if err != nil {
+		printTaskNotFound(m, input.taskName)
+		return err
+	}
+
+	taskInput := input.input
+	if input.inputFile != "" {
+		fileContent, err := os.ReadFile(input.inputFile)
+		if err != nil {
+			output.PrintErrorDetails("Error reading input file", err)
+			return err
+		}
+		taskInput = string(fileContent)
+	}
+
+	var encodedInput *string
+	if taskInput != "" {
+		encoded, err := app.EncodeTaskInput(taskInput)
+		if err != nil {
+			output.PrintErrorDetails("Invalid task input", err)
+			return err
+		}
+		encodedInput = &encoded
+	}
+
+	secrets, err := deployment.LoadSecrets(input.appDir)
+	if err != nil {
+		output.PrintErrorDetails("Error reading secrets from environment file %q", err, deployment.EnvFile)
+		return err
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...) // #nosec G204
+	cmd.Dir = input.appDir
+	cmd.Env = taskEnvironment(os.Environ(), m.Env, secrets, encodedInput)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = io.MultiWriter(os.Stdout, &stdout)
+	cmd.Stderr = os.Stderr
+
+	output.Notify("Running the task %q locally", "%s\n", input.taskName, strings.Join(command, " "))
+
+	exitCode := 0
+	if err := execute(cmd); err != nil {
+		exitErr := &exec.ExitError{}
+		if !errors.As(err, &exitErr) {
+			if errors.Is(err, exec.ErrNotFound) {
+				output.PrintErrorDetails(
+					"Could not find the %q command. Make sure the app requirements are installed in the python environment.",
+					err, command[0],
+				)
+			} else {
+				output.PrintErrorDetails("Error running the task", err)
+			}
+
+			return err
+		}
+		exitCode = exitErr.ExitCode()
+	}
+
+	printTaskResult(input.taskName, command, encodedInput, stdout.Bytes(), exitCode)
+
+	if exitCode != 0 {
+		return fmt.Errorf("%w: exit code %d", ErrTaskFailed, exitCode)
+	}
+
+	return nil
+}
+
+// taskCommand returns the command of the task defined in the manifest, or
+// runs the python file named after the task, if it exists in the app
+// directory.
+func taskCommand(appDir string, m *manifest.Manifest, taskName string) ([]string, error) {
+	if task, ok := m.Tasks[taskName]; ok && len(task.Command) > 0 {
+		return task.Command, nil
+	}
+
+	taskFile := strings.ReplaceAll(taskName, "-", "_") + ".py"
+	if _, err := os.Stat(filepath.Join(appDir, taskFile)); err == nil {
+		return []string{"python", taskFile}, nil
+	}
+
+	return nil, ErrTaskNotFound
+}
+
+func printTaskNotFound(m *manifest.Manifest, taskName string) {
+	taskFile := strings.ReplaceAll(taskName, "-", "_") + ".py"
+	body := fmt.Sprintf(
+		"Define the task command in a \"tasks.%s\" table in the app manifest, or add a %q file to the app directory.",
+		taskName, taskFile,
+	)
+
+	if len(m.Tasks) > 0 {
+		body += "\n\nTasks defined in the app manifest: " + strings.Join(slices.Sorted(maps.Keys(m.Tasks)), ", ")
+	}
+
+	output.PrintError("Task %q not found", body, taskName)
+}
+
+// Returns the environment of the task command, where manifest environment
+// variables override the process environment, secrets override the manifest
+// environment variables, and the task input overrides all.
+func taskEnvironment(environ []string, env map[string]string, secrets map[string]string, encodedInput *string) []string {
+	result := slices.Clone(environ)
+	for _, vars := range []map[string]string{env, secrets} {
+		for _, key := range slices.Sorted(maps.Keys(vars)) {
+			result = append(result, key+"="+vars[key])
+		}
+	}
+
+	if encodedInput != nil {
+		result = append(result, taskInputEnvVar+"="+*encodedInput)
+	}
+
+	return result
+}
+
+func printTaskResult(taskName string, command []string, encodedInput *string, stdout []byte, exitCode int) {
+	encodedOutput := base64.StdEncoding.EncodeToString(bytes.TrimRight(stdout, "\n"))
+
+	println()
+	println("Task:     " + taskName)
+	println("Command:  " + strings.Join(command, " "))
+	println("Input:    " + app.DecodeTaskDataForDisplay(encodedInput))
+	println("Output:   " + app.DecodeTaskDataForDisplay(&encodedOutput))
+	println(fmt.Sprintf("ExitCode: %d", exitCode))
+}
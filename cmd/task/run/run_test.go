@@ -0,0 +1,209 @@
+package run
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/manifest"
+	"numerous.com/cli/internal/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const manifestTOML = `name = "My App"
+port = 80
+
+[python]
+library = "streamlit"
+version = "3.11"
+app_file = "app.py"
+
+[env]
+LOG_LEVEL = "debug"
+API_KEY = "not-secret"
+
+[tasks.train]
+command = ["python", "train.py", "--epochs", "2"]
+
+[deploy.staging]
+env_file = ".env.staging"
+`
+
+func writeApp(t *testing.T) string {
+	t.Helper()
+
+	appDir := t.TempDir()
+	test.WriteFile(t, filepath.Join(appDir, manifest.ManifestFileName), []byte(manifestTOML))
+	test.WriteFile(t, filepath.Join(appDir, ".env"), []byte("API_KEY=secret-value\n"))
+	test.WriteFile(t, filepath.Join(appDir, ".env.staging"), []byte("STAGING_KEY=staging-secret\n"))
+	test.WriteFile(t, filepath.Join(appDir, "report_generator.py"), []byte("print('report')\n"))
+
+	return appDir
+}
+
+func TestRun(t *testing.T) {
+	t.Run("runs the task command of the manifest in the app directory", func(t *testing.T) {
+		appDir := writeApp(t)
+		var executed *exec.Cmd
+		execute := func(cmd *exec.Cmd) error {
+			executed = cmd
+			return nil
+		}
+
+		err := run(context.TODO(), execute, runInput{appDir: appDir, local: true, taskName: "train"})
+
+		require.NoError(t, err)
+		require.NotNil(t, executed)
+		assert.Equal(t, []string{"python", "train.py", "--epochs", "2"}, executed.Args)
+		assert.Equal(t, appDir, executed.Dir)
+		assert.Contains(t, executed.Env, "LOG_LEVEL=debug")
+		assert.Equal(t, "API_KEY=secret-value", lastEnv(executed.Env, "API_KEY"))
+	})
+
+	t.Run("runs the python file named after the task", func(t *testing.T) {
+		appDir := writeApp(t)
+		var executed *exec.Cmd
+		execute := func(cmd *exec.Cmd) error {
+			executed = cmd
+			return nil
+		}
+
+		err := run(context.TODO(), execute, runInput{appDir: appDir, local: true, taskName: "report-generator"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"python", "report_generator.py"}, executed.Args)
+	})
+
+	t.Run("passes the input base64 encoded", func(t *testing.T) {
+		appDir := writeApp(t)
+		inputFile := filepath.Join(t.TempDir(), "input.json")
+		test.WriteFile(t, inputFile, []byte(`{"file": "input"}`))
+
+		for _, input := range []runInput{
+			{appDir: appDir, local: true, taskName: "train", input: `{"file": "input"}`},
+			{appDir: appDir, local: true, taskName: "train", inputFile: inputFile},
+		} {
+			var executed *exec.Cmd
+			execute := func(cmd *exec.Cmd) error {
+				executed = cmd
+				return nil
+			}
+
+			err := run(context.TODO(), execute, input)
+
+			require.NoError(t, err)
+			expected := base64.StdEncoding.EncodeToString([]byte(`{"file": "input"}`))
+			assert.Equal(t, "TASK_DATA_INPUT="+expected, lastEnv(executed.Env, "TASK_DATA_INPUT"))
+		}
+	})
+
+	t.Run("loads the env file of the deploy environment", func(t *testing.T) {
+		appDir := writeApp(t)
+		var executed *exec.Cmd
+		execute := func(cmd *exec.Cmd) error {
+			executed = cmd
+			return nil
+		}
+
+		err := run(context.TODO(), execute, runInput{appDir: appDir, local: true, env: "staging", taskName: "train"})
+
+		require.NoError(t, err)
+		assert.Contains(t, executed.Env, "STAGING_KEY=staging-secret")
+		assert.Equal(t, "API_KEY=not-secret", lastEnv(executed.Env, "API_KEY"))
+	})
+
+	t.Run("captures the task output", func(t *testing.T) {
+		appDir := writeApp(t)
+		execute := func(cmd *exec.Cmd) error {
+			fmt.Fprintln(cmd.Stdout, "model trained")
+			return nil
+		}
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return run(context.TODO(), execute, runInput{appDir: appDir, local: true, taskName: "train"})
+		})
+
+		require.NoError(t, err)
+		out, _ := io.ReadAll(stdout)
+		assert.Contains(t, string(out), "model trained")
+	})
+
+	t.Run("returns error if the task exits with a non-zero exit code", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("requires a POSIX shell")
+		}
+
+		appDir := writeApp(t)
+		execute := func(cmd *exec.Cmd) error {
+			return exec.Command("sh", "-c", "exit 3").Run()
+		}
+
+		err := run(context.TODO(), execute, runInput{appDir: appDir, local: true, taskName: "train"})
+
+		assert.ErrorIs(t, err, ErrTaskFailed)
+		assert.ErrorContains(t, err, "exit code 3")
+	})
+
+	t.Run("returns error if the task is not found", func(t *testing.T) {
+		appDir := writeApp(t)
+		execute := func(cmd *exec.Cmd) error {
+			t.Fatal("no command should be executed")
+			return nil
+		}
+
+		stdout, err := test.RunEWithPatchedStdout(t, func() error {
+			return run(context.TODO(), execute, runInput{appDir: appDir, local: true, taskName: "missing"})
+		})
+
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+		out, _ := io.ReadAll(stdout)
+		assert.Contains(t, string(out), `Task "missing" not found`)
+		assert.Contains(t, string(out), "Tasks defined in the app manifest: train")
+	})
+
+	t.Run("returns error if the input is too large", func(t *testing.T) {
+		appDir := writeApp(t)
+		execute := func(cmd *exec.Cmd) error {
+			t.Fatal("no command should be executed")
+			return nil
+		}
+
+		err := run(context.TODO(), execute, runInput{appDir: appDir, local: true, taskName: "train", input: strings.Repeat("x", app.MaxTaskInputSize)})
+
+		assert.ErrorIs(t, err, app.ErrTaskInputTooLarge)
+	})
+
+	t.Run("returns error for conflicting input flags", func(t *testing.T) {
+		err := run(context.TODO(), nil, runInput{local: true, taskName: "train", input: "a", inputFile: "b"})
+
+		assert.ErrorIs(t, err, ErrConflictingInputFlags)
+	})
+
+	t.Run("returns error if not run locally", func(t *testing.T) {
+		err := run(context.TODO(), nil, runInput{appDir: writeApp(t), taskName: "train"})
+
+		assert.ErrorIs(t, err, ErrNotLocal)
+	})
+}
+
+// Returns the last definition of the key in the environment, which is the
+// one used by the executed command.
+func lastEnv(env []string, key string) string {
+	found := ""
+	for _, kv := range env {
+		if strings.HasPrefix(kv, key+"=") {
+			found = kv
+		}
+	}
+
+	return found
+}
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"time"
+
 	"numerous.com/cli/internal/appident"
 
 	"github.com/hasura/go-graphql-client"
@@ -12,40 +14,41 @@ type AppDeployLogsSubscription struct {
 }
 
 func (s *Service) AppDeployLogs(ai appident.AppIdentifier, tail *int, follow bool) (chan AppDeployLogEntry, error) {
-	ch := make(chan AppDeployLogEntry)
+	subscribe := func(sc SubscriptionClient, resubscribe bool, handle func(AppDeployLogEntry)) error {
+		handler := func(message []byte, err error) error {
+			if err != nil {
+				return err
+			}
 
-	handler := func(message []byte, err error) error {
-		if err != nil {
-			return err
-		}
+			var value AppDeployLogsSubscription
 
-		var value AppDeployLogsSubscription
+			err = jsonutil.UnmarshalGraphQL(message, &value)
+			if err != nil {
+				return err
+			}
 
-		err = jsonutil.UnmarshalGraphQL(message, &value)
-		if err != nil {
-			return err
-		}
+			handle(value.AppDeployLogs)
 
-		ch <- value.AppDeployLogs
+			return nil
+		}
 
-		return nil
-	}
+		vars := make(map[string]any)
+		vars["orgSlug"] = ai.OrganizationSlug
+		vars["appSlug"] = ai.AppSlug
+		vars["tail"] = tail
+		vars["follow"] = follow
+
+		// read the default range of recent logs when resubscribing, where
+		// entries seen before are skipped, and entries older than the range
+		// are missed
+		if resubscribe {
+			vars["tail"] = (*int)(nil)
+		}
 
-	vars := make(map[string]any)
-	vars["orgSlug"] = ai.OrganizationSlug
-	vars["appSlug"] = ai.AppSlug
-	vars["tail"] = tail
-	vars["follow"] = follow
+		_, err := sc.Subscribe(&AppDeployLogsSubscription{}, vars, handler, graphql.OperationName("CLIAppDeployLogs"))
 
-	_, err := s.subscription.Subscribe(&AppDeployLogsSubscription{}, vars, handler, graphql.OperationName("CLIAppDeployLogs"))
-	if err != nil {
-		return nil, err
+		return err
 	}
 
-	go func() {
-		defer close(ch)
-		s.subscription.Run() // nolint:errcheck
-	}()
-
-	return ch, nil
+	return subscribeLogs(s, follow, subscribe, func(e AppDeployLogEntry) (time.Time, string) { return e.Timestamp, e.Text })
 }
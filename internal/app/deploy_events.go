@@ -50,6 +50,10 @@ func (s *Service) DeployEvents(ctx context.Context, input DeployEventsInput) err
 	}
 	defer s.subscription.Close()
 
+	// closing the subscription stops it, without waiting for the next event
+	stop := context.AfterFunc(ctx, func() { s.subscription.Close() })
+	defer stop()
+
 	var handlerError error
 	variables := map[string]any{"deployVersionID": GraphQLID(input.DeploymentVersionID)}
 	handler := func(message []byte, err error) error {
@@ -0,0 +1,144 @@
+package app
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/hasura/go-graphql-client"
+	"numerous.com/cli/internal/logdedup"
+)
+
+var (
+	reconnectMaxAttempts  = 5
+	reconnectInitialDelay = time.Second
+	reconnectMaxDelay     = 30 * time.Second
+)
+
+// ReconnectOptions configures how log subscriptions, which are followed, are
+// resubscribed when the connection is lost.
+type ReconnectOptions struct {
+	// NewSubscription returns a new subscription client, which is used to
+	// resubscribe.
+	NewSubscription func() SubscriptionClient
+	// OnReconnect is called when the connection is established again.
+	OnReconnect func()
+	// OnGiveUp is called with the last error, if the connection could not be
+	// established again, before the log entry channel is closed.
+	OnGiveUp func(err error)
+}
+
+// WithReconnect enables resubscribing to followed logs when the connection is
+// lost.
+func (s *Service) WithReconnect(opts ReconnectOptions) *Service {
+	s.reconnect = &opts
+	return s
+}
+
+// connectionNotifier is implemented by subscription clients, which notify when
+// the connection is established, like the graphql subscription client.
+type connectionNotifier interface {
+	OnConnected(fn func()) *graphql.SubscriptionClient
+}
+
+// subscribeLogsFunc subscribes to logs with the subscription client, sending
+// entries to the handler. If resubscribe is true, logs are subscribed to
+// again after the connection was lost.
+type subscribeLogsFunc[E any] func(sc SubscriptionClient, resubscribe bool, handler func(E)) error
+
+// subscribeLogs subscribes to logs, and returns a channel of the log entries.
+// If following logs, and reconnection is enabled, it resubscribes when the
+// connection is lost. Entries seen before the connection was lost are
+// skipped, identified by their timestamp and text.
+//
+// The subscriptions cannot start from a given timestamp, so resubscribing
+// reads the default range of recent logs instead. If the connection was lost
+// for longer than that range covers, the entries logged in between are
+// missed, without any error.
+func subscribeLogs[E any](s *Service, follow bool, subscribe subscribeLogsFunc[E], key func(E) (time.Time, string)) (chan E, error) {
+	ch := make(chan E)
+
+	var dedup logdedup.Deduplicator
+	var reconnected atomic.Bool
+	handler := func(entry E) {
+		if dedup.Add(key(entry)) && reconnected.Load() {
+			return
+		}
+
+		ch <- entry
+	}
+
+	if err := subscribe(s.subscription, false, handler); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer close(ch)
+
+		if !follow || s.reconnect == nil {
+			s.subscription.Run() // nolint:errcheck
+			return
+		}
+
+		var connections atomic.Int32
+		onConnected := func() {
+			if connections.Add(1) > 1 {
+				reconnected.Store(true)
+				if s.reconnect.OnReconnect != nil {
+					s.reconnect.OnReconnect()
+				}
+			}
+		}
+
+		s.runReconnecting(onConnected, &connections, func(sc SubscriptionClient) error {
+			return subscribe(sc, true, handler)
+		})
+	}()
+
+	return ch, nil
+}
+
+// runReconnecting runs the subscription, and when it fails, resubscribes with
+// new subscription clients and an exponential backoff, until the maximum
+// number of attempts fail in a row.
+func (s *Service) runReconnecting(onConnected func(), connections *atomic.Int32, resubscribe func(SubscriptionClient) error) {
+	sc := s.subscription
+	notifyConnected(sc, onConnected)
+	err := sc.Run()
+
+	delay := reconnectInitialDelay
+	for attempt := 1; err != nil; attempt++ {
+		if attempt > reconnectMaxAttempts {
+			if s.reconnect.OnGiveUp != nil {
+				s.reconnect.OnGiveUp(err)
+			}
+
+			return
+		}
+
+		time.Sleep(delay)
+		delay = min(2*delay, reconnectMaxDelay) // nolint:mnd
+
+		sc = s.reconnect.NewSubscription()
+		notifyConnected(sc, onConnected)
+		if err = resubscribe(sc); err != nil {
+			continue
+		}
+
+		connectionsBefore := connections.Load()
+		err = sc.Run()
+		if connections.Load() > connectionsBefore {
+			// the connection was established, so later failures start over
+			attempt = 0
+			delay = reconnectInitialDelay
+		}
+	}
+}
+
+func notifyConnected(sc SubscriptionClient, onConnected func()) {
+	if notifier, ok := sc.(connectionNotifier); ok {
+		notifier.OnConnected(onConnected)
+	} else {
+		// assume that the client connects, when it does not notify about it
+		onConnected()
+	}
+}
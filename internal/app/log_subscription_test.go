@@ -0,0 +1,145 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"numerous.com/cli/internal/appident"
+
+	"github.com/hasura/go-graphql-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSubscriptionClient sends its messages to the subscription handler when
+// run, and then returns its error, e.g. to simulate a lost connection.
+type fakeSubscriptionClient struct {
+	messages  []string
+	err       error
+	variables map[string]any
+	handler   func(message []byte, err error) error
+}
+
+func (c *fakeSubscriptionClient) Subscribe(v any, variables map[string]any, handler func(message []byte, err error) error, options ...graphql.Option) (string, error) {
+	c.variables = variables
+	c.handler = handler
+
+	return "subID", nil
+}
+
+func (c *fakeSubscriptionClient) Run() error {
+	for _, msg := range c.messages {
+		c.handler([]byte(msg), nil) // nolint:errcheck
+	}
+
+	return c.err
+}
+
+func (c *fakeSubscriptionClient) Close() error { return nil }
+
+func patchReconnect(t *testing.T, maxAttempts int) {
+	t.Helper()
+
+	origMaxAttempts, origInitialDelay := reconnectMaxAttempts, reconnectInitialDelay
+	reconnectMaxAttempts, reconnectInitialDelay = maxAttempts, time.Millisecond
+	t.Cleanup(func() { reconnectMaxAttempts, reconnectInitialDelay = origMaxAttempts, origInitialDelay })
+}
+
+func logMessage(timestamp string, text string) string {
+	return `{"appDeployLogs": {"timestamp": "` + timestamp + `", "text": "` + text + `"}}`
+}
+
+func readAll[E any](t *testing.T, ch chan E) []E {
+	t.Helper()
+
+	var entries []E
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return entries
+			}
+			entries = append(entries, e)
+		case <-time.After(time.Second):
+			assert.Fail(t, "timed out waiting for the channel to close")
+			return entries
+		}
+	}
+}
+
+func TestAppDeployLogsReconnect(t *testing.T) {
+	ai := appident.AppIdentifier{OrganizationSlug: "organization-slug", AppSlug: "app-slug"}
+	errConnectionLost := errors.New("connection lost")
+	tail := 2
+
+	t.Run("resubscribes and skips entries seen before", func(t *testing.T) {
+		patchReconnect(t, 3)
+		first := &fakeSubscriptionClient{
+			messages: []string{logMessage("2024-11-11T11:11:11Z", "message 1"), logMessage("2024-11-11T11:11:22Z", "message 2")},
+			err:      errConnectionLost,
+		}
+		second := &fakeSubscriptionClient{
+			messages: []string{
+				logMessage("2024-11-11T11:11:11Z", "message 1"),
+				logMessage("2024-11-11T11:11:22Z", "message 2"),
+				logMessage("2024-11-11T11:11:22Z", "message 3"),
+				logMessage("2024-11-11T11:11:33Z", "message 4"),
+			},
+		}
+		reconnects := 0
+		s := New(nil, first, nil).WithReconnect(ReconnectOptions{
+			NewSubscription: func() SubscriptionClient { return second },
+			OnReconnect:     func() { reconnects++ },
+			OnGiveUp:        func(err error) { assert.Fail(t, "unexpected give up", err) },
+		})
+
+		ch, err := s.AppDeployLogs(ai, &tail, true)
+		require.NoError(t, err)
+		actual := readAll(t, ch)
+
+		assert.Equal(t, []AppDeployLogEntry{
+			{Timestamp: time.Date(2024, time.November, 11, 11, 11, 11, 0, time.UTC), Text: "message 1"},
+			{Timestamp: time.Date(2024, time.November, 11, 11, 11, 22, 0, time.UTC), Text: "message 2"},
+			{Timestamp: time.Date(2024, time.November, 11, 11, 11, 22, 0, time.UTC), Text: "message 3"},
+			{Timestamp: time.Date(2024, time.November, 11, 11, 11, 33, 0, time.UTC), Text: "message 4"},
+		}, actual)
+		assert.Equal(t, 1, reconnects)
+		assert.Equal(t, &tail, first.variables["tail"])
+		assert.Nil(t, second.variables["tail"])
+	})
+
+	t.Run("gives up after the maximum number of attempts", func(t *testing.T) {
+		patchReconnect(t, 2)
+		subscriptions := 0
+		var gaveUp error
+		s := New(nil, &fakeSubscriptionClient{err: errConnectionLost}, nil).WithReconnect(ReconnectOptions{
+			NewSubscription: func() SubscriptionClient {
+				subscriptions++
+				return &fakeSubscriptionClient{err: errConnectionLost}
+			},
+			OnGiveUp: func(err error) { gaveUp = err },
+		})
+
+		ch, err := s.AppDeployLogs(ai, nil, true)
+		require.NoError(t, err)
+		readAll(t, ch)
+
+		assert.ErrorIs(t, gaveUp, errConnectionLost)
+		assert.Equal(t, 2, subscriptions)
+	})
+
+	t.Run("does not resubscribe without follow", func(t *testing.T) {
+		s := New(nil, &fakeSubscriptionClient{err: errConnectionLost}, nil).WithReconnect(ReconnectOptions{
+			NewSubscription: func() SubscriptionClient {
+				assert.Fail(t, "unexpected resubscription")
+				return nil
+			},
+		})
+
+		ch, err := s.AppDeployLogs(ai, nil, false)
+		require.NoError(t, err)
+
+		assert.Empty(t, readAll(t, ch))
+	})
+}
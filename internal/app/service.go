@@ -32,6 +32,7 @@ type Service struct {
 	subscription SubscriptionClient
 	uploadDoer   UploadDoer
 	clock        Clock
+	reconnect    *ReconnectOptions
 }
 
 func New(client *graphql.Client, subscription SubscriptionClient, uploadDoer UploadDoer) *Service {
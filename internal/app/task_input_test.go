@@ -47,7 +47,7 @@ func TestEncodeTaskInput(t *testing.T) {
 	maxRawDataSize := 3 * int(math.Ceil(float64(MaxTaskInputSize/4)))
 	t.Run("encodes simple string", func(t *testing.T) {
 		input := "test input"
-		encoded, err := encodeTaskInput(input)
+		encoded, err := EncodeTaskInput(input)
 		assert.NoError(t, err)
 
 		decoded, err := base64.StdEncoding.DecodeString(encoded)
@@ -57,7 +57,7 @@ func TestEncodeTaskInput(t *testing.T) {
 
 	t.Run("encodes JSON string", func(t *testing.T) {
 		input := `{"user_id": 123}`
-		encoded, err := encodeTaskInput(input)
+		encoded, err := EncodeTaskInput(input)
 		assert.NoError(t, err)
 
 		decoded, err := base64.StdEncoding.DecodeString(encoded)
@@ -67,28 +67,28 @@ func TestEncodeTaskInput(t *testing.T) {
 
 	t.Run("returns error when input exceeds max size", func(t *testing.T) {
 		input := strings.Repeat("a", maxRawDataSize+1)
-		_, err := encodeTaskInput(input)
+		_, err := EncodeTaskInput(input)
 		assert.Error(t, err)
 		assert.Equal(t, ErrTaskInputTooLarge, err)
 	})
 
 	t.Run("returns expected encoded string when input is exactly max size", func(t *testing.T) {
 		input := strings.Repeat("a", maxRawDataSize)
-		encoded, err := encodeTaskInput(input)
+		encoded, err := EncodeTaskInput(input)
 		assert.NoError(t, err)
 		assert.LessOrEqual(t, len(encoded), MaxTaskInputSize)
 	})
 
 	t.Run("handles empty string", func(t *testing.T) {
 		input := ""
-		encoded, err := encodeTaskInput(input)
+		encoded, err := EncodeTaskInput(input)
 		assert.NoError(t, err)
 		assert.Equal(t, input, encoded)
 	})
 
 	t.Run("handles unicode characters", func(t *testing.T) {
 		input := "Hello, " + "\u2713"
-		encoded, err := encodeTaskInput(input)
+		encoded, err := EncodeTaskInput(input)
 		assert.NoError(t, err)
 
 		decoded, err := base64.StdEncoding.DecodeString(encoded)
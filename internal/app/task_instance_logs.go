@@ -23,39 +23,40 @@ type TaskInstanceLogsSubscription struct {
 }
 
 func (s *Service) TaskInstanceLogs(input TaskInstanceLogsInput) (chan WorkloadLogEntry, error) {
-	ch := make(chan WorkloadLogEntry)
+	subscribe := func(sc SubscriptionClient, resubscribe bool, handle func(WorkloadLogEntry)) error {
+		handler := func(message []byte, err error) error {
+			if err != nil {
+				return err
+			}
 
-	handler := func(message []byte, err error) error {
-		if err != nil {
-			return err
-		}
+			var value TaskInstanceLogsSubscription
+
+			err = jsonutil.UnmarshalGraphQL(message, &value)
+			if err != nil {
+				return err
+			}
 
-		var value TaskInstanceLogsSubscription
+			handle(value.TaskInstanceLogs)
 
-		err = jsonutil.UnmarshalGraphQL(message, &value)
-		if err != nil {
-			return err
+			return nil
 		}
 
-		ch <- value.TaskInstanceLogs
+		vars := make(map[string]any)
+		vars["taskInstanceID"] = graphql.ID(input.InstanceID)
+		vars["tail"] = input.Tail
+		vars["follow"] = input.Follow
 
-		return nil
-	}
+		// read the default range of recent logs when resubscribing, where
+		// entries seen before are skipped, and entries older than the range
+		// are missed
+		if resubscribe {
+			vars["tail"] = (*int)(nil)
+		}
 
-	vars := make(map[string]any)
-	vars["taskInstanceID"] = graphql.ID(input.InstanceID)
-	vars["tail"] = input.Tail
-	vars["follow"] = input.Follow
+		_, err := sc.Subscribe(&TaskInstanceLogsSubscription{}, vars, handler, graphql.OperationName("CLITaskInstanceLogs"))
 
-	_, err := s.subscription.Subscribe(&TaskInstanceLogsSubscription{}, vars, handler, graphql.OperationName("CLITaskInstanceLogs"))
-	if err != nil {
-		return nil, err
+		return err
 	}
 
-	go func() {
-		defer close(ch)
-		s.subscription.Run() // nolint:errcheck
-	}()
-
-	return ch, nil
+	return subscribeLogs(s, input.Follow, subscribe, func(e WorkloadLogEntry) (time.Time, string) { return e.Timestamp, e.Text })
 }
@@ -61,7 +61,7 @@ func (s *Service) StartTask(ctx context.Context, input StartTaskInput) (*TaskSta
 	}
 
 	if input.Input != nil {
-		encodedInput, err := encodeTaskInput(*input.Input)
+		encodedInput, err := EncodeTaskInput(*input.Input)
 		if err != nil {
 			return nil, err
 		}
@@ -82,7 +82,9 @@ func (s *Service) StartTask(ctx context.Context, input StartTaskInput) (*TaskSta
 	return &result, nil
 }
 
-func encodeTaskInput(rawInput string) (string, error) {
+// EncodeTaskInput returns the base64 encoding of the task input, which is
+// how the input is passed to tasks, or an error if it is too large.
+func EncodeTaskInput(rawInput string) (string, error) {
 	encoded := base64.StdEncoding.EncodeToString([]byte(rawInput))
 	if len(encoded) > MaxTaskInputSize {
 		return "", ErrTaskInputTooLarge
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -22,8 +23,8 @@ type UploadArchive struct {
 	Size   int64
 }
 
-func (s *Service) UploadAppSource(uploadURL string, archive UploadArchive) error {
-	req, err := http.NewRequest(http.MethodPut, uploadURL, archive.Reader)
+func (s *Service) UploadAppSource(ctx context.Context, uploadURL string, archive UploadArchive) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, archive.Reader)
 	if err != nil {
 		return err
 	}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"net/http"
@@ -28,7 +29,7 @@ func TestUploadAppSource(t *testing.T) {
 		doer.On("Do", mock.Anything).Return(nilResp, testError)
 		s := Service{uploadDoer: &doer}
 
-		err := s.UploadAppSource("http://some-upload-url", UploadArchive{Reader: dummyReader(), Size: int64(len(dummyData))})
+		err := s.UploadAppSource(context.TODO(), "http://some-upload-url", UploadArchive{Reader: dummyReader(), Size: int64(len(dummyData))})
 
 		assert.ErrorIs(t, err, testError)
 	})
@@ -40,7 +41,7 @@ func TestUploadAppSource(t *testing.T) {
 		doer.On("Do", mock.Anything).Return(&resp, nil)
 		s := Service{uploadDoer: &doer}
 
-		err := s.UploadAppSource("http://some-upload-url", UploadArchive{Reader: dummyReader(), Size: int64(len(dummyData))})
+		err := s.UploadAppSource(context.TODO(), "http://some-upload-url", UploadArchive{Reader: dummyReader(), Size: int64(len(dummyData))})
 
 		expected := AppSourceUploadError{
 			HTTPStatusCode: http.StatusBadRequest,
@@ -57,7 +58,7 @@ func TestUploadAppSource(t *testing.T) {
 	t.Run("given invalid upload URL then it returns error", func(t *testing.T) {
 		s := Service{uploadDoer: &test.MockDoer{}}
 
-		err := s.UploadAppSource("://invalid-url", UploadArchive{Reader: dummyReader(), Size: int64(len(dummyData))})
+		err := s.UploadAppSource(context.TODO(), "://invalid-url", UploadArchive{Reader: dummyReader(), Size: int64(len(dummyData))})
 
 		assert.Error(t, err)
 	})
@@ -68,7 +69,7 @@ func TestUploadAppSource(t *testing.T) {
 		doer.On("Do", mock.Anything).Return(&resp, nil)
 		s := Service{uploadDoer: &doer}
 
-		err := s.UploadAppSource("http://some-upload-url", UploadArchive{Reader: bytes.NewReader([]byte("")), Size: 0})
+		err := s.UploadAppSource(context.TODO(), "http://some-upload-url", UploadArchive{Reader: bytes.NewReader([]byte("")), Size: 0})
 
 		assert.NoError(t, err)
 	})
@@ -79,11 +80,27 @@ func TestUploadAppSource(t *testing.T) {
 		doer.On("Do", mock.Anything).Return(&resp, nil)
 		s := Service{uploadDoer: &doer}
 		data := []byte("some data")
-		err := s.UploadAppSource("http://some-upload-url", UploadArchive{Reader: bytes.NewReader(data), Size: int64(len(data))})
+		err := s.UploadAppSource(context.TODO(), "http://some-upload-url", UploadArchive{Reader: bytes.NewReader(data), Size: int64(len(data))})
 
 		assert.NoError(t, err)
 		doer.AssertCalled(t, "Do", mock.MatchedBy(func(r *http.Request) bool {
 			return r.ContentLength == 9
 		}))
 	})
+
+	t.Run("it sends the request with the given context", func(t *testing.T) {
+		doer := test.MockDoer{}
+		resp := http.Response{Status: "OK", StatusCode: http.StatusOK}
+		doer.On("Do", mock.Anything).Return(&resp, nil)
+		s := Service{uploadDoer: &doer}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		err := s.UploadAppSource(ctx, "http://some-upload-url", UploadArchive{Reader: dummyReader(), Size: int64(len(dummyData))})
+
+		assert.NoError(t, err)
+		doer.AssertCalled(t, "Do", mock.MatchedBy(func(r *http.Request) bool {
+			return r.Context() == ctx
+		}))
+	})
 }
@@ -39,10 +39,12 @@ func (ai AppIdentifier) validate() error {
 	return nil
 }
 
-// Uses the given slug and appName, or loads from manifest, and validates.
-func GetAppIdentifier(appDir string, m *manifest.Manifest, orgSlug string, appSlug string) (AppIdentifier, error) {
+// Uses the given slug and appName, or loads from manifest, and validates. If
+// env is not empty, the deploy environment of that name in the manifest is
+// used instead of the default deployment, but arguments still take precedence.
+func GetAppIdentifier(appDir string, m *manifest.Manifest, env string, orgSlug string, appSlug string) (AppIdentifier, error) {
 	// if a full identifier is provided, just return it
-	if orgSlug != "" && appSlug != "" {
+	if orgSlug != "" && appSlug != "" && env == "" {
 		ai := AppIdentifier{OrganizationSlug: orgSlug, AppSlug: appSlug}
 		return ai, ai.validate()
 	}
@@ -50,14 +52,21 @@ func GetAppIdentifier(appDir string, m *manifest.Manifest, orgSlug string, appSl
 	// try to load the manifest, if none was given
 	var manifestLoadErr error
 	if m == nil {
-		m, manifestLoadErr = manifest.Load(filepath.Join(appDir, manifest.ManifestFileName))
+		manifestPath := filepath.Join(appDir, manifest.ManifestFileName)
+		var format manifest.Format
+		m, format, manifestLoadErr = manifest.LoadWithFormat(manifestPath)
+		manifest.WarnIfDeprecated(manifestPath, format)
 	}
 
 	// if loading manifest succeeded, get values if arguments are not given
 	if manifestLoadErr == nil {
-		orgSlug = GetOrgSlug(m, orgSlug)
-		appSlug = GetAppSlug(m, appSlug)
-	} else if appSlug == "" {
+		deployment, err := m.ResolveDeployEnvironment(env)
+		if err != nil {
+			return AppIdentifier{}, err
+		}
+		orgSlug = firstNonEmpty(orgSlug, deployment.OrganizationSlug)
+		appSlug = firstNonEmpty(appSlug, deployment.AppSlug, manifestAppNameToAppSlug(m.Name))
+	} else if appSlug == "" || env != "" {
 		return AppIdentifier{}, ErrAppNotInitialized
 	}
 
@@ -72,6 +81,16 @@ func GetAppIdentifier(appDir string, m *manifest.Manifest, orgSlug string, appSl
 	return ai, ai.validate()
 }
 
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
 var (
 	appNameWhitespaceRegexp *regexp.Regexp = regexp.MustCompile(`\s+`)
 	appNameSanitizeRegexp   *regexp.Regexp = regexp.MustCompile(`[^0-9a-z-\s]`)
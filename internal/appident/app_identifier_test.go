@@ -14,6 +14,17 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var manifestWithEnvironments = &manifest.Manifest{
+	Deployment: &manifest.Deployment{
+		OrganizationSlug: "manifest-org-slug",
+		AppSlug:          "manifest-app-slug",
+		Environments: map[string]manifest.DeployEnvironment{
+			"staging":    {OrganizationSlug: "staging-org-slug", AppSlug: "staging-app-slug"},
+			"production": {OrganizationSlug: "production-org-slug"},
+		},
+	},
+}
+
 func TestGetAppIdentifier(t *testing.T) {
 	const orgSlug = "organization-slug"
 	const appSlug = "app-slug"
@@ -21,7 +32,7 @@ func TestGetAppIdentifier(t *testing.T) {
 	t.Run("given invalid organization slug then invalid organization slug error is returned", func(t *testing.T) {
 		config.OverrideConfigBaseDir(t.TempDir())
 
-		actual, err := GetAppIdentifier("", nil, "Some Invalid Organization Slug", appSlug)
+		actual, err := GetAppIdentifier("", nil, "", "Some Invalid Organization Slug", appSlug)
 
 		assert.Equal(t, AppIdentifier{OrganizationSlug: "Some Invalid Organization Slug", AppSlug: appSlug}, actual)
 		assert.ErrorIs(t, err, ErrInvalidOrganizationSlug)
@@ -30,7 +41,7 @@ func TestGetAppIdentifier(t *testing.T) {
 	t.Run("given invalid app slug then invalid app slug error is returned", func(t *testing.T) {
 		config.OverrideConfigBaseDir(t.TempDir())
 
-		actual, err := GetAppIdentifier("", nil, orgSlug, "Some Invalid App Slug")
+		actual, err := GetAppIdentifier("", nil, "", orgSlug, "Some Invalid App Slug")
 
 		assert.Equal(t, AppIdentifier{OrganizationSlug: orgSlug, AppSlug: "Some Invalid App Slug"}, actual)
 		assert.ErrorIs(t, err, ErrInvalidAppSlug)
@@ -43,7 +54,25 @@ func TestGetAppIdentifier(t *testing.T) {
 		// location
 		require.NoError(t, os.MkdirAll(appDir, fs.ModeDir))
 
-		actual, err := GetAppIdentifier(appDir, nil, "", "")
+		actual, err := GetAppIdentifier(appDir, nil, "", "", "")
+
+		assert.Empty(t, actual)
+		assert.ErrorIs(t, err, ErrAppNotInitialized)
+	})
+
+	t.Run("given unknown environment then environment not found error is returned", func(t *testing.T) {
+		config.OverrideConfigBaseDir(t.TempDir())
+
+		actual, err := GetAppIdentifier("", manifestWithEnvironments, "unknown", "", "")
+
+		assert.Empty(t, actual)
+		assert.ErrorIs(t, err, manifest.ErrDeployEnvironmentNotFound)
+	})
+
+	t.Run("given environment and error loading manifest then error is returned", func(t *testing.T) {
+		config.OverrideConfigBaseDir(t.TempDir())
+
+		actual, err := GetAppIdentifier(t.TempDir(), nil, "staging", orgSlug, appSlug)
 
 		assert.Empty(t, actual)
 		assert.ErrorIs(t, err, ErrAppNotInitialized)
@@ -53,6 +82,7 @@ func TestGetAppIdentifier(t *testing.T) {
 		name   string
 		argOrg string
 		argApp string
+		env    string
 		// manifest that is passed directly to the function
 		manifestPreloaded *manifest.Manifest
 		// manifest that is stored in the app folder
@@ -136,6 +166,44 @@ func TestGetAppIdentifier(t *testing.T) {
 				AppSlug:          "app-name",
 			},
 		},
+		{
+			name:          "given environment it uses organization and app from the loaded manifest environment",
+			env:           "staging",
+			manifestSaved: manifestWithEnvironments,
+			expected: AppIdentifier{
+				OrganizationSlug: "staging-org-slug",
+				AppSlug:          "staging-app-slug",
+			},
+		},
+		{
+			name:              "given environment it uses organization and app from the preloaded manifest environment",
+			env:               "staging",
+			manifestPreloaded: manifestWithEnvironments,
+			expected: AppIdentifier{
+				OrganizationSlug: "staging-org-slug",
+				AppSlug:          "staging-app-slug",
+			},
+		},
+		{
+			name:          "given environment without app it falls back to default deployment app",
+			env:           "production",
+			manifestSaved: manifestWithEnvironments,
+			expected: AppIdentifier{
+				OrganizationSlug: "production-org-slug",
+				AppSlug:          "manifest-app-slug",
+			},
+		},
+		{
+			name:          "given environment and arguments the arguments override the environment",
+			env:           "staging",
+			argOrg:        "arg-org-slug",
+			argApp:        "arg-app-slug",
+			manifestSaved: manifestWithEnvironments,
+			expected: AppIdentifier{
+				OrganizationSlug: "arg-org-slug",
+				AppSlug:          "arg-app-slug",
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			appDir := t.TempDir()
@@ -155,7 +223,7 @@ func TestGetAppIdentifier(t *testing.T) {
 				test.WriteFile(t, filepath.Join(appDir, manifest.ManifestFileName), []byte(mToml))
 			}
 
-			actual, err := GetAppIdentifier(appDir, tc.manifestPreloaded, tc.argOrg, tc.argApp)
+			actual, err := GetAppIdentifier(appDir, tc.manifestPreloaded, tc.env, tc.argOrg, tc.argApp)
 
 			assert.NoError(t, err)
 			assert.Equal(t, tc.expected, actual)
@@ -3,6 +3,7 @@ package appident
 import (
 	"errors"
 
+	"numerous.com/cli/internal/manifest"
 	"numerous.com/cli/internal/output"
 )
 
@@ -26,5 +27,11 @@ func PrintGetAppIdentifierError(err error, appDir string, ai AppIdentifier) {
 		output.PrintErrorMissingAppSlug()
 	case errors.Is(err, ErrMissingOrganizationSlug):
 		output.PrintErrorMissingOrganizationSlug()
+	case errors.Is(err, manifest.ErrDeployEnvironmentNotFound):
+		output.PrintError(
+			"Deploy environment not found",
+			"Details: %s\nDeploy environments are defined in \"deploy.<environment>\" tables in the app manifest.",
+			err.Error(),
+		)
 	}
 }
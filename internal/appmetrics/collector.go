@@ -0,0 +1,310 @@
+// Package appmetrics exposes metrics of app workloads in the Prometheus text
+// exposition format.
+package appmetrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"numerous.com/cli/internal/app"
+)
+
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Labels identifies a workload of an app. Subscription is empty for the
+// workload of the organization owning the app.
+type Labels struct {
+	Organization string
+	App          string
+	Subscription string
+}
+
+type appKey struct {
+	organization string
+	app          string
+}
+
+type workloadState struct {
+	workload app.AppWorkload
+	restarts int
+	statuses []string
+}
+
+type appState struct {
+	refreshedAt   time.Time
+	refreshErrors int
+}
+
+// Collector holds the latest workloads of apps, and the counters derived from
+// the changes between refreshes.
+type Collector struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	workloads map[Labels]*workloadState
+	apps      map[appKey]*appState
+}
+
+func New() *Collector {
+	return &Collector{
+		now:       time.Now,
+		workloads: make(map[Labels]*workloadState),
+		apps:      make(map[appKey]*appState),
+	}
+}
+
+// Update sets the workloads of the app. A restart is counted for a workload,
+// when it has started later than at the previous update, and workloads which
+// are no longer returned are removed.
+func (c *Collector) Update(orgSlug, appSlug string, workloads []app.AppWorkload, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	seen := make(map[Labels]bool)
+	for _, w := range workloads {
+		labels := Labels{Organization: orgSlug, App: appSlug}
+		if w.Subscription != nil {
+			labels.Subscription = w.Subscription.SubscriptionUUID
+		}
+		seen[labels] = true
+
+		state, ok := c.workloads[labels]
+		if !ok {
+			state = &workloadState{}
+			c.workloads[labels] = state
+		} else if w.StartedAt.After(state.workload.StartedAt) {
+			state.restarts++
+		}
+
+		state.workload = w
+		if !slices.Contains(state.statuses, w.Status) {
+			state.statuses = append(state.statuses, w.Status)
+		}
+	}
+
+	for labels := range c.workloads {
+		if labels.Organization == orgSlug && labels.App == appSlug && !seen[labels] {
+			delete(c.workloads, labels)
+		}
+	}
+
+	c.app(orgSlug, appSlug).refreshedAt = now
+}
+
+// RecordError counts a failed refresh of the workloads of the app.
+func (c *Collector) RecordError(orgSlug, appSlug string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.app(orgSlug, appSlug).refreshErrors++
+}
+
+func (c *Collector) app(orgSlug, appSlug string) *appState {
+	key := appKey{organization: orgSlug, app: appSlug}
+	state, ok := c.apps[key]
+	if !ok {
+		state = &appState{}
+		c.apps[key] = state
+	}
+
+	return state
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentType)
+	c.Write(w) // nolint:errcheck
+}
+
+// Write writes the metrics in the Prometheus text exposition format.
+func (c *Collector) Write(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	workloads := c.sortedWorkloads()
+	apps := c.sortedApps()
+
+	var b strings.Builder
+	writeWorkloadMetric(&b, "numerous_app_workload_cpu_usage", "gauge", "Current CPU usage of the workload, in 1024ths of a vCPU.", workloads, func(s *workloadState) []sample {
+		return []sample{{value: s.workload.CPUUsage.Current}}
+	})
+	writeWorkloadMetric(&b, "numerous_app_workload_cpu_limit", "gauge", "CPU limit of the workload, in 1024ths of a vCPU.", workloads, func(s *workloadState) []sample {
+		return limitSamples(s.workload.CPUUsage)
+	})
+	writeWorkloadMetric(&b, "numerous_app_workload_memory_usage_megabytes", "gauge", "Current memory usage of the workload, in megabytes.", workloads, func(s *workloadState) []sample {
+		return []sample{{value: s.workload.MemoryUsageMB.Current}}
+	})
+	writeWorkloadMetric(&b, "numerous_app_workload_memory_limit_megabytes", "gauge", "Memory limit of the workload, in megabytes.", workloads, func(s *workloadState) []sample {
+		return limitSamples(s.workload.MemoryUsageMB)
+	})
+	writeWorkloadMetric(&b, "numerous_app_workload_status", "gauge", "Status of the workload, 1 for the current status, and 0 for other statuses seen before.", workloads, func(s *workloadState) []sample {
+		samples := make([]sample, 0, len(s.statuses))
+		for _, status := range s.statuses {
+			value := 0.0
+			if status == s.workload.Status {
+				value = 1
+			}
+			samples = append(samples, sample{labels: []label{{"status", status}}, value: value})
+		}
+
+		return samples
+	})
+	writeWorkloadMetric(&b, "numerous_app_workload_start_time_seconds", "gauge", "Start time of the workload, in seconds since the Unix epoch.", workloads, func(s *workloadState) []sample {
+		return []sample{{value: unixSeconds(s.workload.StartedAt)}}
+	})
+	writeWorkloadMetric(&b, "numerous_app_workload_uptime_seconds", "gauge", "Time since the workload started, in seconds.", workloads, func(s *workloadState) []sample {
+		return []sample{{value: now.Sub(s.workload.StartedAt).Seconds()}}
+	})
+	writeWorkloadMetric(&b, "numerous_app_workload_restarts_total", "counter", "Number of times the workload was seen starting again, since the exporter started.", workloads, func(s *workloadState) []sample {
+		return []sample{{value: float64(s.restarts)}}
+	})
+	writeAppMetric(&b, "numerous_app_workloads_last_refresh_timestamp_seconds", "gauge", "Time of the last successful refresh of the workloads of the app, in seconds since the Unix epoch.", apps, func(s *appState) []sample {
+		if s.refreshedAt.IsZero() {
+			return nil
+		}
+
+		return []sample{{value: unixSeconds(s.refreshedAt)}}
+	})
+	writeAppMetric(&b, "numerous_app_workloads_refresh_errors_total", "counter", "Number of failed refreshes of the workloads of the app.", apps, func(s *appState) []sample {
+		return []sample{{value: float64(s.refreshErrors)}}
+	})
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+type label struct {
+	name  string
+	value string
+}
+
+type sample struct {
+	labels []label
+	value  float64
+}
+
+type labeledWorkload struct {
+	labels Labels
+	state  *workloadState
+}
+
+type labeledApp struct {
+	key   appKey
+	state *appState
+}
+
+func (c *Collector) sortedWorkloads() []labeledWorkload {
+	workloads := make([]labeledWorkload, 0, len(c.workloads))
+	for labels, state := range c.workloads {
+		workloads = append(workloads, labeledWorkload{labels: labels, state: state})
+	}
+
+	slices.SortFunc(workloads, func(a, b labeledWorkload) int {
+		return strings.Compare(
+			a.labels.Organization+"\x00"+a.labels.App+"\x00"+a.labels.Subscription,
+			b.labels.Organization+"\x00"+b.labels.App+"\x00"+b.labels.Subscription,
+		)
+	})
+
+	return workloads
+}
+
+func (c *Collector) sortedApps() []labeledApp {
+	apps := make([]labeledApp, 0, len(c.apps))
+	for key, state := range c.apps {
+		apps = append(apps, labeledApp{key: key, state: state})
+	}
+
+	slices.SortFunc(apps, func(a, b labeledApp) int {
+		return strings.Compare(a.key.organization+"\x00"+a.key.app, b.key.organization+"\x00"+b.key.app)
+	})
+
+	return apps
+}
+
+func writeWorkloadMetric(b *strings.Builder, name, typ, help string, workloads []labeledWorkload, samples func(*workloadState) []sample) {
+	writeHeader(b, name, typ, help)
+	for _, w := range workloads {
+		base := []label{
+			{"organization", w.labels.Organization},
+			{"app", w.labels.App},
+			{"subscription", w.labels.Subscription},
+		}
+		for _, s := range samples(w.state) {
+			writeSample(b, name, append(slices.Clone(base), s.labels...), s.value)
+		}
+	}
+}
+
+func writeAppMetric(b *strings.Builder, name, typ, help string, apps []labeledApp, samples func(*appState) []sample) {
+	writeHeader(b, name, typ, help)
+	for _, a := range apps {
+		base := []label{{"organization", a.key.organization}, {"app", a.key.app}}
+		for _, s := range samples(a.state) {
+			writeSample(b, name, append(slices.Clone(base), s.labels...), s.value)
+		}
+	}
+}
+
+func writeHeader(b *strings.Builder, name, typ, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s %s\n", name, typ)
+}
+
+func writeSample(b *strings.Builder, name string, labels []label, value float64) {
+	b.WriteString(name)
+	b.WriteByte('{')
+	for i, l := range labels {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(l.name + `="` + escapeLabelValue(l.value) + `"`)
+	}
+	b.WriteString("} ")
+	b.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	b.WriteByte('\n')
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(v string) string {
+	return labelValueReplacer.Replace(v)
+}
+
+func limitSamples(usage app.AppWorkloadResourceUsage) []sample {
+	if usage.Limit == nil {
+		return nil
+	}
+
+	return []sample{{value: *usage.Limit}}
+}
+
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+// Retain removes the workloads and counters of the apps in the organization,
+// which are not in the given app slugs.
+func (c *Collector) Retain(orgSlug string, appSlugs []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for labels := range c.workloads {
+		if labels.Organization == orgSlug && !slices.Contains(appSlugs, labels.App) {
+			delete(c.workloads, labels)
+		}
+	}
+
+	for key := range c.apps {
+		if key.organization == orgSlug && !slices.Contains(appSlugs, key.app) {
+			delete(c.apps, key)
+		}
+	}
+}
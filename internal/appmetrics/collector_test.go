@@ -0,0 +1,132 @@
+package appmetrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"numerous.com/cli/internal/app"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ref[T any](v T) *T { return &v }
+
+func TestCollector(t *testing.T) {
+	startedAt := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	now := startedAt.Add(time.Hour)
+	workload := app.AppWorkload{
+		OrganizationSlug: "acme",
+		StartedAt:        startedAt,
+		Status:           "RUNNING",
+		CPUUsage:         app.AppWorkloadResourceUsage{Current: 100, Limit: ref(1024.0)},
+		MemoryUsageMB:    app.AppWorkloadResourceUsage{Current: 256.5},
+	}
+	subscriptionWorkload := app.AppWorkload{
+		Subscription: &app.AppWorkloadSubscription{OrganizationSlug: "customer", SubscriptionUUID: "sub-id"},
+		StartedAt:    startedAt,
+		Status:       "STARTING",
+	}
+
+	write := func(t *testing.T, c *Collector) string {
+		t.Helper()
+
+		var b strings.Builder
+		require.NoError(t, c.Write(&b))
+
+		return b.String()
+	}
+
+	t.Run("writes workload metrics with labels", func(t *testing.T) {
+		c := New()
+		c.now = func() time.Time { return now }
+
+		c.Update("acme", "app", []app.AppWorkload{workload, subscriptionWorkload}, now)
+
+		metrics := write(t, c)
+		assert.Contains(t, metrics, "# TYPE numerous_app_workload_cpu_usage gauge\n")
+		assert.Contains(t, metrics, `numerous_app_workload_cpu_usage{organization="acme",app="app",subscription=""} 100`+"\n")
+		assert.Contains(t, metrics, `numerous_app_workload_cpu_limit{organization="acme",app="app",subscription=""} 1024`+"\n")
+		assert.NotContains(t, metrics, `numerous_app_workload_cpu_limit{organization="acme",app="app",subscription="sub-id"}`)
+		assert.Contains(t, metrics, `numerous_app_workload_memory_usage_megabytes{organization="acme",app="app",subscription=""} 256.5`+"\n")
+		assert.Contains(t, metrics, `numerous_app_workload_status{organization="acme",app="app",subscription="sub-id",status="STARTING"} 1`+"\n")
+		assert.Contains(t, metrics, `numerous_app_workload_uptime_seconds{organization="acme",app="app",subscription=""} 3600`+"\n")
+		assert.Contains(t, metrics, `numerous_app_workload_start_time_seconds{organization="acme",app="app",subscription=""} 1.7041104e+09`+"\n")
+		assert.Contains(t, metrics, `numerous_app_workloads_last_refresh_timestamp_seconds{organization="acme",app="app"} 1.704114e+09`+"\n")
+	})
+
+	t.Run("counts restarts and keeps previous statuses", func(t *testing.T) {
+		c := New()
+		c.now = func() time.Time { return now }
+		c.Update("acme", "app", []app.AppWorkload{workload}, now)
+
+		restarted := workload
+		restarted.StartedAt = now
+		restarted.Status = "STARTING"
+		c.Update("acme", "app", []app.AppWorkload{restarted}, now)
+
+		metrics := write(t, c)
+		assert.Contains(t, metrics, `numerous_app_workload_restarts_total{organization="acme",app="app",subscription=""} 1`+"\n")
+		assert.Contains(t, metrics, `numerous_app_workload_status{organization="acme",app="app",subscription="",status="RUNNING"} 0`+"\n")
+		assert.Contains(t, metrics, `numerous_app_workload_status{organization="acme",app="app",subscription="",status="STARTING"} 1`+"\n")
+	})
+
+	t.Run("removes workloads which are gone", func(t *testing.T) {
+		c := New()
+		c.Update("acme", "app", []app.AppWorkload{workload, subscriptionWorkload}, now)
+		c.Update("acme", "other-app", []app.AppWorkload{workload}, now)
+
+		c.Update("acme", "app", []app.AppWorkload{workload}, now)
+
+		metrics := write(t, c)
+		assert.NotContains(t, metrics, `subscription="sub-id"`)
+		assert.Contains(t, metrics, `numerous_app_workload_cpu_usage{organization="acme",app="other-app",subscription=""} 100`)
+	})
+
+	t.Run("retains only the given apps", func(t *testing.T) {
+		c := New()
+		c.Update("acme", "app", []app.AppWorkload{workload}, now)
+		c.Update("acme", "deleted-app", []app.AppWorkload{workload}, now)
+		c.RecordError("acme", "deleted-app")
+
+		c.Retain("acme", []string{"app"})
+
+		metrics := write(t, c)
+		assert.Contains(t, metrics, `app="app"`)
+		assert.NotContains(t, metrics, `app="deleted-app"`)
+	})
+
+	t.Run("counts refresh errors", func(t *testing.T) {
+		c := New()
+
+		c.RecordError("acme", "app")
+		c.RecordError("acme", "app")
+
+		metrics := write(t, c)
+		assert.Contains(t, metrics, `numerous_app_workloads_refresh_errors_total{organization="acme",app="app"} 2`+"\n")
+		assert.NotContains(t, metrics, "numerous_app_workloads_last_refresh_timestamp_seconds{")
+	})
+
+	t.Run("escapes label values", func(t *testing.T) {
+		c := New()
+
+		c.Update("acme", "a\"b\\c", []app.AppWorkload{workload}, now)
+
+		assert.Contains(t, write(t, c), `app="a\"b\\c"`)
+	})
+
+	t.Run("serves metrics over HTTP", func(t *testing.T) {
+		c := New()
+		c.Update("acme", "app", []app.AppWorkload{workload}, now)
+		rec := httptest.NewRecorder()
+
+		c.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), "numerous_app_workload_cpu_usage{")
+	})
+}
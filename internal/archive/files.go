@@ -0,0 +1,37 @@
+package archive
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// ListFiles returns the slash separated paths, relative to `srcDir`, of all
+// regular files that would be included in an archive of `srcDir`, excluding
+// files matching patterns in `exclude`.
+func ListFiles(srcDir string, exclude []string) ([]string, error) {
+	var files []string
+
+	err := filepath.Walk(srcDir, func(fileName string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(srcDir, fileName)
+		if err != nil {
+			return err
+		}
+
+		if relPath == "." || shouldExclude(exclude, relPath) || !fi.Mode().IsRegular() {
+			return nil
+		}
+
+		files = append(files, filepath.ToSlash(relPath))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
@@ -0,0 +1,23 @@
+package archive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListFiles(t *testing.T) {
+	t.Run("lists all files", func(t *testing.T) {
+		actual, err := ListFiles("testdata/testfolder/", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"dir/nested_file.txt", "file.txt"}, actual)
+	})
+
+	t.Run("lists files without ignored file path", func(t *testing.T) {
+		actual, err := ListFiles("testdata/testfolder/", []string{"dir/*"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"file.txt"}, actual)
+	})
+}
@@ -120,3 +120,29 @@ func extractRegularFile(tr *tar.Reader, header *tar.Header, target string) error
 
 	return f.Close()
 }
+
+// TarReadFiles reads the regular files of the tar file in the reader into
+// memory, and returns their contents mapped by their path in the archive.
+func TarReadFiles(content io.Reader) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	tr := tar.NewReader(content)
+
+	for {
+		header, err := tr.Next()
+		switch {
+		case err == io.EOF:
+			return files, nil
+		case err != nil:
+			return nil, err
+		case header == nil || header.Typeflag != tar.TypeReg:
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+
+		files[path.Clean(header.Name)] = data
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTarCreate(t *testing.T) {
@@ -39,6 +40,20 @@ func TestTarCreate(t *testing.T) {
 	})
 }
 
+func TestTarReadFiles(t *testing.T) {
+	t.Run("reads all files", func(t *testing.T) {
+		tarFile, err := os.Open("testdata/testfolder.tar")
+		require.NoError(t, err)
+		defer tarFile.Close()
+
+		actual, err := TarReadFiles(tarFile)
+
+		assert.NoError(t, err)
+		expected := readFiles(t, "testdata/testfolder")
+		assert.Equal(t, expected, actual)
+	})
+}
+
 func readTarFile(tarFilePath string) (map[string][]byte, error) {
 	result := make(map[string][]byte)
 	tarFile, err := os.Open(tarFilePath)
@@ -0,0 +1,9 @@
+package config
+
+import "path/filepath"
+
+// CustomLibrariesFilePath returns the path of the file with custom app library
+// definitions.
+func CustomLibrariesFilePath() string {
+	return filepath.Join(configBaseDir, "numerous", "libraries.toml")
+}
@@ -0,0 +1,14 @@
+package config
+
+import "path/filepath"
+
+// LogArchiveDir returns the directory, where app logs are recorded.
+func LogArchiveDir() string {
+	return filepath.Join(configBaseDir, "numerous", "logs")
+}
+
+// LogForwardCheckpointPath returns the path of the file, where the position of
+// the last log entry forwarded from the app is stored.
+func LogForwardCheckpointPath(orgSlug, appSlug string) string {
+	return filepath.Join(configBaseDir, "numerous", "forward", orgSlug, appSlug+".json")
+}
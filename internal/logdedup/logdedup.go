@@ -0,0 +1,41 @@
+// Package logdedup detects log entries, which are received again, e.g. after
+// resubscribing to logs, or recording logs again from an earlier point.
+package logdedup
+
+import (
+	"sync"
+	"time"
+)
+
+// Deduplicator tracks the log entries seen at the latest timestamp. Entries
+// are identified by their timestamp and text. The zero value is ready to use,
+// and it is safe for concurrent use.
+type Deduplicator struct {
+	mu     sync.Mutex
+	latest time.Time
+	texts  map[string]bool
+}
+
+// Add adds the entry, and returns true if it was seen before, or it is older
+// than the latest entry.
+func (d *Deduplicator) Add(timestamp time.Time, text string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch {
+	case timestamp.Before(d.latest):
+		return true
+	case timestamp.Equal(d.latest) && d.texts != nil:
+		if d.texts[text] {
+			return true
+		}
+		d.texts[text] = true
+
+		return false
+	default:
+		d.latest = timestamp
+		d.texts = map[string]bool{text: true}
+
+		return false
+	}
+}
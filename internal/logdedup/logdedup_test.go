@@ -0,0 +1,29 @@
+package logdedup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicator(t *testing.T) {
+	t.Run("detects entries seen before", func(t *testing.T) {
+		ts := time.Date(2024, time.November, 11, 11, 11, 11, 0, time.UTC)
+		var d Deduplicator
+
+		assert.False(t, d.Add(ts, "message 1"))
+		assert.False(t, d.Add(ts, "message 2"))
+		assert.True(t, d.Add(ts, "message 1"))
+		assert.False(t, d.Add(ts.Add(time.Second), "message 3"))
+		assert.True(t, d.Add(ts, "message 4"))
+	})
+
+	t.Run("handles entries without timestamp", func(t *testing.T) {
+		var d Deduplicator
+
+		assert.False(t, d.Add(time.Time{}, "message 1"))
+		assert.False(t, d.Add(time.Time{}, "message 2"))
+		assert.True(t, d.Add(time.Time{}, "message 1"))
+	})
+}
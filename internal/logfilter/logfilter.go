@@ -0,0 +1,184 @@
+// Package logfilter filters log entries by time range, regular expressions and
+// detected log levels, with context lines around matching entries, like grep.
+package logfilter
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"numerous.com/cli/internal/logging"
+)
+
+type Entry struct {
+	Timestamp time.Time
+	Text      string
+}
+
+type Options struct {
+	Since *time.Time
+	Until *time.Time
+	// Entries must match all of the Grep expressions, and none of the GrepV
+	// expressions.
+	Grep  []*regexp.Regexp
+	GrepV []*regexp.Regexp
+	// Entries must have a detected level of at least Level, if it is not empty.
+	Level logging.Level
+	// The number of entries to include before and after matching entries.
+	Context int
+}
+
+type Filter struct {
+	opts Options
+
+	// the level of the last entry with a detected level, which is used for
+	// entries without one, e.g. the lines of a traceback
+	currentLevel logging.Level
+
+	// entries not included, which may be included as context before the next
+	// match
+	before []Entry
+	// the number of entries to include as context after the last match
+	after int
+	// whether entries have been included, and entries have been skipped since
+	skippedSinceIncluded bool
+	included             bool
+}
+
+func New(opts Options) *Filter {
+	return &Filter{opts: opts}
+}
+
+// PastUntil returns true if the entry is after the until time, which means no
+// more entries should be read when reading entries in order.
+func (f *Filter) PastUntil(entry Entry) bool {
+	return f.opts.Until != nil && entry.Timestamp.After(*f.opts.Until)
+}
+
+// Add adds the entry to the filter, and returns the entries that should be
+// printed. If separate is true, the returned entries are not directly after
+// the previously returned entries, and a separator should be printed first.
+func (f *Filter) Add(entry Entry) (entries []Entry, separate bool) {
+	if f.opts.Since != nil && entry.Timestamp.Before(*f.opts.Since) {
+		return nil, false
+	}
+
+	if f.PastUntil(entry) {
+		return nil, false
+	}
+
+	if level, ok := DetectLevel(entry.Text); ok {
+		f.currentLevel = level
+	}
+
+	if f.matches(entry) {
+		f.before = append(f.before, entry)
+		entries = f.before
+		f.before = nil
+		f.after = f.opts.Context
+
+		return entries, f.include()
+	}
+
+	if f.after > 0 {
+		f.after--
+		return []Entry{entry}, f.include()
+	}
+
+	if f.opts.Context > 0 {
+		f.before = append(f.before, entry)
+		if len(f.before) <= f.opts.Context {
+			return nil, false
+		}
+		f.before = f.before[1:]
+	}
+
+	f.skippedSinceIncluded = true
+
+	return nil, false
+}
+
+func (f *Filter) include() bool {
+	separate := f.included && f.skippedSinceIncluded && f.opts.Context > 0
+	f.included = true
+	f.skippedSinceIncluded = false
+
+	return separate
+}
+
+func (f *Filter) matches(entry Entry) bool {
+	for _, re := range f.opts.Grep {
+		if !re.MatchString(entry.Text) {
+			return false
+		}
+	}
+
+	for _, re := range f.opts.GrepV {
+		if re.MatchString(entry.Text) {
+			return false
+		}
+	}
+
+	if f.opts.Level != "" {
+		return f.currentLevel != "" && levelSeverity(f.currentLevel) >= levelSeverity(f.opts.Level)
+	}
+
+	return true
+}
+
+var (
+	levelKeyPattern  = regexp.MustCompile(`(?i)\b(?:level|severity|lvl)"?\s*[=:]\s*"?([a-z]+)`)
+	levelWordPattern = regexp.MustCompile(`\b(TRACE|DEBUG|INFO|NOTICE|WARN|WARNING|ERROR|ERR|CRITICAL|FATAL|PANIC)\b`)
+	tracebackPattern = regexp.MustCompile(`^Traceback \(most recent call last\):`)
+)
+
+// DetectLevel returns the log level of the text, detected from common level
+// markers, like "level=warn", "[ERROR]", or "INFO:", or the start of a python
+// traceback.
+func DetectLevel(text string) (logging.Level, bool) {
+	if tracebackPattern.MatchString(text) {
+		return logging.LevelError, true
+	}
+
+	if m := levelKeyPattern.FindStringSubmatch(text); m != nil {
+		if level, ok := parseLevel(m[1]); ok {
+			return level, true
+		}
+	}
+
+	if m := levelWordPattern.FindStringSubmatch(text); m != nil {
+		return parseLevel(m[1])
+	}
+
+	return "", false
+}
+
+func parseLevel(marker string) (logging.Level, bool) {
+	switch strings.ToLower(marker) {
+	case "trace", "debug":
+		return logging.LevelDebug, true
+	case "info", "notice":
+		return logging.LevelInfo, true
+	case "warn", "warning":
+		return logging.LevelWarning, true
+	case "error", "err", "critical", "fatal", "panic":
+		return logging.LevelError, true
+	default:
+		return "", false
+	}
+}
+
+func levelSeverity(level logging.Level) int {
+	switch level {
+	case logging.LevelDebug:
+		return 0
+	case logging.LevelInfo:
+		return 1
+	case logging.LevelWarning:
+		return 2 // nolint:mnd
+	case logging.LevelError:
+		return 3 // nolint:mnd
+	default:
+		return -1
+	}
+}
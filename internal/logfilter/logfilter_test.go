@@ -0,0 +1,135 @@
+package logfilter
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"numerous.com/cli/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// result is an entry returned by the filter, or a separator, if text is "--"
+type result string
+
+func apply(f *Filter, texts ...string) []result {
+	ts := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	var results []result
+	for i, text := range texts {
+		entries, separate := f.Add(Entry{Timestamp: ts.Add(time.Duration(i) * time.Minute), Text: text})
+		if separate {
+			results = append(results, "--")
+		}
+
+		for _, e := range entries {
+			results = append(results, result(e.Text))
+		}
+	}
+
+	return results
+}
+
+func TestFilter(t *testing.T) {
+	t.Run("includes all entries without options", func(t *testing.T) {
+		actual := apply(New(Options{}), "a", "b", "c")
+
+		assert.Equal(t, []result{"a", "b", "c"}, actual)
+	})
+
+	t.Run("filters entries by time range", func(t *testing.T) {
+		since := time.Date(2024, time.March, 1, 12, 1, 0, 0, time.UTC)
+		until := time.Date(2024, time.March, 1, 12, 2, 0, 0, time.UTC)
+
+		actual := apply(New(Options{Since: &since, Until: &until}), "a", "b", "c", "d")
+
+		assert.Equal(t, []result{"b", "c"}, actual)
+	})
+
+	t.Run("filters entries by patterns", func(t *testing.T) {
+		opts := Options{
+			Grep:  []*regexp.Regexp{regexp.MustCompile("GET|POST")},
+			GrepV: []*regexp.Regexp{regexp.MustCompile("/healthz")},
+		}
+
+		actual := apply(New(opts), "GET /", "GET /healthz", "started", "POST /form")
+
+		assert.Equal(t, []result{"GET /", "POST /form"}, actual)
+	})
+
+	t.Run("filters entries by level, including lines without level after matching lines", func(t *testing.T) {
+		actual := apply(
+			New(Options{Level: logging.LevelWarning}),
+			"INFO: started",
+			"WARNING: slow request",
+			"ERROR:root:failed",
+			"Traceback (most recent call last):",
+			`  File "app.py", line 1`,
+			"INFO: recovered",
+			"  details",
+		)
+
+		assert.Equal(t, []result{
+			"WARNING: slow request",
+			"ERROR:root:failed",
+			"Traceback (most recent call last):",
+			`  File "app.py", line 1`,
+		}, actual)
+	})
+
+	t.Run("includes context around matching entries", func(t *testing.T) {
+		opts := Options{Grep: []*regexp.Regexp{regexp.MustCompile("match")}, Context: 1}
+
+		actual := apply(New(opts), "1", "2", "match 1", "3", "4", "5", "match 2", "match 3", "6", "7")
+
+		assert.Equal(t, []result{"2", "match 1", "3", "--", "5", "match 2", "match 3", "6"}, actual)
+	})
+
+	t.Run("does not separate overlapping context", func(t *testing.T) {
+		opts := Options{Grep: []*regexp.Regexp{regexp.MustCompile("match")}, Context: 1}
+
+		actual := apply(New(opts), "match 1", "2", "match 2", "3", "4")
+
+		assert.Equal(t, []result{"match 1", "2", "match 2", "3"}, actual)
+	})
+}
+
+func TestPastUntil(t *testing.T) {
+	until := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	f := New(Options{Until: &until})
+
+	assert.False(t, f.PastUntil(Entry{Timestamp: until}))
+	assert.True(t, f.PastUntil(Entry{Timestamp: until.Add(time.Second)}))
+}
+
+func TestDetectLevel(t *testing.T) {
+	testcases := []struct {
+		text     string
+		expected logging.Level
+	}{
+		{text: "DEBUG:root:message", expected: logging.LevelDebug},
+		{text: "2024-03-01 12:00:00 INFO  Started", expected: logging.LevelInfo},
+		{text: "[WARN] disk almost full", expected: logging.LevelWarning},
+		{text: "WARNING: slow", expected: logging.LevelWarning},
+		{text: "time=2024-03-01 level=error msg=failed", expected: logging.LevelError},
+		{text: `{"level": "Warning", "message": "slow"}`, expected: logging.LevelWarning},
+		{text: "CRITICAL:root:out of memory", expected: logging.LevelError},
+		{text: "Traceback (most recent call last):", expected: logging.LevelError},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.text, func(t *testing.T) {
+			actual, ok := DetectLevel(testcase.text)
+
+			assert.True(t, ok)
+			assert.Equal(t, testcase.expected, actual)
+		})
+	}
+
+	t.Run("returns false without level marker", func(t *testing.T) {
+		_, ok := DetectLevel("an error occurred while processing info")
+
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,233 @@
+// Package logformat formats structured log lines for display.
+//
+// Log lines which are JSON objects are formatted as "LEVEL message key=value",
+// with the level colored, and other lines are left unchanged.
+package logformat
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"numerous.com/cli/internal/output"
+)
+
+var (
+	levelKeys     = []string{"level", "lvl", "severity", "levelname", "log.level"}
+	messageKeys   = []string{"msg", "message", "event"}
+	timestampKeys = []string{"time", "timestamp", "ts", "@timestamp", "asctime"}
+)
+
+// timestampFormats are the formats of string timestamps, in addition to
+// numeric Unix timestamps in seconds or milliseconds.
+var timestampFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05,999",
+}
+
+// unixMillisThreshold is the lowest numeric timestamp, which is treated as
+// milliseconds instead of seconds, which is in the year 33658 in seconds.
+const unixMillisThreshold = 1e12
+
+var levelColors = map[string]string{
+	"debug":    output.AnsiFaint,
+	"trace":    output.AnsiFaint,
+	"info":     output.AnsiGreen,
+	"warn":     output.AnsiYellow,
+	"warning":  output.AnsiYellow,
+	"error":    output.AnsiRed,
+	"err":      output.AnsiRed,
+	"critical": output.AnsiRed,
+	"fatal":    output.AnsiRed,
+	"panic":    output.AnsiRed,
+}
+
+type Options struct {
+	// Fields are the keys of the fields to show, in addition to the level and
+	// message, or all fields if empty.
+	Fields []string
+	// Raw disables formatting, so lines are returned unchanged.
+	Raw bool
+}
+
+type field struct {
+	key   string
+	value json.RawMessage
+}
+
+// Format returns the text to display for the log line, and the timestamp of
+// the line, if it is a JSON object with a timestamp field.
+func Format(text string, opts Options) (string, *time.Time) {
+	if opts.Raw {
+		return text, nil
+	}
+
+	fields, ok := parseObject(text)
+	if !ok {
+		return text, nil
+	}
+
+	level, fields := takeString(fields, levelKeys)
+	message, fields := takeString(fields, messageKeys)
+	timestamp, fields := takeTimestamp(fields)
+
+	var parts []string
+	if level != "" {
+		color, ok := levelColors[strings.ToLower(level)]
+		if !ok {
+			color = output.AnsiReset
+		}
+		parts = append(parts, color+strings.ToUpper(level)+output.AnsiReset)
+	}
+
+	if message != "" {
+		parts = append(parts, message)
+	}
+
+	for _, f := range selectFields(fields, opts.Fields) {
+		parts = append(parts, output.AnsiFaint+f.key+"="+output.AnsiReset+formatValue(f.value))
+	}
+
+	return strings.Join(parts, " "), timestamp
+}
+
+// parseObject returns the fields of the JSON object in the text, in the order
+// they appear, or false if the text is not a JSON object.
+func parseObject(text string) ([]field, bool) {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") || !strings.HasSuffix(trimmed, "}") {
+		return nil, false
+	}
+
+	dec := json.NewDecoder(strings.NewReader(trimmed))
+	if _, err := dec.Token(); err != nil {
+		return nil, false
+	}
+
+	var fields []field
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, false
+		}
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, false
+		}
+
+		fields = append(fields, field{key: key.(string), value: value}) // nolint:forcetypeassert
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, false
+	}
+
+	return fields, true
+}
+
+// takeString returns the string value of the first field with one of the
+// keys, and the fields without it.
+func takeString(fields []field, keys []string) (string, []field) {
+	for _, key := range keys {
+		i := slices.IndexFunc(fields, func(f field) bool { return f.key == key })
+		if i < 0 {
+			continue
+		}
+
+		var s string
+		if err := json.Unmarshal(fields[i].value, &s); err != nil {
+			continue
+		}
+
+		return s, slices.Delete(slices.Clone(fields), i, i+1)
+	}
+
+	return "", fields
+}
+
+// takeTimestamp returns the timestamp of the first timestamp field, which
+// can be parsed, and the fields without it.
+func takeTimestamp(fields []field) (*time.Time, []field) {
+	for _, key := range timestampKeys {
+		i := slices.IndexFunc(fields, func(f field) bool { return f.key == key })
+		if i < 0 {
+			continue
+		}
+
+		if ts, ok := parseTimestamp(fields[i].value); ok {
+			return &ts, slices.Delete(slices.Clone(fields), i, i+1)
+		}
+	}
+
+	return nil, fields
+}
+
+func parseTimestamp(value json.RawMessage) (time.Time, bool) {
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		for _, format := range timestampFormats {
+			if ts, err := time.Parse(format, s); err == nil {
+				return ts, true
+			}
+		}
+
+		return time.Time{}, false
+	}
+
+	var n float64
+	if err := json.Unmarshal(value, &n); err != nil || n <= 0 {
+		return time.Time{}, false
+	}
+
+	if n >= unixMillisThreshold {
+		return time.UnixMilli(int64(n)).UTC(), true
+	}
+
+	sec := int64(n)
+
+	return time.Unix(sec, int64((n-float64(sec))*float64(time.Second))).UTC(), true
+}
+
+// selectFields returns the fields with the keys, in the order of the keys, or
+// all fields if there are no keys.
+func selectFields(fields []field, keys []string) []field {
+	if len(keys) == 0 {
+		return fields
+	}
+
+	var selected []field
+	for _, key := range keys {
+		if i := slices.IndexFunc(fields, func(f field) bool { return f.key == key }); i >= 0 {
+			selected = append(selected, fields[i])
+		}
+	}
+
+	return selected
+}
+
+// formatValue returns strings without quotes, unless they contain spaces,
+// quotes or equal signs, and other values as compact JSON.
+func formatValue(value json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+			return strconv.Quote(s)
+		}
+
+		return s
+	}
+
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, value); err != nil {
+		return string(value)
+	}
+
+	return buf.String()
+}
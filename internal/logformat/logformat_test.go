@@ -0,0 +1,72 @@
+package logformat
+
+import (
+	"testing"
+	"time"
+
+	"numerous.com/cli/internal/output"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	faintKey := func(key string) string { return output.AnsiFaint + key + "=" + output.AnsiReset }
+
+	t.Run("formats JSON object as level, message and fields", func(t *testing.T) {
+		text, ts := Format(`{"level": "error", "msg": "request failed", "path": "/api/users", "status": 500, "error": "db: timeout exceeded"}`, Options{})
+
+		expected := output.AnsiRed + "ERROR" + output.AnsiReset + " request failed " +
+			faintKey("path") + "/api/users " + faintKey("status") + "500 " + faintKey("error") + `"db: timeout exceeded"`
+		assert.Equal(t, expected, text)
+		assert.Nil(t, ts)
+	})
+
+	t.Run("returns the timestamp of the log line", func(t *testing.T) {
+		for value, expected := range map[string]time.Time{
+			`"2024-03-01T12:00:00.5Z"`:    time.Date(2024, time.March, 1, 12, 0, 0, 500000000, time.UTC),
+			`"2024-03-01 12:00:00,250"`:   time.Date(2024, time.March, 1, 12, 0, 0, 250000000, time.UTC),
+			`1709294400`:                  time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC),
+			`1709294400500`:               time.Date(2024, time.March, 1, 12, 0, 0, 500000000, time.UTC),
+			`"2024-03-01T13:00:00+01:00"`: time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC),
+		} {
+			text, ts := Format(`{"message": "started", "time": `+value+`}`, Options{})
+
+			assert.Equal(t, "started", text, value)
+			if assert.NotNil(t, ts, value) {
+				assert.True(t, expected.Equal(*ts), "%s: expected %s, got %s", value, expected, ts)
+			}
+		}
+	})
+
+	t.Run("keeps unparsable timestamp as field", func(t *testing.T) {
+		text, ts := Format(`{"msg": "started", "time": "yesterday"}`, Options{})
+
+		assert.Equal(t, "started "+faintKey("time")+"yesterday", text)
+		assert.Nil(t, ts)
+	})
+
+	t.Run("shows selected fields in the given order", func(t *testing.T) {
+		text, _ := Format(`{"level": "info", "msg": "request", "path": "/", "status": 200, "user": "alice"}`, Options{Fields: []string{"user", "path", "missing"}})
+
+		expected := output.AnsiGreen + "INFO" + output.AnsiReset + " request " + faintKey("user") + "alice " + faintKey("path") + "/"
+		assert.Equal(t, expected, text)
+	})
+
+	t.Run("returns raw line unchanged", func(t *testing.T) {
+		line := `{"level": "info", "msg": "request"}`
+
+		text, ts := Format(line, Options{Raw: true})
+
+		assert.Equal(t, line, text)
+		assert.Nil(t, ts)
+	})
+
+	t.Run("returns plain text and non-object lines unchanged", func(t *testing.T) {
+		for _, line := range []string{"INFO: started", `["a", "b"]`, `{"invalid": }`, `{"a": 1} trailing`, ""} {
+			text, ts := Format(line, Options{})
+
+			assert.Equal(t, line, text)
+			assert.Nil(t, ts)
+		}
+	})
+}
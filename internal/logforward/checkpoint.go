@@ -0,0 +1,84 @@
+package logforward
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"numerous.com/cli/internal/app"
+)
+
+const (
+	checkpointDirPerm  = 0o755
+	checkpointFilePerm = 0o644
+)
+
+// Checkpoint is the position of the last forwarded log entry. Since entries
+// can share a timestamp, it holds the texts of all forwarded entries with the
+// latest timestamp.
+type Checkpoint struct {
+	path      string
+	Timestamp time.Time `json:"timestamp"`
+	Texts     []string  `json:"texts"`
+}
+
+// LoadCheckpoint reads the checkpoint from the file, or returns an empty
+// checkpoint, if the file does not exist.
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	c := &Checkpoint{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// IsEmpty returns true if no entries have been forwarded.
+func (c *Checkpoint) IsEmpty() bool {
+	return c.Timestamp.IsZero()
+}
+
+// Advance moves the checkpoint past the entries, which are ordered by their
+// timestamps.
+func (c *Checkpoint) Advance(entries []app.AppDeployLogEntry) {
+	for _, e := range entries {
+		switch {
+		case e.Timestamp.After(c.Timestamp):
+			c.Timestamp = e.Timestamp
+			c.Texts = []string{e.Text}
+		case e.Timestamp.Equal(c.Timestamp) && !slices.Contains(c.Texts, e.Text):
+			c.Texts = append(c.Texts, e.Text)
+		}
+	}
+}
+
+// Save writes the checkpoint to its file, replacing it atomically.
+func (c *Checkpoint) Save() error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), checkpointDirPerm); err != nil {
+		return err
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, checkpointFilePerm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, c.path)
+}
@@ -0,0 +1,194 @@
+package logforward
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"numerous.com/cli/internal/app"
+)
+
+const (
+	defaultFileMaxSize  = 100 * 1024 * 1024
+	defaultFileMaxFiles = 5
+	fileDirPerm         = 0o755
+	filePerm            = 0o644
+)
+
+var (
+	errInvalidSize     = errors.New("invalid size")
+	errInvalidMaxFiles = errors.New("invalid max-files")
+)
+
+var sizeUnits = map[string]int64{"": 1, "B": 1, "KB": 1024, "MB": 1024 * 1024, "GB": 1024 * 1024 * 1024}
+
+// fileSink appends entries as JSON lines to a file. When the file would exceed
+// its maximum size, it is rotated: "app.log" is renamed to "app.log.1",
+// "app.log.1" to "app.log.2" and so on, keeping at most the maximum number of
+// files, including the current one.
+type fileSink struct {
+	path     string
+	maxSize  int64
+	maxFiles int
+	labels   Labels
+	file     *os.File
+	size     int64
+}
+
+type fileEntry struct {
+	Timestamp    string `json:"timestamp"`
+	Text         string `json:"text"`
+	Organization string `json:"organization"`
+	App          string `json:"app"`
+}
+
+func newFileSink(path string, query url.Values, labels Labels) (*fileSink, error) {
+	s := &fileSink{path: path, maxSize: defaultFileMaxSize, maxFiles: defaultFileMaxFiles, labels: labels}
+
+	if v := query.Get("max-size"); v != "" {
+		size, err := parseSize(v)
+		if err != nil {
+			return nil, err
+		}
+		s.maxSize = size
+	}
+
+	if v := query.Get("max-files"); v != "" {
+		maxFiles, err := strconv.Atoi(v)
+		if err != nil || maxFiles < 1 {
+			return nil, fmt.Errorf("%w %q", errInvalidMaxFiles, v)
+		}
+		s.maxFiles = maxFiles
+	}
+
+	return s, nil
+}
+
+// parseSize parses sizes in bytes, optionally with a unit, e.g. "10MB".
+func parseSize(v string) (int64, error) {
+	upper := strings.ToUpper(strings.TrimSpace(v))
+	number := strings.TrimRight(upper, "KMGB")
+	unit, ok := sizeUnits[upper[len(number):]]
+	if !ok {
+		return 0, fmt.Errorf("%w %q", errInvalidSize, v)
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSpace(number), 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%w %q", errInvalidSize, v)
+	}
+
+	return n * unit, nil
+}
+
+func (s *fileSink) Name() string {
+	return "file:" + s.path
+}
+
+func (s *fileSink) Send(ctx context.Context, entries []app.AppDeployLogEntry) error {
+	if err := s.open(); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		line, err := json.Marshal(fileEntry{
+			Timestamp:    e.Timestamp.Format(time.RFC3339Nano),
+			Text:         e.Text,
+			Organization: s.labels.Organization,
+			App:          s.labels.App,
+		})
+		if err != nil {
+			return err
+		}
+		line = append(line, '\n')
+
+		if s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+			if err := s.rotate(); err != nil {
+				return err
+			}
+		}
+
+		n, err := s.file.Write(line)
+		s.size += int64(n)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *fileSink) open() error {
+	if s.file != nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), fileDirPerm); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, filePerm)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	s.file = f
+	s.size = info.Size()
+
+	return nil
+}
+
+// rotate shifts the numbered files, removing the oldest, and starts a new
+// current file.
+func (s *fileSink) rotate() error {
+	if err := s.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Remove(s.rotatedPath(s.maxFiles - 1)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	for i := s.maxFiles - 2; i >= 0; i-- { // nolint:mnd
+		if err := os.Rename(s.rotatedPath(i), s.rotatedPath(i+1)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return s.open()
+}
+
+// rotatedPath returns the path of the i'th rotated file, where 0 is the
+// current file.
+func (s *fileSink) rotatedPath(i int) string {
+	if i == 0 {
+		return s.path
+	}
+
+	return s.path + "." + strconv.Itoa(i)
+}
+
+func (s *fileSink) Close() error {
+	if s.file == nil {
+		return nil
+	}
+
+	err := s.file.Close()
+	s.file = nil
+
+	return err
+}
@@ -0,0 +1,161 @@
+// Package logforward forwards app log entries to external sinks, like syslog
+// servers, OpenTelemetry collectors, Loki or rotating files.
+//
+// Entries are sent in batches, and failed batches are retried with an
+// exponential backoff. After a batch is sent to all sinks, a checkpoint is
+// saved, so forwarding can resume after the last forwarded entry. Entries may
+// be sent more than once, e.g. if a sink fails after accepting part of a batch.
+package logforward
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/logdedup"
+)
+
+var (
+	retryInitialDelay = time.Second
+	retryMaxDelay     = time.Minute
+)
+
+// ErrPermanent wraps errors of sinks, which are not resolved by retrying, like
+// rejected credentials.
+var ErrPermanent = errors.New("permanent error")
+
+// Labels identify the app of the forwarded logs in the sinks.
+type Labels struct {
+	Organization string
+	App          string
+}
+
+type Sink interface {
+	// Name returns a description of the sink for messages.
+	Name() string
+	// Send sends the batch of log entries to the sink. Errors wrapping
+	// ErrPermanent are not retried.
+	Send(ctx context.Context, entries []app.AppDeployLogEntry) error
+	Close() error
+}
+
+type Options struct {
+	Sinks         []Sink
+	Checkpoint    *Checkpoint
+	BatchSize     int
+	FlushInterval time.Duration
+	// OnRetry is called when sending a batch to a sink failed, before waiting
+	// for the delay and retrying.
+	OnRetry func(sink Sink, err error, delay time.Duration)
+}
+
+type Forwarder struct {
+	opts Options
+	seen logdedup.Deduplicator
+}
+
+func New(opts Options) *Forwarder {
+	f := &Forwarder{opts: opts}
+	for _, text := range opts.Checkpoint.Texts {
+		f.seen.Add(opts.Checkpoint.Timestamp, text)
+	}
+
+	return f
+}
+
+// Run forwards the entries until the channel is closed, or the context is
+// cancelled. Entries which were forwarded before, according to the checkpoint
+// or because they are received again after resubscribing, are skipped. It
+// returns an error if a batch could not be sent because of a permanent error,
+// or the checkpoint could not be saved.
+func (f *Forwarder) Run(ctx context.Context, entries <-chan app.AppDeployLogEntry) error {
+	ticker := time.NewTicker(f.opts.FlushInterval)
+	defer ticker.Stop()
+
+	var batch []app.AppDeployLogEntry
+	flush := func() error {
+		err := f.flush(ctx, batch)
+		batch = nil
+
+		return err
+	}
+
+	for {
+		var err error
+		select {
+		case entry, ok := <-entries:
+			if !ok {
+				err = flush()
+				if ctx.Err() != nil {
+					return nil
+				}
+
+				return err
+			}
+
+			if f.seen.Add(entry.Timestamp, entry.Text) {
+				continue
+			}
+
+			batch = append(batch, entry)
+			if len(batch) >= f.opts.BatchSize {
+				err = flush()
+			}
+		case <-ticker.C:
+			err = flush()
+		case <-ctx.Done():
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// flush sends the batch to all sinks, and saves the checkpoint after the last
+// entry of the batch.
+func (f *Forwarder) flush(ctx context.Context, batch []app.AppDeployLogEntry) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	for _, sink := range f.opts.Sinks {
+		if err := f.send(ctx, sink, batch); err != nil {
+			return err
+		}
+	}
+
+	f.opts.Checkpoint.Advance(batch)
+
+	return f.opts.Checkpoint.Save()
+}
+
+// send sends the batch to the sink, retrying with an exponential backoff until
+// it succeeds, fails permanently, or the context is cancelled.
+func (f *Forwarder) send(ctx context.Context, sink Sink, batch []app.AppDeployLogEntry) error {
+	delay := retryInitialDelay
+	for {
+		err := sink.Send(ctx, batch)
+		if err == nil || errors.Is(err, ErrPermanent) {
+			return err
+		}
+
+		if f.opts.OnRetry != nil {
+			f.opts.OnRetry(sink, err, delay)
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		delay = min(2*delay, retryMaxDelay) // nolint:mnd
+	}
+}
@@ -0,0 +1,170 @@
+package logforward
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"numerous.com/cli/internal/app"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSink records the sent batches, and fails with its errors first.
+type fakeSink struct {
+	batches [][]app.AppDeployLogEntry
+	errs    []error
+}
+
+func (s *fakeSink) Name() string { return "fake" }
+
+func (s *fakeSink) Send(ctx context.Context, entries []app.AppDeployLogEntry) error {
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+
+		return err
+	}
+
+	s.batches = append(s.batches, entries)
+
+	return nil
+}
+
+func (s *fakeSink) Close() error { return nil }
+
+func patchRetryDelay(t *testing.T) {
+	t.Helper()
+
+	orig := retryInitialDelay
+	retryInitialDelay = time.Millisecond
+	t.Cleanup(func() { retryInitialDelay = orig })
+}
+
+func entryAt(seconds int, text string) app.AppDeployLogEntry {
+	return app.AppDeployLogEntry{Timestamp: time.Date(2024, time.March, 1, 12, 0, seconds, 0, time.UTC), Text: text}
+}
+
+func sendAll(entries ...app.AppDeployLogEntry) chan app.AppDeployLogEntry {
+	ch := make(chan app.AppDeployLogEntry, len(entries))
+	for _, e := range entries {
+		ch <- e
+	}
+	close(ch)
+
+	return ch
+}
+
+func newCheckpoint(t *testing.T) *Checkpoint {
+	t.Helper()
+
+	c, err := LoadCheckpoint(filepath.Join(t.TempDir(), "checkpoint.json"))
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestForwarder(t *testing.T) {
+	t.Run("sends entries in batches and saves checkpoint", func(t *testing.T) {
+		sink := &fakeSink{}
+		checkpoint := newCheckpoint(t)
+		f := New(Options{Sinks: []Sink{sink}, Checkpoint: checkpoint, BatchSize: 2, FlushInterval: time.Hour})
+
+		err := f.Run(context.TODO(), sendAll(entryAt(1, "a"), entryAt(2, "b"), entryAt(2, "c")))
+
+		require.NoError(t, err)
+		assert.Equal(t, [][]app.AppDeployLogEntry{
+			{entryAt(1, "a"), entryAt(2, "b")},
+			{entryAt(2, "c")},
+		}, sink.batches)
+		loaded, err := LoadCheckpoint(checkpoint.path)
+		require.NoError(t, err)
+		assert.Equal(t, entryAt(2, "").Timestamp, loaded.Timestamp)
+		assert.Equal(t, []string{"b", "c"}, loaded.Texts)
+	})
+
+	t.Run("skips entries forwarded before the checkpoint", func(t *testing.T) {
+		sink := &fakeSink{}
+		checkpoint := newCheckpoint(t)
+		checkpoint.Advance([]app.AppDeployLogEntry{entryAt(1, "a"), entryAt(2, "b")})
+		f := New(Options{Sinks: []Sink{sink}, Checkpoint: checkpoint, BatchSize: 10, FlushInterval: time.Hour})
+
+		err := f.Run(context.TODO(), sendAll(entryAt(1, "a"), entryAt(2, "b"), entryAt(2, "c"), entryAt(3, "d"), entryAt(3, "d")))
+
+		require.NoError(t, err)
+		assert.Equal(t, [][]app.AppDeployLogEntry{{entryAt(2, "c"), entryAt(3, "d")}}, sink.batches)
+	})
+
+	t.Run("flushes after the flush interval", func(t *testing.T) {
+		sink := &fakeSink{}
+		ch := make(chan app.AppDeployLogEntry)
+		f := New(Options{Sinks: []Sink{sink}, Checkpoint: newCheckpoint(t), BatchSize: 10, FlushInterval: 10 * time.Millisecond})
+
+		go func() {
+			ch <- entryAt(1, "a")
+			time.Sleep(50 * time.Millisecond)
+			ch <- entryAt(2, "b")
+			close(ch)
+		}()
+		err := f.Run(context.TODO(), ch)
+
+		require.NoError(t, err)
+		assert.Equal(t, [][]app.AppDeployLogEntry{{entryAt(1, "a")}, {entryAt(2, "b")}}, sink.batches)
+	})
+
+	t.Run("retries failed batches", func(t *testing.T) {
+		patchRetryDelay(t)
+		sink := &fakeSink{errs: []error{errors.New("error 1"), errors.New("error 2")}}
+		retries := 0
+		f := New(Options{
+			Sinks:         []Sink{sink},
+			Checkpoint:    newCheckpoint(t),
+			BatchSize:     10,
+			FlushInterval: time.Hour,
+			OnRetry:       func(Sink, error, time.Duration) { retries++ },
+		})
+
+		err := f.Run(context.TODO(), sendAll(entryAt(1, "a")))
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, retries)
+		assert.Equal(t, [][]app.AppDeployLogEntry{{entryAt(1, "a")}}, sink.batches)
+	})
+
+	t.Run("returns permanent errors without saving checkpoint", func(t *testing.T) {
+		permanentErr := errors.Join(ErrPermanent, errors.New("unauthorized"))
+		checkpoint := newCheckpoint(t)
+		f := New(Options{Sinks: []Sink{&fakeSink{errs: []error{permanentErr}}}, Checkpoint: checkpoint, BatchSize: 10, FlushInterval: time.Hour})
+
+		err := f.Run(context.TODO(), sendAll(entryAt(1, "a")))
+
+		assert.ErrorIs(t, err, ErrPermanent)
+		assert.True(t, checkpoint.IsEmpty())
+		assert.NoFileExists(t, checkpoint.path)
+	})
+}
+
+func TestLoadCheckpoint(t *testing.T) {
+	t.Run("returns empty checkpoint for non-existing file", func(t *testing.T) {
+		c, err := LoadCheckpoint(filepath.Join(t.TempDir(), "non-existing.json"))
+
+		require.NoError(t, err)
+		assert.True(t, c.IsEmpty())
+	})
+
+	t.Run("loads saved checkpoint", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "dir", "checkpoint.json")
+		c, err := LoadCheckpoint(path)
+		require.NoError(t, err)
+		c.Advance([]app.AppDeployLogEntry{entryAt(1, "a"), entryAt(1, "b"), entryAt(1, "a")})
+		require.NoError(t, c.Save())
+
+		loaded, err := LoadCheckpoint(path)
+
+		require.NoError(t, err)
+		assert.Equal(t, c, loaded)
+	})
+}
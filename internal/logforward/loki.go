@@ -0,0 +1,48 @@
+package logforward
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"numerous.com/cli/internal/app"
+)
+
+// lokiSink pushes entries to the Loki push API, in one stream labeled with the
+// organization and app slugs.
+type lokiSink struct {
+	httpSink
+	labels Labels
+}
+
+func newLokiSink(u *url.URL, labels Labels, headers http.Header) *lokiSink {
+	return &lokiSink{httpSink: newHTTPSink(u, headers), labels: labels}
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+func (s *lokiSink) Send(ctx context.Context, entries []app.AppDeployLogEntry) error {
+	stream := lokiStream{
+		Stream: map[string]string{"organization": s.labels.Organization, "app": s.labels.App, "source": "numerous"},
+		Values: make([][2]string, 0, len(entries)),
+	}
+	for _, e := range entries {
+		stream.Values = append(stream.Values, [2]string{strconv.FormatInt(e.Timestamp.UnixNano(), 10), e.Text}) // nolint:mnd
+	}
+
+	body, err := json.Marshal(lokiPushRequest{Streams: []lokiStream{stream}})
+	if err != nil {
+		return err
+	}
+
+	return s.post(ctx, body)
+}
@@ -0,0 +1,107 @@
+package logforward
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/logging"
+)
+
+var otlpSeverityNumbers = map[logging.Level]int{
+	logging.LevelDebug:   5,
+	logging.LevelInfo:    9,
+	logging.LevelWarning: 13,
+	logging.LevelError:   17,
+}
+
+var otlpSeverityTexts = map[logging.Level]string{
+	logging.LevelDebug:   "DEBUG",
+	logging.LevelInfo:    "INFO",
+	logging.LevelWarning: "WARN",
+	logging.LevelError:   "ERROR",
+}
+
+// otlpSink sends entries to an OTLP/HTTP logs endpoint, encoded as JSON, with
+// the app slug as service name.
+type otlpSink struct {
+	httpSink
+	labels Labels
+}
+
+func newOTLPSink(u *url.URL, labels Labels, headers http.Header) *otlpSink {
+	return &otlpSink{httpSink: newHTTPSink(u, headers), labels: labels}
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         string    `json:"timeUnixNano"`
+	ObservedTimeUnixNano string    `json:"observedTimeUnixNano"`
+	SeverityNumber       int       `json:"severityNumber"`
+	SeverityText         string    `json:"severityText"`
+	Body                 otlpValue `json:"body"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpLogsRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+func (s *otlpSink) Send(ctx context.Context, entries []app.AppDeployLogEntry) error {
+	scope := otlpScopeLogs{Scope: otlpScope{Name: "numerous-cli"}}
+	for _, e := range entries {
+		level := detectLevel(e.Text)
+		ts := strconv.FormatInt(e.Timestamp.UnixNano(), 10) // nolint:mnd
+		scope.LogRecords = append(scope.LogRecords, otlpLogRecord{
+			TimeUnixNano:         ts,
+			ObservedTimeUnixNano: ts,
+			SeverityNumber:       otlpSeverityNumbers[level],
+			SeverityText:         otlpSeverityTexts[level],
+			Body:                 otlpValue{StringValue: e.Text},
+		})
+	}
+
+	resource := otlpResourceLogs{
+		Resource: otlpResource{Attributes: []otlpAttribute{
+			{Key: "service.name", Value: otlpValue{StringValue: s.labels.App}},
+			{Key: "numerous.organization", Value: otlpValue{StringValue: s.labels.Organization}},
+			{Key: "numerous.app", Value: otlpValue{StringValue: s.labels.App}},
+		}},
+		ScopeLogs: []otlpScopeLogs{scope},
+	}
+
+	body, err := json.Marshal(otlpLogsRequest{ResourceLogs: []otlpResourceLogs{resource}})
+	if err != nil {
+		return err
+	}
+
+	return s.post(ctx, body)
+}
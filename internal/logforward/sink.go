@@ -0,0 +1,139 @@
+package logforward
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"numerous.com/cli/internal/logfilter"
+	"numerous.com/cli/internal/logging"
+)
+
+const httpTimeout = 30 * time.Second
+
+var (
+	ErrInvalidSink      = errors.New("invalid sink")
+	ErrUnexpectedStatus = errors.New("unexpected status")
+)
+
+// ParseSink returns the sink of the specification, which is a URL with a
+// scheme for the kind of sink:
+//
+//   - "syslog+udp://host:514" or "syslog+tcp://host:601" for RFC 5424 syslog.
+//   - "otlp+http://host:4318/v1/logs" (or otlp+https) for OTLP/HTTP logs.
+//   - "loki+http://host:3100/loki/api/v1/push" (or loki+https) for Loki.
+//   - "file:///path/to/app.log?max-size=100MB&max-files=5" for rotating files,
+//     or "file:app.log" for a path relative to the working directory.
+//
+// The headers are sent with requests to HTTP sinks, e.g. for authentication.
+func ParseSink(spec string, labels Labels, headers http.Header) (Sink, error) {
+	u, err := url.Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidSink, spec, err)
+	}
+
+	kind, transport, _ := strings.Cut(u.Scheme, "+")
+	switch {
+	case kind == "syslog" && (transport == "udp" || transport == "tcp") && u.Host != "":
+		return newSyslogSink(transport, u.Host, labels), nil
+	case kind == "otlp" && (transport == "http" || transport == "https") && u.Host != "":
+		return newOTLPSink(httpSinkURL(u, transport, "/v1/logs"), labels, headers), nil
+	case kind == "loki" && (transport == "http" || transport == "https") && u.Host != "":
+		return newLokiSink(httpSinkURL(u, transport, "/loki/api/v1/push"), labels, headers), nil
+	case kind == "file" && transport == "" && (u.Path != "" || u.Opaque != ""):
+		path := u.Path
+		if path == "" {
+			// relative paths, like "file:logs/app.log"
+			path = u.Opaque
+		}
+
+		sink, err := newFileSink(path, u.Query(), labels)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidSink, spec, err)
+		}
+
+		return sink, nil
+	default:
+		return nil, fmt.Errorf(`%w %q: must be a "syslog+udp", "syslog+tcp", "otlp+http", "otlp+https", "loki+http", "loki+https" or "file" URL`, ErrInvalidSink, spec)
+	}
+}
+
+// httpSinkURL returns the URL of the HTTP sink, with the default path if the
+// URL has none.
+func httpSinkURL(u *url.URL, scheme string, defaultPath string) *url.URL {
+	endpoint := *u
+	endpoint.Scheme = scheme
+	if endpoint.Path == "" || endpoint.Path == "/" {
+		endpoint.Path = defaultPath
+	}
+
+	return &endpoint
+}
+
+// httpSink posts batches of entries to an HTTP endpoint.
+type httpSink struct {
+	url     *url.URL
+	headers http.Header
+	client  *http.Client
+}
+
+func newHTTPSink(u *url.URL, headers http.Header) httpSink {
+	return httpSink{url: u, headers: headers, client: &http.Client{Timeout: httpTimeout}}
+}
+
+func (s *httpSink) Name() string {
+	return s.url.Redacted()
+}
+
+// post sends the JSON body. Responses with client errors, except for too many
+// requests, are permanent errors.
+func (s *httpSink) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url.String(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPermanent, err)
+	}
+
+	req.Header = s.headers.Clone()
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024)) // nolint:mnd
+	err = fmt.Errorf("%w %d: %s", ErrUnexpectedStatus, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: %w", ErrPermanent, err)
+	}
+
+	return err
+}
+
+func (s *httpSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// detectLevel returns the log level of the text, or info if it has none.
+func detectLevel(text string) logging.Level {
+	if level, ok := logfilter.DetectLevel(text); ok {
+		return level
+	}
+
+	return logging.LevelInfo
+}
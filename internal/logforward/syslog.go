@@ -0,0 +1,124 @@
+package logforward
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/logging"
+)
+
+const (
+	syslogDialTimeout  = 10 * time.Second
+	syslogWriteTimeout = 10 * time.Second
+	// syslogFacilityUser is the "user-level messages" facility.
+	syslogFacilityUser = 1
+	// syslogTimestampFormat is RFC 3339 with at most 6 fractional digits, as
+	// required by RFC 5424.
+	syslogTimestampFormat = "2006-01-02T15:04:05.000000Z07:00"
+	// syslogSDID is the structured data ID of the labels. 32473 is the private
+	// enterprise number reserved for documentation.
+	syslogSDID = "numerous@32473"
+)
+
+var syslogSeverities = map[logging.Level]int{
+	logging.LevelDebug:   7,
+	logging.LevelInfo:    6,
+	logging.LevelWarning: 4,
+	logging.LevelError:   3,
+}
+
+// syslogSink sends entries as RFC 5424 messages, one per datagram over UDP,
+// or with octet counting framing (RFC 6587) over TCP.
+type syslogSink struct {
+	network  string
+	addr     string
+	hostname string
+	labels   Labels
+	conn     net.Conn
+}
+
+func newSyslogSink(network string, addr string, labels Labels) *syslogSink {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	return &syslogSink{network: network, addr: addr, hostname: hostname, labels: labels}
+}
+
+func (s *syslogSink) Name() string {
+	return "syslog+" + s.network + "://" + s.addr
+}
+
+func (s *syslogSink) Send(ctx context.Context, entries []app.AppDeployLogEntry) error {
+	if s.conn == nil {
+		dialer := net.Dialer{Timeout: syslogDialTimeout}
+		conn, err := dialer.DialContext(ctx, s.network, s.addr)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	for _, e := range entries {
+		msg := s.format(e)
+		if s.network == "tcp" {
+			msg = fmt.Sprintf("%d %s", len(msg), msg)
+		}
+
+		s.conn.SetWriteDeadline(time.Now().Add(syslogWriteTimeout)) // nolint:errcheck
+		if _, err := s.conn.Write([]byte(msg)); err != nil {
+			// reconnect on the next attempt
+			s.Close() // nolint:errcheck
+			return err
+		}
+	}
+
+	return nil
+}
+
+// format returns the RFC 5424 message of the entry, with the app slug as
+// app name, and the labels as structured data.
+func (s *syslogSink) format(e app.AppDeployLogEntry) string {
+	pri := syslogFacilityUser*8 + syslogSeverities[detectLevel(e.Text)] // nolint:mnd
+	sd := fmt.Sprintf(`[%s organization="%s" app="%s"]`, syslogSDID, escapeSDParam(s.labels.Organization), escapeSDParam(s.labels.App))
+
+	return fmt.Sprintf("<%d>1 %s %s %s - - %s %s", pri, e.Timestamp.UTC().Format(syslogTimestampFormat), s.hostname, syslogAppName(s.labels.App), sd, e.Text)
+}
+
+func (s *syslogSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+
+	return err
+}
+
+var sdParamEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+func escapeSDParam(v string) string {
+	return sdParamEscaper.Replace(v)
+}
+
+// syslogAppName returns the app slug, truncated to the maximum length of the
+// APP-NAME field.
+func syslogAppName(slug string) string {
+	const maxLen = 48
+	if slug == "" {
+		return "-"
+	}
+
+	if len(slug) > maxLen {
+		return slug[:maxLen]
+	}
+
+	return slug
+}
@@ -0,0 +1,293 @@
+// Package logstore records app log entries in a local archive, and searches
+// the recorded entries.
+//
+// Entries are stored as JSON lines in segment files, with one file per
+// organization, app and day (in UTC), e.g.
+// "<dir>/my-org/my-app/2024-03-01.ndjson".
+package logstore
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"numerous.com/cli/internal/logdedup"
+)
+
+const (
+	segmentExt       = ".ndjson"
+	dirPerm          = 0o755
+	filePerm         = 0o644
+	maxEntrySize     = 1024 * 1024
+	segmentDayFormat = time.DateOnly
+)
+
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Text      string    `json:"text"`
+}
+
+type Store struct {
+	dir string
+}
+
+func New(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Recorder appends entries of the logs of an app to the store.
+type Recorder struct {
+	dir  string
+	day  string
+	file *os.File
+	seen logdedup.Deduplicator
+}
+
+// Recorder returns a recorder of the logs of the app. Entries which are older
+// than the latest recorded entry of the app, or which are already recorded,
+// are skipped, so logs can be recorded again from an earlier point.
+func (s *Store) Recorder(orgSlug, appSlug string) (*Recorder, error) {
+	dir := filepath.Join(s.dir, orgSlug, appSlug)
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, err
+	}
+
+	r := &Recorder{dir: dir}
+	if err := r.loadLatest(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// loadLatest reads the entries with the latest timestamp in the last segment.
+func (r *Recorder) loadLatest() error {
+	segments, err := listSegments(r.dir)
+	if err != nil || len(segments) == 0 {
+		return err
+	}
+
+	return readSegment(filepath.Join(r.dir, segments[len(segments)-1]), func(e Entry) error {
+		r.seen.Add(e.Timestamp, e.Text)
+		return nil
+	})
+}
+
+// Record appends the entry to the segment of its day.
+func (r *Recorder) Record(e Entry) error {
+	if r.seen.Add(e.Timestamp, e.Text) {
+		return nil
+	}
+
+	day := e.Timestamp.UTC().Format(segmentDayFormat)
+	if r.file == nil || day != r.day {
+		if err := r.Close(); err != nil {
+			return err
+		}
+
+		f, err := os.OpenFile(filepath.Join(r.dir, day+segmentExt), os.O_CREATE|os.O_APPEND|os.O_WRONLY, filePerm)
+		if err != nil {
+			return err
+		}
+		r.file = f
+		r.day = day
+	}
+
+	line, err := json.Marshal(Entry{Timestamp: e.Timestamp.UTC(), Text: e.Text})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.file.Write(append(line, '\n'))
+
+	return err
+}
+
+func (r *Recorder) Close() error {
+	if r.file == nil {
+		return nil
+	}
+
+	err := r.file.Close()
+	r.file = nil
+
+	return err
+}
+
+type SearchOptions struct {
+	Query *regexp.Regexp
+	Since *time.Time
+	Until *time.Time
+	// Only search the logs of the organization or app, if not empty.
+	OrganizationSlug string
+	AppSlug          string
+}
+
+type Result struct {
+	OrganizationSlug string
+	AppSlug          string
+	Entry
+}
+
+// Search returns the recorded entries matching the query, ordered by their
+// timestamps.
+func (s *Store) Search(opts SearchOptions) ([]Result, error) {
+	orgs, err := listDirs(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	for _, org := range orgs {
+		if opts.OrganizationSlug != "" && org != opts.OrganizationSlug {
+			continue
+		}
+
+		apps, err := listDirs(filepath.Join(s.dir, org))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, app := range apps {
+			if opts.AppSlug != "" && app != opts.AppSlug {
+				continue
+			}
+
+			appResults, err := s.searchApp(org, app, opts)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, appResults...)
+		}
+	}
+
+	slices.SortStableFunc(results, func(a, b Result) int { return a.Timestamp.Compare(b.Timestamp) })
+
+	return results, nil
+}
+
+func (s *Store) searchApp(org, app string, opts SearchOptions) ([]Result, error) {
+	dir := filepath.Join(s.dir, org, app)
+	segments, err := listSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	for _, segment := range segments {
+		if !segmentInRange(segment, opts.Since, opts.Until) {
+			continue
+		}
+
+		err := readSegment(filepath.Join(dir, segment), func(e Entry) error {
+			if opts.Since != nil && e.Timestamp.Before(*opts.Since) {
+				return nil
+			}
+
+			if opts.Until != nil && e.Timestamp.After(*opts.Until) {
+				return nil
+			}
+
+			if opts.Query != nil && !opts.Query.MatchString(e.Text) {
+				return nil
+			}
+
+			results = append(results, Result{OrganizationSlug: org, AppSlug: app, Entry: e})
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// segmentInRange returns true if the day of the segment overlaps the range.
+func segmentInRange(segment string, since, until *time.Time) bool {
+	day, err := time.Parse(segmentDayFormat, strings.TrimSuffix(segment, segmentExt))
+	if err != nil {
+		return false
+	}
+
+	if since != nil && day.Add(24*time.Hour).Before(*since) { // nolint:mnd
+		return false
+	}
+
+	if until != nil && day.After(*until) {
+		return false
+	}
+
+	return true
+}
+
+// readSegment calls fn for each entry in the segment. Lines which are not
+// valid entries, e.g. a partially written last line, are skipped.
+func readSegment(path string, fn func(Entry) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, maxEntrySize)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// listSegments returns the segment file names in the directory, in the order
+// of their days.
+func listSegments(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), segmentExt) {
+			segments = append(segments, e.Name())
+		}
+	}
+
+	return segments, nil
+}
+
+// listDirs returns the names of the directories in the directory, or none if
+// it does not exist.
+func listDirs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	for _, e := range entries {
+		if e.IsDir() {
+			dirs = append(dirs, e.Name())
+		}
+	}
+
+	return dirs, nil
+}
@@ -0,0 +1,125 @@
+package logstore
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func record(t *testing.T, s *Store, orgSlug, appSlug string, entries ...Entry) {
+	t.Helper()
+
+	r, err := s.Recorder(orgSlug, appSlug)
+	require.NoError(t, err)
+
+	for _, e := range entries {
+		require.NoError(t, r.Record(e))
+	}
+
+	require.NoError(t, r.Close())
+}
+
+func TestRecorder(t *testing.T) {
+	ts := time.Date(2024, time.March, 1, 23, 59, 59, 0, time.UTC)
+
+	t.Run("writes entries to segments of their day", func(t *testing.T) {
+		dir := t.TempDir()
+
+		record(t, New(dir), "org", "app",
+			Entry{Timestamp: ts, Text: "entry 1"},
+			Entry{Timestamp: ts.Add(time.Second), Text: "entry 2"},
+		)
+
+		day1, err := os.ReadFile(filepath.Join(dir, "org", "app", "2024-03-01.ndjson"))
+		require.NoError(t, err)
+		assert.Equal(t, `{"timestamp":"2024-03-01T23:59:59Z","text":"entry 1"}`+"\n", string(day1))
+		day2, err := os.ReadFile(filepath.Join(dir, "org", "app", "2024-03-02.ndjson"))
+		require.NoError(t, err)
+		assert.Equal(t, `{"timestamp":"2024-03-02T00:00:00Z","text":"entry 2"}`+"\n", string(day2))
+	})
+
+	t.Run("skips entries recorded before", func(t *testing.T) {
+		dir := t.TempDir()
+		s := New(dir)
+
+		record(t, s, "org", "app",
+			Entry{Timestamp: ts.Add(-time.Second), Text: "entry 1"},
+			Entry{Timestamp: ts, Text: "entry 2"},
+		)
+		record(t, s, "org", "app",
+			Entry{Timestamp: ts.Add(-time.Second), Text: "entry 1"},
+			Entry{Timestamp: ts, Text: "entry 2"},
+			Entry{Timestamp: ts, Text: "entry 3"},
+		)
+
+		data, err := os.ReadFile(filepath.Join(dir, "org", "app", "2024-03-01.ndjson"))
+		require.NoError(t, err)
+		expected := `{"timestamp":"2024-03-01T23:59:58Z","text":"entry 1"}` + "\n" +
+			`{"timestamp":"2024-03-01T23:59:59Z","text":"entry 2"}` + "\n" +
+			`{"timestamp":"2024-03-01T23:59:59Z","text":"entry 3"}` + "\n"
+		assert.Equal(t, expected, string(data))
+	})
+}
+
+func TestSearch(t *testing.T) {
+	ts := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+	s := New(dir)
+	record(t, s, "org-1", "app-1",
+		Entry{Timestamp: ts, Text: "error 1"},
+		Entry{Timestamp: ts.Add(2 * time.Hour), Text: "info"},
+		Entry{Timestamp: ts.Add(48 * time.Hour), Text: "error 3"},
+	)
+	record(t, s, "org-1", "app-2", Entry{Timestamp: ts.Add(time.Hour), Text: "error 2"})
+	record(t, s, "org-2", "app-1", Entry{Timestamp: ts.Add(3 * time.Hour), Text: "error 4"})
+
+	result := func(org, app string, ts time.Time, text string) Result {
+		return Result{OrganizationSlug: org, AppSlug: app, Entry: Entry{Timestamp: ts, Text: text}}
+	}
+
+	t.Run("returns matching entries of all apps in order", func(t *testing.T) {
+		actual, err := s.Search(SearchOptions{Query: regexp.MustCompile("error")})
+
+		require.NoError(t, err)
+		assert.Equal(t, []Result{
+			result("org-1", "app-1", ts, "error 1"),
+			result("org-1", "app-2", ts.Add(time.Hour), "error 2"),
+			result("org-2", "app-1", ts.Add(3*time.Hour), "error 4"),
+			result("org-1", "app-1", ts.Add(48*time.Hour), "error 3"),
+		}, actual)
+	})
+
+	t.Run("returns entries in the time range", func(t *testing.T) {
+		since, until := ts.Add(time.Hour), ts.Add(24*time.Hour)
+
+		actual, err := s.Search(SearchOptions{Query: regexp.MustCompile("error"), Since: &since, Until: &until})
+
+		require.NoError(t, err)
+		assert.Equal(t, []Result{
+			result("org-1", "app-2", ts.Add(time.Hour), "error 2"),
+			result("org-2", "app-1", ts.Add(3*time.Hour), "error 4"),
+		}, actual)
+	})
+
+	t.Run("returns entries of the organization and app", func(t *testing.T) {
+		actual, err := s.Search(SearchOptions{Query: regexp.MustCompile("error"), OrganizationSlug: "org-1", AppSlug: "app-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []Result{
+			result("org-1", "app-1", ts, "error 1"),
+			result("org-1", "app-1", ts.Add(48*time.Hour), "error 3"),
+		}, actual)
+	})
+
+	t.Run("returns nothing for an empty store", func(t *testing.T) {
+		actual, err := New(filepath.Join(dir, "non-existing")).Search(SearchOptions{})
+
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
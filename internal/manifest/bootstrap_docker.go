@@ -39,17 +39,10 @@ st.markdown(
 const dockerExampleRequirementsTxt = "streamlit\n"
 
 func (d Docker) bootstrapFiles(basePath string, port uint) error {
-	dockerfilePath := filepath.Join(basePath, d.Dockerfile)
 	appPath := filepath.Join(basePath, "app.py")
 	requirementsPath := filepath.Join(basePath, "requirements.txt")
 
-	if exists, err := fileExists(dockerfilePath); err != nil {
-		return err
-	} else if exists {
-		return ErrNoBootstrapDockerfileExists
-	}
-
-	if err := createAndWriteIfFileNotExist(dockerfilePath, fmt.Sprintf(dockerExampleDockerfile, port, port)); err != nil {
+	if err := d.bootstrapDockerfile(basePath, fmt.Sprintf(dockerExampleDockerfile, port, port)); err != nil {
 		return err
 	}
 
@@ -63,3 +56,17 @@ func (d Docker) bootstrapFiles(basePath string, port uint) error {
 
 	return nil
 }
+
+// bootstrapDockerfile writes the Dockerfile with the given content, or returns
+// ErrNoBootstrapDockerfileExists if it already exists.
+func (d Docker) bootstrapDockerfile(basePath string, content string) error {
+	dockerfilePath := filepath.Join(basePath, d.Dockerfile)
+
+	if exists, err := fileExists(dockerfilePath); err != nil {
+		return err
+	} else if exists {
+		return ErrNoBootstrapDockerfileExists
+	}
+
+	return createAndWriteIfFileNotExist(dockerfilePath, content)
+}
@@ -3,7 +3,10 @@ package manifest
 import (
 	"os"
 	"path/filepath"
+	"strings"
 
+	"numerous.com/cli/internal/output"
+	"numerous.com/cli/internal/pyproject"
 	"numerous.com/cli/internal/requirements"
 )
 
@@ -13,6 +16,10 @@ func (p Python) bootstrapFiles(basePath string) error {
 		return err
 	}
 
+	if p.ProjectFile != "" {
+		return p.bootstrapProject(basePath)
+	}
+
 	requirementsFilePath := filepath.Join(basePath, p.RequirementsFile)
 	if err := createFile(requirementsFilePath); err != nil {
 		return err
@@ -49,3 +56,57 @@ func (p Python) bootstrapRequirements(basePath string) error {
 
 	return req.Write(wfile)
 }
+
+// bootstrapProject adds the library requirements to the dependencies of the
+// project file, keeping its formatting.
+func (p Python) bootstrapProject(basePath string) error {
+	projectPath := filepath.Join(basePath, p.ProjectFile)
+	project, err := readFile(projectPath, pyproject.Read)
+	if err != nil {
+		return err
+	}
+
+	added := []string{}
+	for _, requirement := range p.Library.Requirements {
+		if project.HasDependency(requirement) {
+			continue
+		}
+
+		if err := project.Add(requirement); err != nil {
+			return err
+		}
+		added = append(added, requirement)
+	}
+
+	if len(added) == 0 {
+		return nil
+	}
+
+	wfile, err := os.Create(projectPath)
+	if err != nil {
+		return err
+	}
+	defer wfile.Close()
+
+	if err := project.Write(wfile); err != nil {
+		return err
+	}
+
+	if p.LockFile != "" {
+		output.Notify(
+			"Update the lock file",
+			"The dependencies %s were added to %q, so %q must be updated, e.g. with \"%s\".",
+			strings.Join(added, ", "), p.ProjectFile, p.LockFile, lockCommand(p.LockFile),
+		)
+	}
+
+	return nil
+}
+
+func lockCommand(lockFile string) string {
+	if filepath.Base(lockFile) == PoetryLockFileName {
+		return "poetry lock"
+	}
+
+	return "uv lock"
+}
@@ -0,0 +1,170 @@
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"numerous.com/cli/internal/dotenv"
+)
+
+// MaskedSecretValue is shown in place of the value of a secret.
+const MaskedSecretValue = "********"
+
+var (
+	ErrDeployEnvironmentNotFound = errors.New("deploy environment not found")
+	errInvalidDeployTable        = errors.New("deploy must be a table")
+)
+
+// DeployEnvironment is a deployment profile defined in a "deploy.<name>"
+// table, which overrides the default deployment and app configuration when
+// the environment is selected.
+type DeployEnvironment struct {
+	OrganizationSlug string  `toml:"organization,omitempty" json:"organization,omitempty"`
+	AppSlug          string  `toml:"app,omitempty" json:"app,omitempty"`
+	Size             *string `toml:"size,omitempty" json:"size,omitempty"`
+	EnvFile          string  `toml:"env_file,omitempty" json:"env_file,omitempty"`
+}
+
+// UnmarshalTOML decodes the default deployment keys of the deploy table, and
+// any sub tables as deploy environments.
+func (d *Deployment) UnmarshalTOML(value any) error {
+	table, ok := value.(map[string]any)
+	if !ok {
+		return errInvalidDeployTable
+	}
+
+	defaults := map[string]any{}
+	for key, v := range table {
+		envTable, isTable := v.(map[string]any)
+		if !isTable {
+			defaults[key] = v
+			continue
+		}
+
+		var env DeployEnvironment
+		if err := decodeTable(envTable, &env); err != nil {
+			return fmt.Errorf("deploy environment %q: %w", key, err)
+		}
+
+		if d.Environments == nil {
+			d.Environments = map[string]DeployEnvironment{}
+		}
+		d.Environments[key] = env
+	}
+
+	var fields struct {
+		OrganizationSlug string `toml:"organization"`
+		AppSlug          string `toml:"app"`
+	}
+	if err := decodeTable(defaults, &fields); err != nil {
+		return err
+	}
+	d.OrganizationSlug = fields.OrganizationSlug
+	d.AppSlug = fields.AppSlug
+
+	return nil
+}
+
+// decodeTable decodes an already parsed TOML table into v, by encoding it and
+// decoding it again, so the usual TOML type checks apply.
+func decodeTable(table map[string]any, v any) error {
+	buf := new(bytes.Buffer)
+	if err := toml.NewEncoder(buf).Encode(table); err != nil {
+		return err
+	}
+
+	_, err := toml.Decode(buf.String(), v)
+
+	return err
+}
+
+// encodeDeployEnvironments writes the deploy environments as sub tables of
+// the deploy table, which the TOML encoder cannot do, since the environments
+// are not a field of their own.
+func encodeDeployEnvironments(buf *bytes.Buffer, environments map[string]DeployEnvironment) error {
+	for _, name := range slices.Sorted(maps.Keys(environments)) {
+		envBuf := new(bytes.Buffer)
+		table := map[string]map[string]DeployEnvironment{"deploy": {name: environments[name]}}
+		if err := toml.NewEncoder(envBuf).Encode(table); err != nil {
+			return err
+		}
+
+		buf.WriteString("\n")
+		buf.WriteString(strings.TrimPrefix(envBuf.String(), "[deploy]\n"))
+	}
+
+	return nil
+}
+
+// DeployEnvironmentNames returns the sorted names of the deploy environments
+// defined in the manifest.
+func (m *Manifest) DeployEnvironmentNames() []string {
+	if m.Deployment == nil {
+		return nil
+	}
+
+	return slices.Sorted(maps.Keys(m.Deployment.Environments))
+}
+
+// ResolveDeployEnvironment returns the deployment configuration for the named
+// environment, where values not defined by the environment are taken from the
+// default deployment and the app configuration. If name is empty, only the
+// defaults are used.
+func (m *Manifest) ResolveDeployEnvironment(name string) (DeployEnvironment, error) {
+	resolved := DeployEnvironment{Size: m.Size, EnvFile: EnvFileName}
+	if m.Deployment != nil {
+		resolved.OrganizationSlug = m.Deployment.OrganizationSlug
+		resolved.AppSlug = m.Deployment.AppSlug
+	}
+
+	if name == "" {
+		return resolved, nil
+	}
+
+	var env DeployEnvironment
+	found := false
+	if m.Deployment != nil {
+		env, found = m.Deployment.Environments[name]
+	}
+
+	if !found {
+		return DeployEnvironment{}, fmt.Errorf("%w: %q", ErrDeployEnvironmentNotFound, name)
+	}
+
+	if env.OrganizationSlug != "" {
+		resolved.OrganizationSlug = env.OrganizationSlug
+	}
+
+	if env.AppSlug != "" {
+		resolved.AppSlug = env.AppSlug
+	}
+
+	if env.Size != nil {
+		resolved.Size = env.Size
+	}
+
+	if env.EnvFile != "" {
+		resolved.EnvFile = env.EnvFile
+	}
+
+	return resolved, nil
+}
+
+// LoadSecrets loads the secrets from the env file of the deploy environment,
+// relative to the app directory. A missing default env file is not an error,
+// since secrets are optional.
+func (d DeployEnvironment) LoadSecrets(appDir string) (map[string]string, error) {
+	secrets, err := dotenv.Load(filepath.Join(appDir, d.EnvFile))
+	if errors.Is(err, os.ErrNotExist) && d.EnvFile == EnvFileName {
+		return nil, nil
+	}
+
+	return secrets, err
+}
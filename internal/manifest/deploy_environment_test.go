@@ -0,0 +1,171 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"numerous.com/cli/internal/test"
+)
+
+const tomlStreamlitWithEnvironments string = `name = "Streamlit App Name"
+description = "A description"
+cover_image = "cover.png"
+exclude = ["*venv", "venv*"]
+port = 80
+size = "small"
+
+[python]
+  library = "streamlit"
+  version = "3.11"
+  app_file = "app.py"
+  requirements_file = "requirements.txt"
+
+[deploy]
+  organization = "organization-slug"
+  app = "app-slug"
+
+  [deploy.production]
+    organization = "production-organization-slug"
+    size = "large"
+    env_file = ".env.production"
+
+  [deploy.staging]
+    app = "staging-app-slug"
+`
+
+var manifestStreamlitWithEnvironments = Manifest{
+	App: App{
+		Name:        "Streamlit App Name",
+		Description: "A description",
+		CoverImage:  "cover.png",
+		Exclude:     []string{"*venv", "venv*"},
+		Port:        80,
+		Size:        ref("small"),
+	},
+	Python: &Python{
+		Library:          LibraryStreamlit,
+		Version:          "3.11",
+		AppFile:          "app.py",
+		RequirementsFile: "requirements.txt",
+	},
+	Deployment: &Deployment{
+		OrganizationSlug: "organization-slug",
+		AppSlug:          "app-slug",
+		Environments: map[string]DeployEnvironment{
+			"production": {OrganizationSlug: "production-organization-slug", Size: ref("large"), EnvFile: ".env.production"},
+			"staging":    {AppSlug: "staging-app-slug"},
+		},
+	},
+}
+
+func TestDeployEnvironments(t *testing.T) {
+	t.Run("loads deploy environments", func(t *testing.T) {
+		filePath := test.WriteTempFile(t, ManifestFileName, []byte(tomlStreamlitWithEnvironments))
+		defer os.Remove(filePath)
+
+		actual, err := Load(filePath)
+
+		require.NoError(t, err)
+		assert.Equal(t, manifestStreamlitWithEnvironments, *actual)
+		assert.Equal(t, []string{"production", "staging"}, actual.DeployEnvironmentNames())
+	})
+
+	t.Run("encodes deploy environments", func(t *testing.T) {
+		actual, err := manifestStreamlitWithEnvironments.ToTOML()
+
+		require.NoError(t, err)
+		assert.Equal(t, tomlStreamlitWithEnvironments, actual)
+	})
+
+	t.Run("returns error for invalid deploy environment value", func(t *testing.T) {
+		filePath := test.WriteTempFile(t, ManifestFileName, []byte("[docker]\ndockerfile = \"Dockerfile\"\n[deploy.staging]\nsize = 1\n"))
+		defer os.Remove(filePath)
+
+		_, err := Load(filePath)
+
+		assert.ErrorContains(t, err, `deploy environment "staging"`)
+	})
+
+	t.Run("validates deploy environments", func(t *testing.T) {
+		content := "[docker]\ndockerfile = \"Dockerfile\"\n\n[deploy]\norg = \"org\"\n\n[deploy.staging]\nsize = 1\nenv-file = \".env.staging\"\n"
+
+		actual := validate(content)
+
+		assert.Equal(t, []ValidationIssue{
+			{Line: 5, Key: "deploy.org", Message: `unknown key "org"`},
+			{Line: 8, Key: "deploy.staging.size", Message: "must be a string, not integer"},
+			{Line: 9, Key: "deploy.staging.env-file", Message: `unknown key "env-file", did you mean "env_file"?`},
+		}, actual.Issues)
+	})
+}
+
+func TestResolveDeployEnvironment(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		env      string
+		expected DeployEnvironment
+	}{
+		{
+			name:     "no environment returns defaults",
+			env:      "",
+			expected: DeployEnvironment{OrganizationSlug: "organization-slug", AppSlug: "app-slug", Size: ref("small"), EnvFile: ".env"},
+		},
+		{
+			name:     "environment overrides organization, size and env file",
+			env:      "production",
+			expected: DeployEnvironment{OrganizationSlug: "production-organization-slug", AppSlug: "app-slug", Size: ref("large"), EnvFile: ".env.production"},
+		},
+		{
+			name:     "environment overrides app",
+			env:      "staging",
+			expected: DeployEnvironment{OrganizationSlug: "organization-slug", AppSlug: "staging-app-slug", Size: ref("small"), EnvFile: ".env"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := manifestStreamlitWithEnvironments.ResolveDeployEnvironment(tc.env)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	t.Run("returns error for unknown environment", func(t *testing.T) {
+		_, err := manifestStreamlitWithEnvironments.ResolveDeployEnvironment("unknown")
+
+		assert.ErrorIs(t, err, ErrDeployEnvironmentNotFound)
+	})
+
+	t.Run("returns error for manifest without deployment", func(t *testing.T) {
+		_, err := manifestDockerNoDeploy.ResolveDeployEnvironment("staging")
+
+		assert.ErrorIs(t, err, ErrDeployEnvironmentNotFound)
+	})
+}
+
+func TestLoadSecrets(t *testing.T) {
+	t.Run("loads secrets from the env file", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.WriteFile(t, filepath.Join(appDir, ".env.staging"), []byte("API_KEY=secret\n"))
+
+		secrets, err := DeployEnvironment{EnvFile: ".env.staging"}.LoadSecrets(appDir)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"API_KEY": "secret"}, secrets)
+	})
+
+	t.Run("ignores missing default env file", func(t *testing.T) {
+		secrets, err := DeployEnvironment{EnvFile: EnvFileName}.LoadSecrets(t.TempDir())
+
+		require.NoError(t, err)
+		assert.Nil(t, secrets)
+	})
+
+	t.Run("returns error for missing env file of environment", func(t *testing.T) {
+		_, err := DeployEnvironment{EnvFile: ".env.staging"}.LoadSecrets(t.TempDir())
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,291 @@
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	EjectDockerfileName  = "Dockerfile"
+	DockerignoreFileName = ".dockerignore"
+
+	defaultEjectPythonVersion = "3.11"
+	uvImage                   = "ghcr.io/astral-sh/uv:latest"
+)
+
+var (
+	ErrEjectNoPythonConfiguration = errors.New("no python configuration to eject")
+	ErrNoServeCommand             = errors.New("library has no serve command")
+	ErrEjectNoPort                = errors.New("no port to serve the app on")
+	ErrEjectManifestText          = errors.New("manifest cannot be updated while keeping its formatting")
+)
+
+// Eject creates a Dockerfile and a .dockerignore file in basePath, which build
+// and serve the app like its python configuration, and changes the manifest
+// to build the app from the Dockerfile instead. If the manifest has no port,
+// the app is served on the default port of the library, which is set in the
+// manifest. The manifest file itself is not written.
+func (m *Manifest) Eject(basePath string) error {
+	if m.Python == nil {
+		return ErrEjectNoPythonConfiguration
+	}
+
+	port := m.ServePort()
+	if port == 0 {
+		return ErrEjectNoPort
+	}
+
+	dockerfile, err := m.Python.dockerfile(port)
+	if err != nil {
+		return err
+	}
+
+	docker := Docker{Dockerfile: EjectDockerfileName, Context: "."}
+	if err := docker.bootstrapDockerfile(basePath, dockerfile); err != nil {
+		return err
+	}
+
+	if err := createAndWriteIfFileNotExist(filepath.Join(basePath, DockerignoreFileName), dockerignore(m.Exclude)); err != nil {
+		return err
+	}
+
+	m.Port = port
+	m.Python = nil
+	m.Docker = &docker
+
+	return nil
+}
+
+// EjectText returns the manifest content, changed to match the ejected
+// manifest m. The python table is replaced by the docker table, and the port
+// is set, while comments and formatting of the rest of the content are kept.
+// Content in a deprecated format is migrated first.
+func EjectText(content string, m *Manifest) (string, error) {
+	if m.Docker == nil {
+		return "", ErrEjectManifestText
+	}
+
+	migrated, _, err := Migrate(content)
+	if err != nil {
+		return "", err
+	}
+
+	ejected, err := ejectText(migrated, m)
+	if err != nil {
+		return "", err
+	}
+
+	var check Manifest
+	if _, err := toml.Decode(ejected, &check); err != nil || !reflect.DeepEqual(check, *m) {
+		return "", ErrEjectManifestText
+	}
+
+	return ejected, nil
+}
+
+// ejectText replaces the python table with the docker table, and sets the top
+// level port. Comment lines directly above a key of the python table are
+// removed along with it.
+func ejectText(content string, m *Manifest) (string, error) {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+
+	var result, pending []string
+	table := ""
+	replaced, hasPort := false, false
+	depth := 0
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case depth > 0:
+			// continuation of a multiline value
+			depth += bracketDepth(line)
+			if table != "python" {
+				result = append(result, line)
+			}
+		case strings.HasPrefix(trimmed, "["):
+			if table == "" && !hasPort {
+				result = append(result, encodeKeyValue("port", m.Port, ""), "")
+				hasPort = true
+			}
+			result = append(result, pending...)
+			pending = nil
+
+			table = tableName(trimmed)
+			if table != "python" {
+				result = append(result, line)
+				continue
+			}
+
+			if replaced {
+				return "", ErrEjectManifestText
+			}
+			replaced = true
+			result = append(result,
+				"[docker]",
+				encodeKeyValue("dockerfile", m.Docker.Dockerfile, ""),
+				encodeKeyValue("context", m.Docker.Context, ""),
+			)
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			pending = append(pending, line)
+		default:
+			key, _, _ := strings.Cut(trimmed, "=")
+			key = strings.Trim(strings.TrimSpace(key), `"'`)
+			depth = bracketDepth(line)
+
+			switch {
+			case table == "python":
+			case table == "" && key == "port":
+				result = append(append(result, pending...), encodeKeyValue(key, m.Port, trailingComment(line)))
+				hasPort = true
+			default:
+				result = append(append(result, pending...), line)
+			}
+			pending = nil
+		}
+	}
+
+	if !replaced {
+		return "", ErrEjectManifestText
+	}
+
+	if table != "python" {
+		result = append(result, pending...)
+	}
+
+	var b strings.Builder
+	writeLines(&b, result)
+
+	return b.String(), nil
+}
+
+// tableName returns the name of the table in a table header line.
+func tableName(header string) string {
+	if i := commentIndex(header); i >= 0 {
+		header = header[:i]
+	}
+
+	return strings.TrimSpace(strings.Trim(strings.TrimSpace(header), "[]"))
+}
+
+func (p Python) dockerfile(port uint) (string, error) {
+	serveArgs := p.Library.ServeArgs(filepath.ToSlash(p.AppFile), port)
+	if len(serveArgs) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrNoServeCommand, p.Library.Name)
+	}
+
+	version := p.Version
+	if version == "" {
+		version = defaultEjectPythonVersion
+	}
+
+	lines := []string{
+		"# Generated by numerous eject from the [python] table of numerous.toml.",
+		"FROM python:" + version + "-slim",
+		"",
+		"WORKDIR /app",
+		"",
+	}
+	lines = append(lines, p.dockerfileInstall()...)
+	lines = append(lines, "", "COPY . /app", "")
+
+	for _, env := range p.Library.ServeEnvironment(filepath.ToSlash(p.AppFile), port) {
+		lines = append(lines, "ENV "+env)
+	}
+
+	lines = append(lines, fmt.Sprintf("EXPOSE %d", port), "", "CMD "+dockerExecForm(serveArgs), "")
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// dockerfileInstall returns the Dockerfile instructions installing the app
+// requirements, using the lock file or project file if defined.
+func (p Python) dockerfileInstall() []string {
+	projectFile := filepath.ToSlash(p.ProjectFile)
+	lockFile := filepath.ToSlash(p.LockFile)
+
+	switch {
+	case projectFile != "" && path.Base(lockFile) == UVLockFileName:
+		return []string{
+			"COPY --from=" + uvImage + " /uv /bin/uv",
+			"COPY " + projectFile + " " + lockFile + " /app/",
+			"RUN uv sync --locked --no-install-project --no-dev",
+			`ENV PATH="/app/.venv/bin:$PATH"`,
+		}
+	case projectFile != "" && path.Base(lockFile) == PoetryLockFileName:
+		return []string{
+			"RUN pip install --no-cache-dir poetry",
+			"COPY " + projectFile + " " + lockFile + " /app/",
+			"RUN poetry config virtualenvs.create false && poetry install --no-root --only main",
+		}
+	case projectFile != "":
+		return []string{
+			"COPY --from=" + uvImage + " /uv /bin/uv",
+			"COPY " + projectFile + " /app/" + projectFile,
+			"RUN uv pip install --system -r /app/" + projectFile,
+		}
+	case p.RequirementsFile != "":
+		requirementsFile := filepath.ToSlash(p.RequirementsFile)
+
+		return []string{
+			"COPY " + requirementsFile + " /app/" + requirementsFile,
+			"RUN pip install --no-cache-dir -r /app/" + requirementsFile,
+		}
+	default:
+		return []string{"RUN pip install --no-cache-dir " + strings.Join(p.Library.Requirements, " ")}
+	}
+}
+
+// dockerExecForm returns the arguments as a JSON array, as used in the exec
+// form of Dockerfile instructions.
+func dockerExecForm(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		b, _ := json.Marshal(arg) // nolint:errchkjson
+		quoted[i] = string(b)
+	}
+
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// dockerignore returns the content of a .dockerignore file, ignoring the same
+// files as the exclude patterns. Patterns without a slash match files in any
+// directory, like in the app archive, so they are prefixed with "**/", while
+// other patterns are relative to the app directory. The
+// environment file is always ignored, since secrets are not part of the image.
+func dockerignore(exclude []string) string {
+	lines := []string{"# Generated by numerous eject from the exclude list of numerous.toml."}
+	for _, pattern := range exclude {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		negation := ""
+		if strings.HasPrefix(pattern, "!") {
+			negation, pattern = "!", pattern[1:]
+		}
+
+		if strings.Contains(strings.TrimSuffix(pattern, "/"), "/") {
+			pattern = strings.TrimPrefix(strings.TrimPrefix(pattern, "./"), "/")
+		} else {
+			pattern = "**/" + pattern
+		}
+
+		lines = append(lines, negation+pattern)
+	}
+
+	if !slices.Contains(lines, "**/"+EnvFileName) {
+		lines = append(lines, "**/"+EnvFileName)
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
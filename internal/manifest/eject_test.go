@@ -0,0 +1,240 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"numerous.com/cli/internal/test"
+)
+
+func TestEject(t *testing.T) {
+	t.Run("creates Dockerfile and .dockerignore and converts manifest", func(t *testing.T) {
+		basePath := t.TempDir()
+		m := Manifest{
+			App:    App{Name: "App", Exclude: []string{"*venv", ".git", "./data/", ".env"}, Port: 80},
+			Python: &Python{Library: LibraryStreamlit, Version: "3.12", AppFile: "app.py", RequirementsFile: "requirements.txt"},
+		}
+
+		err := m.Eject(basePath)
+
+		require.NoError(t, err)
+		assert.Nil(t, m.Python)
+		assert.Equal(t, &Docker{Dockerfile: "Dockerfile", Context: "."}, m.Docker)
+		test.AssertFileContent(t, filepath.Join(basePath, "Dockerfile"), []byte(`# Generated by numerous eject from the [python] table of numerous.toml.
+FROM python:3.12-slim
+
+WORKDIR /app
+
+COPY requirements.txt /app/requirements.txt
+RUN pip install --no-cache-dir -r /app/requirements.txt
+
+COPY . /app
+
+EXPOSE 80
+
+CMD ["streamlit", "run", "app.py", "--server.port", "80", "--server.address", "0.0.0.0"]
+`))
+		test.AssertFileContent(t, filepath.Join(basePath, ".dockerignore"), []byte(`# Generated by numerous eject from the exclude list of numerous.toml.
+**/*venv
+**/.git
+data/
+**/.env
+`))
+	})
+
+	t.Run("returns error if Dockerfile exists", func(t *testing.T) {
+		basePath := t.TempDir()
+		test.WriteFile(t, filepath.Join(basePath, "Dockerfile"), []byte("FROM scratch\n"))
+		m := Manifest{App: App{Port: 80}, Python: &Python{Library: LibraryStreamlit, AppFile: "app.py"}}
+
+		err := m.Eject(basePath)
+
+		assert.ErrorIs(t, err, ErrNoBootstrapDockerfileExists)
+		assert.NotNil(t, m.Python)
+		assert.NoFileExists(t, filepath.Join(basePath, ".dockerignore"))
+	})
+
+	t.Run("returns error for library without serve command", func(t *testing.T) {
+		basePath := t.TempDir()
+		m := Manifest{App: App{Port: 80}, Python: &Python{Library: Library{Name: "Custom", Key: "custom"}, AppFile: "app.py"}}
+
+		err := m.Eject(basePath)
+
+		assert.ErrorIs(t, err, ErrNoServeCommand)
+		_, statErr := os.Stat(filepath.Join(basePath, "Dockerfile"))
+		assert.ErrorIs(t, statErr, os.ErrNotExist)
+	})
+
+	t.Run("uses default library port if manifest has no port", func(t *testing.T) {
+		basePath := t.TempDir()
+		m := Manifest{Python: &Python{Library: LibraryFastAPI, Version: "3.12", AppFile: "app.py"}}
+
+		err := m.Eject(basePath)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(8000), m.Port)
+		dockerfile, err := os.ReadFile(filepath.Join(basePath, "Dockerfile"))
+		require.NoError(t, err)
+		assert.Contains(t, string(dockerfile), "EXPOSE 8000\n")
+	})
+
+	t.Run("returns error if library has no port and manifest has no port", func(t *testing.T) {
+		basePath := t.TempDir()
+		m := Manifest{Python: &Python{Library: Library{Name: "Custom", Key: "custom"}, AppFile: "app.py"}}
+
+		err := m.Eject(basePath)
+
+		assert.ErrorIs(t, err, ErrEjectNoPort)
+		assert.NoFileExists(t, filepath.Join(basePath, "Dockerfile"))
+	})
+
+	t.Run("returns error for docker manifest", func(t *testing.T) {
+		m := Manifest{Docker: &Docker{Dockerfile: "Dockerfile", Context: "."}}
+
+		err := m.Eject(t.TempDir())
+
+		assert.ErrorIs(t, err, ErrEjectNoPythonConfiguration)
+	})
+}
+
+func TestEjectText(t *testing.T) {
+	docker := &Docker{Dockerfile: "Dockerfile", Context: "."}
+
+	t.Run("replaces python table and keeps comments", func(t *testing.T) {
+		content := `# The app
+name = "App" # the name
+description = ""
+cover_image = ""
+exclude = [
+  "*venv", # virtual environments
+  ".git",
+]
+port = 80
+
+# How the app is built
+[python]
+# The library
+library = "streamlit"
+version = "3.12"
+app_file = "app.py"
+
+# Where the app is deployed
+[deploy]
+organization = "org" # the organization
+`
+		m := Manifest{App: App{Name: "App", Exclude: []string{"*venv", ".git"}, Port: 80}, Docker: docker, Deployment: &Deployment{OrganizationSlug: "org"}}
+
+		ejected, err := EjectText(content, &m)
+
+		require.NoError(t, err)
+		assert.Equal(t, `# The app
+name = "App" # the name
+description = ""
+cover_image = ""
+exclude = [
+  "*venv", # virtual environments
+  ".git",
+]
+port = 80
+
+# How the app is built
+[docker]
+dockerfile = "Dockerfile"
+context = "."
+
+# Where the app is deployed
+[deploy]
+organization = "org" # the organization
+`, ejected)
+	})
+
+	t.Run("adds port if manifest has no port", func(t *testing.T) {
+		content := "name = \"App\"\ndescription = \"\"\ncover_image = \"\"\nexclude = []\n\n[python]\nlibrary = \"fastapi\"\nversion = \"3.12\"\napp_file = \"app.py\"\n"
+		m := Manifest{App: App{Name: "App", Exclude: []string{}, Port: 8000}, Docker: docker}
+
+		ejected, err := EjectText(content, &m)
+
+		require.NoError(t, err)
+		assert.Equal(t, "name = \"App\"\ndescription = \"\"\ncover_image = \"\"\nexclude = []\nport = 8000\n\n[docker]\ndockerfile = \"Dockerfile\"\ncontext = \".\"\n", ejected)
+	})
+
+	t.Run("migrates deprecated format", func(t *testing.T) {
+		content := "# The app\nname = \"App\"\ndescription = \"\"\nlibrary = \"streamlit\"\npython = \"3.12\"\napp_file = \"app.py\"\nrequirements_file = \"requirements.txt\"\nport = 80\ncover_image = \"\"\nexclude = []\n"
+		m := Manifest{App: App{Name: "App", Exclude: []string{}, Port: 80}, Docker: docker}
+
+		ejected, err := EjectText(content, &m)
+
+		require.NoError(t, err)
+		assert.Equal(t, "# The app\nname = \"App\"\ndescription = \"\"\nport = 80\ncover_image = \"\"\nexclude = []\n\n[docker]\ndockerfile = \"Dockerfile\"\ncontext = \".\"\n", ejected)
+	})
+
+	t.Run("returns error if python table cannot be replaced", func(t *testing.T) {
+		content := "name = \"App\"\nport = 80\npython = { library = \"streamlit\", version = \"3.12\", app_file = \"app.py\" }\n"
+		m := Manifest{App: App{Name: "App", Port: 80}, Docker: docker}
+
+		_, err := EjectText(content, &m)
+
+		assert.ErrorIs(t, err, ErrEjectManifestText)
+	})
+}
+
+func TestPythonDockerfileInstall(t *testing.T) {
+	testCases := []struct {
+		name     string
+		python   Python
+		expected []string
+	}{
+		{
+			name:   "uv project",
+			python: Python{Library: LibraryStreamlit, ProjectFile: "pyproject.toml", LockFile: "uv.lock"},
+			expected: []string{
+				"COPY --from=ghcr.io/astral-sh/uv:latest /uv /bin/uv",
+				"COPY pyproject.toml uv.lock /app/",
+				"RUN uv sync --locked --no-install-project --no-dev",
+				`ENV PATH="/app/.venv/bin:$PATH"`,
+			},
+		},
+		{
+			name:   "poetry project",
+			python: Python{Library: LibraryStreamlit, ProjectFile: "pyproject.toml", LockFile: "poetry.lock"},
+			expected: []string{
+				"RUN pip install --no-cache-dir poetry",
+				"COPY pyproject.toml poetry.lock /app/",
+				"RUN poetry config virtualenvs.create false && poetry install --no-root --only main",
+			},
+		},
+		{
+			name:   "project without lock file",
+			python: Python{Library: LibraryStreamlit, ProjectFile: "pyproject.toml"},
+			expected: []string{
+				"COPY --from=ghcr.io/astral-sh/uv:latest /uv /bin/uv",
+				"COPY pyproject.toml /app/pyproject.toml",
+				"RUN uv pip install --system -r /app/pyproject.toml",
+			},
+		},
+		{
+			name:     "no requirements",
+			python:   Python{Library: LibraryFastAPI},
+			expected: []string{"RUN pip install --no-cache-dir fastapi uvicorn"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.python.dockerfileInstall())
+		})
+	}
+}
+
+func TestPythonDockerfileServeEnvironment(t *testing.T) {
+	p := Python{Library: LibraryGradio, Version: "3.11", AppFile: "app.py", RequirementsFile: "requirements.txt"}
+
+	dockerfile, err := p.dockerfile(7860)
+
+	require.NoError(t, err)
+	assert.Contains(t, dockerfile, "ENV GRADIO_SERVER_NAME=0.0.0.0\nENV GRADIO_SERVER_PORT=7860\nEXPOSE 7860\n")
+	assert.Contains(t, dockerfile, `CMD ["python", "app.py"]`)
+}
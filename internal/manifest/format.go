@@ -0,0 +1,75 @@
+package manifest
+
+import (
+	"github.com/BurntSushi/toml"
+)
+
+// Format identifies which version of the manifest file format a manifest is
+// written in.
+type Format int
+
+const (
+	FormatUnknown Format = iota
+	FormatV0
+	FormatV1
+	FormatCurrent
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatV0:
+		return "v0"
+	case FormatV1:
+		return "v1"
+	case FormatCurrent:
+		return "current"
+	default:
+		return "unknown"
+	}
+}
+
+// IsDeprecated returns true if the format is an older manifest format, which
+// should be migrated to the current format.
+func (f Format) IsDeprecated() bool {
+	return f == FormatV0 || f == FormatV1
+}
+
+// legacyKeys are top level keys, which only exist in the v0 and v1 formats,
+// where python app configuration was not placed in a separate table.
+var legacyKeys = []string{"library", "app_file", "requirements_file"}
+
+// DetectFormat determines the manifest format of the given TOML content, based
+// on the keys and value types it contains.
+func DetectFormat(content string) (Format, error) {
+	var values map[string]any
+	if _, err := toml.Decode(content, &values); err != nil {
+		return FormatUnknown, err
+	}
+
+	return detectFormat(values), nil
+}
+
+func detectFormat(values map[string]any) Format {
+	legacy := false
+	for _, key := range legacyKeys {
+		if _, ok := values[key]; ok {
+			legacy = true
+		}
+	}
+
+	if python, ok := values["python"]; ok {
+		if _, isString := python.(string); isString {
+			legacy = true
+		}
+	}
+
+	if !legacy {
+		return FormatCurrent
+	}
+
+	if _, isString := values["port"].(string); isString {
+		return FormatV0
+	}
+
+	return FormatV1
+}
@@ -0,0 +1,35 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectFormat(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		content  string
+		expected Format
+	}{
+		{name: "v0", content: v0TOMLStreamlit, expected: FormatV0},
+		{name: "v1", content: v1TOMLStreamlit, expected: FormatV1},
+		{name: "current python", content: tomlStreamlit, expected: FormatCurrent},
+		{name: "current docker", content: tomlDocker, expected: FormatCurrent},
+		{name: "empty", content: "", expected: FormatCurrent},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := DetectFormat(tc.content)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	t.Run("returns error for invalid TOML", func(t *testing.T) {
+		actual, err := DetectFormat("[python")
+
+		assert.ErrorContains(t, err, "toml:")
+		assert.Equal(t, FormatUnknown, actual)
+	})
+}
@@ -3,6 +3,13 @@ package manifest
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
 )
 
 const (
@@ -11,15 +18,52 @@ const (
 	marimoPort    uint = 8000
 	numerousPort  uint = 7001
 	panelPort     uint = 5006
+	gradioPort    uint = 7860
+	fastAPIPort   uint = 8000
+	flaskPort     uint = 8000
+	shinyPort     uint = 8000
 )
 
-var ErrUnsupportedLibrary = errors.New("unsupported library")
+var (
+	ErrUnsupportedLibrary       = errors.New("unsupported library")
+	ErrLibraryAlreadyRegistered = errors.New("library already registered")
+	ErrInvalidLibraryDefinition = errors.New("invalid library definition")
+)
 
 var (
-	LibraryStreamlit  = Library{Name: "Streamlit", Key: "streamlit", Port: streamlitPort, Requirements: []string{"streamlit"}}
-	LibraryPlotlyDash = Library{Name: "Plotly-dash", Key: "plotly", Port: plotyPort, Requirements: []string{"dash", "gunicorn"}}
-	LibraryMarimo     = Library{Name: "Marimo", Key: "marimo", Port: marimoPort, Requirements: []string{"marimo"}}
-	LibraryPanel      = Library{Name: "Panel", Key: "panel", Port: panelPort, Requirements: []string{"panel"}}
+	LibraryStreamlit = Library{
+		Name: "Streamlit", Key: "streamlit", Port: streamlitPort, Requirements: []string{"streamlit"},
+		ServeCommand: []string{"streamlit", "run", "{app_file}", "--server.port", "{port}", "--server.address", "0.0.0.0"},
+	}
+	LibraryPlotlyDash = Library{
+		Name: "Plotly-dash", Key: "plotly", Port: plotyPort, Requirements: []string{"dash", "gunicorn"},
+		ServeCommand: []string{"gunicorn", "--bind", "0.0.0.0:{port}", "{module}:server"},
+	}
+	LibraryMarimo = Library{
+		Name: "Marimo", Key: "marimo", Port: marimoPort, Requirements: []string{"marimo"},
+		ServeCommand: []string{"marimo", "run", "{app_file}", "--host", "0.0.0.0", "--port", "{port}"},
+	}
+	LibraryPanel = Library{
+		Name: "Panel", Key: "panel", Port: panelPort, Requirements: []string{"panel"}, AppTemplate: panelApp,
+		ServeCommand: []string{"panel", "serve", "{app_file}", "--address", "0.0.0.0", "--port", "{port}"},
+	}
+	LibraryGradio = Library{
+		Name: "Gradio", Key: "gradio", Port: gradioPort, Requirements: []string{"gradio"}, AppTemplate: gradioApp,
+		ServeCommand: []string{"python", "{app_file}"},
+		ServeEnv:     []string{"GRADIO_SERVER_NAME=0.0.0.0", "GRADIO_SERVER_PORT={port}"},
+	}
+	LibraryFastAPI = Library{
+		Name: "FastAPI", Key: "fastapi", Port: fastAPIPort, Requirements: []string{"fastapi", "uvicorn"}, AppTemplate: fastAPIApp,
+		ServeCommand: []string{"uvicorn", "{module}:app", "--host", "0.0.0.0", "--port", "{port}"},
+	}
+	LibraryFlask = Library{
+		Name: "Flask", Key: "flask", Port: flaskPort, Requirements: []string{"flask", "gunicorn"}, AppTemplate: flaskApp,
+		ServeCommand: []string{"gunicorn", "--bind", "0.0.0.0:{port}", "{module}:app"},
+	}
+	LibraryShiny = Library{
+		Name: "Shiny", Key: "shiny", Port: shinyPort, Requirements: []string{"shiny"}, AppTemplate: shinyApp,
+		ServeCommand: []string{"shiny", "run", "{app_file}", "--host", "0.0.0.0", "--port", "{port}"},
+	}
 )
 
 type Library struct {
@@ -27,6 +71,19 @@ type Library struct {
 	Key          string
 	Port         uint
 	Requirements []string
+
+	// AppTemplate is the content of the app file created when initializing an
+	// app with the library. If empty, an empty app file is created.
+	AppTemplate string
+
+	// ServeCommand is the command line which serves an app with the library.
+	// The placeholders "{app_file}", "{module}" and "{port}" are replaced by
+	// the app file, the python module of the app file, and the app port.
+	ServeCommand []string
+
+	// ServeEnv are additional environment variables for the serve command, in
+	// the form "KEY=value", with the same placeholders as ServeCommand.
+	ServeEnv []string
 }
 
 const panelApp = `"""An autogenerated example Panel app."""
@@ -40,6 +97,65 @@ pn.template.MaterialTemplate(
 ).servable()
 `
 
+const gradioApp = `"""An autogenerated example Gradio app."""
+
+import gradio as gr
+
+
+def greet(name: str) -> str:
+    return f"Hello, {name}!"
+
+
+demo = gr.Interface(fn=greet, inputs="text", outputs="text")
+
+if __name__ == "__main__":
+    demo.launch()
+`
+
+const fastAPIApp = `"""An autogenerated example FastAPI app."""
+
+from fastapi import FastAPI
+
+app = FastAPI()
+
+
+@app.get("/")
+def hello() -> dict[str, str]:
+    return {"message": "Hello, world!"}
+`
+
+const flaskApp = `"""An autogenerated example Flask app."""
+
+from flask import Flask
+
+app = Flask(__name__)
+
+
+@app.route("/")
+def hello() -> str:
+    return "<h1>Hello, world!</h1>"
+`
+
+const shinyApp = `"""An autogenerated example Shiny app."""
+
+from shiny import App, render, ui
+
+app_ui = ui.page_fluid(
+    ui.h1("Hello, world!"),
+    ui.input_text("name", "What is your name?"),
+    ui.output_text("greeting"),
+)
+
+
+def server(input, output, session):
+    @render.text
+    def greeting() -> str:
+        return f"Hello, {input.name()}!"
+
+
+app = App(app_ui, server)
+`
+
 func (l *Library) MarshalText() ([]byte, error) {
 	return []byte(l.Key), nil
 }
@@ -50,24 +166,149 @@ func (l *Library) UnmarshalText(text []byte) error {
 		return err
 	}
 
-	l.Key = parsed.Key
-	l.Name = parsed.Name
-	l.Port = parsed.Port
-	l.Requirements = parsed.Requirements
+	*l = parsed
 
 	return nil
 }
 
 func (l *Library) DefaultAppFile() string {
-	switch l.Key {
-	case "panel":
-		return panelApp
-	default:
-		return ""
+	return l.AppTemplate
+}
+
+// ServeArgs returns the command line which serves the app file with the
+// library on the given port, or nil if the library has no serve command.
+func (l *Library) ServeArgs(appFile string, port uint) []string {
+	return expandServePlaceholders(l.ServeCommand, appFile, port)
+}
+
+// ServeEnvironment returns the additional environment variables for the serve
+// command, in the form "KEY=value".
+func (l *Library) ServeEnvironment(appFile string, port uint) []string {
+	return expandServePlaceholders(l.ServeEnv, appFile, port)
+}
+
+func expandServePlaceholders(values []string, appFile string, port uint) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	module := strings.TrimSuffix(filepath.ToSlash(appFile), ".py")
+	module = strings.ReplaceAll(strings.TrimPrefix(module, "./"), "/", ".")
+	replacer := strings.NewReplacer("{app_file}", appFile, "{module}", module, "{port}", strconv.FormatUint(uint64(port), 10))
+
+	expanded := make([]string, len(values))
+	for i, value := range values {
+		expanded[i] = replacer.Replace(value)
+	}
+
+	return expanded
+}
+
+// builtinLibraries are the libraries supported without any custom library
+// definitions.
+var builtinLibraries = []Library{
+	LibraryStreamlit,
+	LibraryPlotlyDash,
+	LibraryMarimo,
+	LibraryPanel,
+	LibraryGradio,
+	LibraryFastAPI,
+	LibraryFlask,
+	LibraryShiny,
+}
+
+// SupportedLibraries contains the built-in libraries, followed by any custom
+// libraries registered with RegisterLibrary.
+var SupportedLibraries = slices.Clone(builtinLibraries)
+
+const maxLibraryPort = 65535
+
+// RegisterLibrary adds a custom library to the supported libraries, so it can
+// be used in manifests and when initializing apps.
+func RegisterLibrary(lib Library) error {
+	return registerLibraries([]Library{lib})
+}
+
+// registerLibraries adds the libraries to the supported libraries, if all of
+// them are valid. Otherwise none of them are added.
+func registerLibraries(libs []Library) error {
+	registered := slices.Clone(SupportedLibraries)
+	for _, lib := range libs {
+		if err := validateLibrary(lib, registered); err != nil {
+			return err
+		}
+		registered = append(registered, lib)
+	}
+
+	SupportedLibraries = registered
+
+	return nil
+}
+
+func validateLibrary(lib Library, registered []Library) error {
+	if lib.Key == "" || lib.Name == "" {
+		return fmt.Errorf("%w: key and name must be defined", ErrInvalidLibraryDefinition)
 	}
+
+	if lib.Key == DockerfileLibraryKey {
+		return fmt.Errorf("%w: key %q is reserved", ErrInvalidLibraryDefinition, lib.Key)
+	}
+
+	for _, existing := range registered {
+		if existing.Key == lib.Key || existing.Name == lib.Name {
+			return fmt.Errorf("%w: %s (%s)", ErrLibraryAlreadyRegistered, lib.Name, lib.Key)
+		}
+	}
+
+	if lib.Port == 0 || lib.Port > maxLibraryPort {
+		return fmt.Errorf("%w: port of %s (%s) must be between 1 and %d", ErrInvalidLibraryDefinition, lib.Name, lib.Key, maxLibraryPort)
+	}
+
+	return nil
+}
+
+type libraryDefinitions struct {
+	Libraries []libraryDefinition `toml:"library"`
 }
 
-var SupportedLibraries = []Library{LibraryStreamlit, LibraryPlotlyDash, LibraryMarimo, LibraryPanel}
+type libraryDefinition struct {
+	Key          string   `toml:"key"`
+	Name         string   `toml:"name"`
+	Port         uint     `toml:"port"`
+	Requirements []string `toml:"requirements"`
+	AppTemplate  string   `toml:"app_template"`
+	ServeCommand []string `toml:"serve_command"`
+	ServeEnv     []string `toml:"serve_env"`
+}
+
+// LoadCustomLibraries registers the custom library definitions in the TOML
+// file at the given path, defined as an array of "library" tables. It is not
+// an error if the file does not exist. If any definition is invalid, none of
+// them are registered.
+func LoadCustomLibraries(filePath string) error {
+	var defs libraryDefinitions
+	_, err := toml.DecodeFile(filePath, &defs)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	libs := make([]Library, 0, len(defs.Libraries))
+	for _, def := range defs.Libraries {
+		libs = append(libs, Library{
+			Name:         def.Name,
+			Key:          def.Key,
+			Port:         def.Port,
+			Requirements: slices.Clone(def.Requirements),
+			AppTemplate:  def.AppTemplate,
+			ServeCommand: slices.Clone(def.ServeCommand),
+			ServeEnv:     slices.Clone(def.ServeEnv),
+		})
+	}
+
+	return registerLibraries(libs)
+}
 
 func GetLibraryByKey(key string) (Library, error) {
 	for _, library := range SupportedLibraries {
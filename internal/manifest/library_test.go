@@ -1,14 +1,27 @@
 package manifest
 
 import (
+	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/BurntSushi/toml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"numerous.com/cli/internal/test"
 )
 
 func TestSupportedLibraries(t *testing.T) {
-	expected := []Library{LibraryStreamlit, LibraryPlotlyDash, LibraryMarimo, LibraryPanel}
+	expected := []Library{
+		LibraryStreamlit,
+		LibraryPlotlyDash,
+		LibraryMarimo,
+		LibraryPanel,
+		LibraryGradio,
+		LibraryFastAPI,
+		LibraryFlask,
+		LibraryShiny,
+	}
 	assert.Equal(t, expected, SupportedLibraries, "unexpected supported libraries - remember to update tests!")
 }
 
@@ -21,6 +34,10 @@ func TestGetLibraryByKey(t *testing.T) {
 		{key: "marimo", expected: LibraryMarimo},
 		{key: "plotly", expected: LibraryPlotlyDash},
 		{key: "panel", expected: LibraryPanel},
+		{key: "gradio", expected: LibraryGradio},
+		{key: "fastapi", expected: LibraryFastAPI},
+		{key: "flask", expected: LibraryFlask},
+		{key: "shiny", expected: LibraryShiny},
 	}
 
 	for _, testCase := range testCases {
@@ -47,6 +64,10 @@ func TestGetLibraryByName(t *testing.T) {
 		{name: "Marimo", expected: LibraryMarimo},
 		{name: "Plotly-dash", expected: LibraryPlotlyDash},
 		{name: "Panel", expected: LibraryPanel},
+		{name: "Gradio", expected: LibraryGradio},
+		{name: "FastAPI", expected: LibraryFastAPI},
+		{name: "Flask", expected: LibraryFlask},
+		{name: "Shiny", expected: LibraryShiny},
 	}
 
 	for _, testCase := range testCases {
@@ -69,7 +90,12 @@ func TestDefaultAppFile(t *testing.T) {
 		library  Library
 		expected string
 	}{
+		{library: LibraryStreamlit, expected: ""},
 		{library: LibraryPanel, expected: panelApp},
+		{library: LibraryGradio, expected: gradioApp},
+		{library: LibraryFastAPI, expected: fastAPIApp},
+		{library: LibraryFlask, expected: flaskApp},
+		{library: LibraryShiny, expected: shinyApp},
 	}
 
 	for _, testCase := range testCases {
@@ -96,3 +122,163 @@ func TestUnmarshalLibrary(t *testing.T) {
 		})
 	}
 }
+
+func TestServeArgs(t *testing.T) {
+	testCases := []struct {
+		library     Library
+		appFile     string
+		expectedCmd []string
+		expectedEnv []string
+	}{
+		{
+			library:     LibraryStreamlit,
+			appFile:     "app.py",
+			expectedCmd: []string{"streamlit", "run", "app.py", "--server.port", "8080", "--server.address", "0.0.0.0"},
+		},
+		{
+			library:     LibraryFastAPI,
+			appFile:     "src/api/main.py",
+			expectedCmd: []string{"uvicorn", "src.api.main:app", "--host", "0.0.0.0", "--port", "8080"},
+		},
+		{
+			library:     LibraryGradio,
+			appFile:     "app.py",
+			expectedCmd: []string{"python", "app.py"},
+			expectedEnv: []string{"GRADIO_SERVER_NAME=0.0.0.0", "GRADIO_SERVER_PORT=8080"},
+		},
+		{
+			library: Library{Name: "No Command", Key: "no-command"},
+			appFile: "app.py",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.library.Key, func(t *testing.T) {
+			assert.Equal(t, tc.expectedCmd, tc.library.ServeArgs(tc.appFile, 8080))
+			assert.Equal(t, tc.expectedEnv, tc.library.ServeEnvironment(tc.appFile, 8080))
+		})
+	}
+}
+
+func TestRegisterLibrary(t *testing.T) {
+	custom := Library{Name: "NiceGUI", Key: "nicegui", Port: 8080, Requirements: []string{"nicegui"}}
+
+	t.Run("registers custom library", func(t *testing.T) {
+		restoreSupportedLibraries(t)
+
+		err := RegisterLibrary(custom)
+
+		require.NoError(t, err)
+		actual, err := GetLibraryByKey("nicegui")
+		assert.NoError(t, err)
+		assert.Equal(t, custom, actual)
+	})
+
+	t.Run("returns error for already registered key", func(t *testing.T) {
+		restoreSupportedLibraries(t)
+
+		err := RegisterLibrary(Library{Name: "My Streamlit", Key: "streamlit"})
+
+		assert.ErrorIs(t, err, ErrLibraryAlreadyRegistered)
+	})
+
+	t.Run("returns error for missing key", func(t *testing.T) {
+		restoreSupportedLibraries(t)
+
+		err := RegisterLibrary(Library{Name: "No Key"})
+
+		assert.ErrorIs(t, err, ErrInvalidLibraryDefinition)
+	})
+
+	t.Run("returns error for dockerfile key", func(t *testing.T) {
+		restoreSupportedLibraries(t)
+
+		err := RegisterLibrary(Library{Name: "Docker", Key: DockerfileLibraryKey})
+
+		assert.ErrorIs(t, err, ErrInvalidLibraryDefinition)
+	})
+
+	t.Run("returns error for missing port", func(t *testing.T) {
+		restoreSupportedLibraries(t)
+
+		err := RegisterLibrary(Library{Name: "No Port", Key: "no-port"})
+
+		assert.ErrorIs(t, err, ErrInvalidLibraryDefinition)
+	})
+}
+
+func TestLoadCustomLibraries(t *testing.T) {
+	t.Run("registers defined libraries", func(t *testing.T) {
+		restoreSupportedLibraries(t)
+		path := filepath.Join(t.TempDir(), "libraries.toml")
+		test.WriteFile(t, path, []byte(`
+[[library]]
+key = "nicegui"
+name = "NiceGUI"
+port = 8080
+requirements = ["nicegui"]
+app_template = "from nicegui import ui\n"
+serve_command = ["python", "{app_file}"]
+`))
+
+		err := LoadCustomLibraries(path)
+
+		require.NoError(t, err)
+		expected := Library{Name: "NiceGUI", Key: "nicegui", Port: 8080, Requirements: []string{"nicegui"}, AppTemplate: "from nicegui import ui\n", ServeCommand: []string{"python", "{app_file}"}}
+		assert.Equal(t, expected, SupportedLibraries[len(SupportedLibraries)-1])
+
+		var m Manifest
+		_, err = toml.Decode("[python]\nlibrary = \"nicegui\"\napp_file = \"app.py\"\n", &m)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, m.Python.Library)
+	})
+
+	t.Run("ignores missing file", func(t *testing.T) {
+		restoreSupportedLibraries(t)
+
+		err := LoadCustomLibraries(filepath.Join(t.TempDir(), "libraries.toml"))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns error for invalid definition", func(t *testing.T) {
+		restoreSupportedLibraries(t)
+		path := filepath.Join(t.TempDir(), "libraries.toml")
+		test.WriteFile(t, path, []byte("[[library]]\nname = \"No Key\"\n"))
+
+		err := LoadCustomLibraries(path)
+
+		assert.ErrorIs(t, err, ErrInvalidLibraryDefinition)
+	})
+
+	t.Run("registers no libraries if a later definition is invalid", func(t *testing.T) {
+		restoreSupportedLibraries(t)
+		original := slices.Clone(SupportedLibraries)
+		path := filepath.Join(t.TempDir(), "libraries.toml")
+		test.WriteFile(t, path, []byte("[[library]]\nkey = \"nicegui\"\nname = \"NiceGUI\"\nport = 8080\n\n[[library]]\nkey = \"reflex\"\nname = \"Reflex\"\n"))
+
+		err := LoadCustomLibraries(path)
+
+		assert.ErrorIs(t, err, ErrInvalidLibraryDefinition)
+		assert.Equal(t, original, SupportedLibraries)
+	})
+
+	t.Run("returns error for duplicate definitions", func(t *testing.T) {
+		restoreSupportedLibraries(t)
+		path := filepath.Join(t.TempDir(), "libraries.toml")
+		test.WriteFile(t, path, []byte("[[library]]\nkey = \"nicegui\"\nname = \"NiceGUI\"\nport = 8080\n\n[[library]]\nkey = \"nicegui\"\nname = \"NiceGUI 2\"\nport = 8080\n"))
+
+		err := LoadCustomLibraries(path)
+
+		assert.ErrorIs(t, err, ErrLibraryAlreadyRegistered)
+		_, err = GetLibraryByKey("nicegui")
+		assert.ErrorIs(t, err, ErrUnsupportedLibrary)
+	})
+}
+
+func restoreSupportedLibraries(t *testing.T) {
+	t.Helper()
+
+	original := slices.Clone(SupportedLibraries)
+	t.Cleanup(func() { SupportedLibraries = original })
+}
@@ -14,9 +14,15 @@ const ManifestFileName string = "numerous.toml"
 
 type Manifest struct {
 	App
-	Python     *Python     `toml:"python,omitempty" json:"python,omitempty"`
-	Docker     *Docker     `toml:"docker,omitempty" json:"docker,omitempty"`
-	Deployment *Deployment `toml:"deploy,omitempty" json:"deploy,omitempty"`
+	Python     *Python           `toml:"python,omitempty" json:"python,omitempty"`
+	Docker     *Docker           `toml:"docker,omitempty" json:"docker,omitempty"`
+	Env        map[string]string `toml:"env,omitempty" json:"env,omitempty"`
+	Tasks      map[string]Task   `toml:"tasks,omitempty" json:"tasks,omitempty"`
+	Deployment *Deployment       `toml:"deploy,omitempty" json:"deploy,omitempty"`
+}
+
+type Task struct {
+	Command []string `toml:"command" json:"command"`
 }
 
 type Docker struct {
@@ -28,7 +34,9 @@ type Python struct {
 	Library          Library `toml:"library" json:"library"`
 	Version          string  `toml:"version" json:"version"`
 	AppFile          string  `toml:"app_file" json:"app_file"`
-	RequirementsFile string  `toml:"requirements_file" json:"requirements_file"`
+	RequirementsFile string  `toml:"requirements_file,omitempty" json:"requirements_file,omitempty"`
+	ProjectFile      string  `toml:"project_file,omitempty" json:"project_file,omitempty"`
+	LockFile         string  `toml:"lock_file,omitempty" json:"lock_file,omitempty"`
 }
 
 type App struct {
@@ -41,8 +49,13 @@ type App struct {
 }
 
 type Deployment struct {
-	OrganizationSlug string `toml:"organization" json:"organization"`
-	AppSlug          string `toml:"app" json:"app"`
+	OrganizationSlug string `toml:"organization,omitempty" json:"organization"`
+	AppSlug          string `toml:"app,omitempty" json:"app"`
+
+	// Environments are the deployment profiles defined in "deploy.<name>"
+	// tables. They are decoded by UnmarshalTOML, since they are not under a
+	// fixed key.
+	Environments map[string]DeployEnvironment `toml:"-" json:"environments,omitempty" schema:"additionalProperties"`
 }
 
 func load(filePath string) (*Manifest, error) {
@@ -57,23 +70,54 @@ func load(filePath string) (*Manifest, error) {
 }
 
 func Load(filePath string) (*Manifest, error) {
-	m, err := load(filePath)
-	if err == nil {
-		return m, nil
-	}
+	m, _, err := LoadWithFormat(filePath)
 
-	v1, v1Err := loadV1(filePath)
-	if v1Err == nil {
-		m := v1.ToManifest()
-		return &m, nil
+	return m, err
+}
+
+// LoadWithFormat loads the manifest like Load, and also returns the format the
+// manifest file is in, so callers can e.g. warn about deprecated formats.
+func LoadWithFormat(filePath string) (*Manifest, Format, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, FormatUnknown, err
 	}
 
-	v0, v0Err := loadV0(filePath)
-	if v0Err == nil {
-		return v0.ToManifest()
+	format, err := DetectFormat(string(content))
+	if err != nil {
+		return nil, FormatUnknown, err
 	}
 
-	return nil, err
+	switch format {
+	case FormatV0:
+		v0, err := loadV0(filePath)
+		if err != nil {
+			return nil, format, err
+		}
+
+		m, err := v0.ToManifest()
+
+		return m, format, err
+	case FormatV1:
+		v1, err := loadV1(filePath)
+		if err != nil {
+			return nil, format, err
+		}
+		m := v1.ToManifest()
+
+		return &m, format, nil
+	default:
+		m, err := load(filePath)
+		if err != nil {
+			return nil, format, err
+		}
+
+		if m.Python != nil && m.Python.Version == "" {
+			m.Python.Version = m.Python.ProjectPythonVersion(filepath.Dir(filePath))
+		}
+
+		return m, format, nil
+	}
 }
 
 func NewApp(name, desc string, port uint) App {
@@ -98,11 +142,29 @@ func ManifestExists(appDir string) (bool, error) {
 	return exists, err
 }
 
+// ServePort returns the port the app is served on, which is the port in the
+// manifest, or the default port of the python library, if no port is set.
+func (m *Manifest) ServePort() uint {
+	if m.Port == 0 && m.Python != nil {
+		return m.Python.Library.Port
+	}
+
+	return m.Port
+}
+
 func (m *Manifest) ToTOML() (string, error) {
 	buf := new(bytes.Buffer)
-	err := toml.NewEncoder(buf).Encode(m)
+	if err := toml.NewEncoder(buf).Encode(m); err != nil {
+		return "", err
+	}
+
+	if m.Deployment != nil && len(m.Deployment.Environments) > 0 {
+		if err := encodeDeployEnvironments(buf, m.Deployment.Environments); err != nil {
+			return "", err
+		}
+	}
 
-	return buf.String(), err
+	return buf.String(), nil
 }
 
 func (m *Manifest) ToJSON() (string, error) {
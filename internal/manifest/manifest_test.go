@@ -239,6 +239,11 @@ func TestLoad(t *testing.T) {
 				tomlContent:      "[python",
 				expectedContains: "toml:",
 			},
+			{
+				name:             "v1 with invalid value type",
+				tomlContent:      "library = \"streamlit\"\napp_file = \"app.py\"\nport = true\n",
+				expectedContains: `toml: line 3 (last key "port")`,
+			},
 		} {
 			t.Run(tc.name, func(t *testing.T) {
 				filePath := test.WriteTempFile(t, ManifestFileName, []byte(tc.tomlContent))
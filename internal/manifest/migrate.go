@@ -0,0 +1,278 @@
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"numerous.com/cli/internal/output"
+)
+
+// legacyPythonKeys maps the top level keys of the deprecated formats, to the
+// keys they are moved to in the python table of the current format.
+var legacyPythonKeys = map[string]string{
+	"library":           "library",
+	"python":            "version",
+	"app_file":          "app_file",
+	"requirements_file": "requirements_file",
+}
+
+var warnedDeprecatedPaths sync.Map
+
+// deprecationWarningOutput is where deprecation notices are written. It is
+// stderr, so that the notice does not break machine readable output.
+var deprecationWarningOutput io.Writer = os.Stderr
+
+// WarnIfDeprecated prints a deprecation notice if the manifest at the given
+// path, loaded in the given format, is in a deprecated format. The notice is
+// only printed once per path.
+func WarnIfDeprecated(filePath string, format Format) {
+	if !format.IsDeprecated() {
+		return
+	}
+
+	if _, warned := warnedDeprecatedPaths.LoadOrStore(filePath, true); warned {
+		return
+	}
+
+	output.FprintWarning(
+		deprecationWarningOutput,
+		fmt.Sprintf("The manifest %q uses the deprecated %s format.", filePath, format),
+		"Run \"numerous manifest migrate\" to upgrade it to the current format.",
+	)
+}
+
+// Migrate converts manifest TOML content in a deprecated format into the
+// current format, and returns the new content along with the detected format.
+// Content in the current format is returned unchanged.
+//
+// Comments and formatting of the original content are kept where possible.
+// If the content cannot be rewritten while keeping them, the converted
+// manifest is encoded from scratch.
+func Migrate(content string) (string, Format, error) {
+	format, err := DetectFormat(content)
+	if err != nil || !format.IsDeprecated() {
+		return content, format, err
+	}
+
+	m, err := decodeLegacy(content, format)
+	if err != nil {
+		return "", format, err
+	}
+
+	migrated, err := migrateText(content, format, m)
+	if err == nil {
+		var check Manifest
+		if _, err := toml.Decode(migrated, &check); err == nil && reflect.DeepEqual(check, *m) {
+			return migrated, format, nil
+		}
+	}
+
+	encoded, err := m.ToTOML()
+	if err != nil {
+		return "", format, err
+	}
+
+	return encoded, format, nil
+}
+
+func decodeLegacy(content string, format Format) (*Manifest, error) {
+	if format == FormatV0 {
+		var v0 ManifestV0
+		if _, err := toml.Decode(content, &v0); err != nil {
+			return nil, err
+		}
+
+		return v0.ToManifest()
+	}
+
+	var v1 ManifestV1
+	if _, err := toml.Decode(content, &v1); err != nil {
+		return nil, err
+	}
+	m := v1.ToManifest()
+
+	return &m, nil
+}
+
+var errMultilineLegacyValue = errors.New("legacy value spans multiple lines")
+
+// migrateText moves the top level python keys of a deprecated manifest into a
+// python table, placed before the first table of the manifest. Comment lines
+// directly above a moved key are moved along with it.
+func migrateText(content string, format Format, m *Manifest) (string, error) {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+
+	var top, python, pending []string
+	rest := []string{}
+	depth := 0
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case depth > 0:
+			// continuation of a multiline value
+			depth += bracketDepth(line)
+			top = append(top, line)
+		case strings.HasPrefix(trimmed, "["):
+			rest = append(pending, lines[i:]...)
+			pending = nil
+		case trimmed == "":
+			top = append(append(top, pending...), line)
+			pending = nil
+		case strings.HasPrefix(trimmed, "#"):
+			pending = append(pending, line)
+		default:
+			key, _, _ := strings.Cut(trimmed, "=")
+			key = strings.Trim(strings.TrimSpace(key), `"'`)
+			depth = bracketDepth(line)
+
+			newKey, isPython := legacyPythonKeys[key]
+			if isPython && depth != 0 {
+				return "", errMultilineLegacyValue
+			}
+
+			switch {
+			case isPython:
+				for _, comment := range pending {
+					python = append(python, "  "+strings.TrimSpace(comment))
+				}
+				python = append(python, "  "+encodeKeyValue(newKey, legacyPythonValue(key, m), trailingComment(line)))
+			case key == "port" && format == FormatV0:
+				top = append(append(top, pending...), encodeKeyValue(key, m.Port, trailingComment(line)))
+			default:
+				top = append(append(top, pending...), line)
+			}
+			pending = nil
+		}
+
+		if len(rest) > 0 {
+			break
+		}
+	}
+	top = append(top, pending...)
+
+	var b strings.Builder
+	writeLines(&b, top)
+	b.WriteString("\n[python]\n")
+	writeLines(&b, python)
+	if len(rest) > 0 {
+		b.WriteString("\n")
+		writeLines(&b, rest)
+	}
+
+	return b.String(), nil
+}
+
+func legacyPythonValue(key string, m *Manifest) string {
+	switch key {
+	case "library":
+		return m.Python.Library.Key
+	case "python":
+		return m.Python.Version
+	case "app_file":
+		return m.Python.AppFile
+	default:
+		return m.Python.RequirementsFile
+	}
+}
+
+// writeLines writes the lines, collapsing consecutive blank lines and
+// removing trailing blank lines.
+func writeLines(b *strings.Builder, lines []string) {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	previousBlank := false
+	for _, line := range lines {
+		blank := strings.TrimSpace(line) == ""
+		if blank && previousBlank {
+			continue
+		}
+		previousBlank = blank
+
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+}
+
+func encodeKeyValue(key string, value any, comment string) string {
+	buf := new(bytes.Buffer)
+	if err := toml.NewEncoder(buf).Encode(map[string]any{key: value}); err != nil {
+		return fmt.Sprintf("%s = %q%s", key, value, comment)
+	}
+
+	return strings.TrimRight(buf.String(), "\n") + comment
+}
+
+// trailingComment returns the comment at the end of the line prefixed by a
+// space, or an empty string if there is no comment.
+func trailingComment(line string) string {
+	if i := commentIndex(line); i >= 0 {
+		return " " + line[i:]
+	}
+
+	return ""
+}
+
+func commentIndex(line string) int {
+	var quote rune
+	escaped := false
+	for i, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return i
+		}
+	}
+
+	return -1
+}
+
+// bracketDepth returns the number of opened minus the number of closed square
+// brackets in the value of the line, ignoring strings and comments.
+func bracketDepth(line string) int {
+	if i := commentIndex(line); i >= 0 {
+		line = line[:i]
+	}
+
+	depth := 0
+	var quote rune
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		}
+	}
+
+	return depth
+}
@@ -0,0 +1,183 @@
+package manifest
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"numerous.com/cli/internal/test"
+)
+
+func TestMigrate(t *testing.T) {
+	t.Run("migrates deprecated formats", func(t *testing.T) {
+		for _, tc := range []struct {
+			name           string
+			content        string
+			expected       string
+			expectedFormat Format
+		}{
+			{
+				name:           "v0",
+				content:        v0TOMLStreamlit,
+				expectedFormat: FormatV0,
+				expected: `name = "Streamlit App Name"
+description = "A description"
+port = 80
+cover_image = "cover.png"
+exclude = ["*venv", "venv*"]
+
+[python]
+  library = "streamlit"
+  version = "3.11"
+  app_file = "app.py"
+  requirements_file = "requirements.txt"
+`,
+			},
+			{
+				name:           "v1 with deploy table",
+				content:        v1TOMLStreamlit,
+				expectedFormat: FormatV1,
+				expected: `name = "Streamlit App Name"
+description = "A description"
+port = 80
+cover_image = "cover.png"
+exclude = ["*venv", "venv*"]
+
+[python]
+  library = "streamlit"
+  version = "3.11"
+  app_file = "app.py"
+  requirements_file = "requirements.txt"
+
+[deploy]
+  organization = "organization-slug"
+  app = "app-slug"
+`,
+			},
+			{
+				name: "v1 with comments and multiline array",
+				content: `# My app
+name = "My App"
+
+# The app library
+library = "marimo" # comment after library
+app_file = "app.py"
+port = 8000
+exclude = [
+  "*venv", # virtual environments
+  ".git",
+]
+
+# Where to deploy
+[deploy]
+organization = "org" # the organization
+app = "my-app"
+`,
+				expectedFormat: FormatV1,
+				expected: `# My app
+name = "My App"
+
+port = 8000
+exclude = [
+  "*venv", # virtual environments
+  ".git",
+]
+
+[python]
+  # The app library
+  library = "marimo" # comment after library
+  app_file = "app.py"
+
+# Where to deploy
+[deploy]
+organization = "org" # the organization
+app = "my-app"
+`,
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				actual, format, err := Migrate(tc.content)
+
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedFormat, format)
+				assert.Equal(t, tc.expected, actual)
+				migratedFormat, err := DetectFormat(actual)
+				require.NoError(t, err)
+				assert.Equal(t, FormatCurrent, migratedFormat)
+			})
+		}
+	})
+
+	t.Run("returns current format content unchanged", func(t *testing.T) {
+		actual, format, err := Migrate(tomlStreamlit)
+
+		require.NoError(t, err)
+		assert.Equal(t, FormatCurrent, format)
+		assert.Equal(t, tomlStreamlit, actual)
+	})
+
+	t.Run("encodes converted manifest if text cannot be migrated", func(t *testing.T) {
+		content := "name = \"app\"\nlibrary = \"streamlit\"\napp_file = \"\"\"\napp.py\"\"\"\nport = 80\n"
+
+		actual, format, err := Migrate(content)
+
+		require.NoError(t, err)
+		assert.Equal(t, FormatV1, format)
+		var expected Manifest
+		_, err = toml.Decode(actual, &expected)
+		require.NoError(t, err)
+		assert.Equal(t, "app.py", expected.Python.AppFile)
+	})
+
+	t.Run("returns error for invalid legacy manifest", func(t *testing.T) {
+		_, _, err := Migrate("library = \"streamlit\"\nport = \"eighty\"\n")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWarnIfDeprecated(t *testing.T) {
+	patchDeprecationWarningOutput := func(t *testing.T) *bytes.Buffer {
+		t.Helper()
+
+		buf := new(bytes.Buffer)
+		original := deprecationWarningOutput
+		t.Cleanup(func() { deprecationWarningOutput = original })
+		deprecationWarningOutput = buf
+
+		return buf
+	}
+
+	t.Run("warns once for deprecated format", func(t *testing.T) {
+		out := patchDeprecationWarningOutput(t)
+		filePath := test.WriteTempFile(t, ManifestFileName, []byte(v1TOMLStreamlit))
+		_, format, err := LoadWithFormat(filePath)
+		require.NoError(t, err)
+
+		stdout := test.RunWithPatchedStdout(t, func() {
+			WarnIfDeprecated(filePath, format)
+			WarnIfDeprecated(filePath, format)
+		})
+
+		stdoutContent, err := io.ReadAll(stdout)
+		require.NoError(t, err)
+		assert.Empty(t, string(stdoutContent))
+		assert.Equal(t, 1, strings.Count(out.String(), "uses the deprecated v1 format"))
+	})
+
+	t.Run("does not warn for current format", func(t *testing.T) {
+		out := patchDeprecationWarningOutput(t)
+		filePath := test.WriteTempFile(t, ManifestFileName, []byte(tomlStreamlit))
+		_, format, err := LoadWithFormat(filePath)
+		require.NoError(t, err)
+
+		WarnIfDeprecated(filePath, format)
+
+		assert.Empty(t, out.String())
+	})
+}
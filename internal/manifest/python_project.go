@@ -0,0 +1,101 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"numerous.com/cli/internal/pyproject"
+	"numerous.com/cli/internal/requirements"
+)
+
+const (
+	UVLockFileName     = "uv.lock"
+	PoetryLockFileName = "poetry.lock"
+)
+
+var ErrMissingLibraryDependency = errors.New("library dependency missing")
+
+// DetectPythonProject returns the project file and lock file of a uv or
+// Poetry project in the app directory. Empty strings are returned for files,
+// which do not exist. A project file without a [project] or
+// [tool.poetry.dependencies] table, e.g. with only tool configuration, is not
+// considered a project, so a requirements file is used instead.
+func DetectPythonProject(appDir string) (string, string) {
+	project, err := readFile(filepath.Join(appDir, pyproject.FileName), pyproject.Read)
+	if err != nil || !project.HasDependencyTable() {
+		return "", ""
+	}
+
+	projectFile := pyproject.FileName
+	lockFile := ""
+	for _, name := range []string{UVLockFileName, PoetryLockFileName} {
+		if exists, _ := fileExists(filepath.Join(appDir, name)); exists {
+			lockFile = name
+			break
+		}
+	}
+
+	return projectFile, lockFile
+}
+
+// ProjectPythonVersion returns the minimum python version allowed by the
+// project file, or an empty string if it is not defined.
+func (p Python) ProjectPythonVersion(basePath string) string {
+	if p.ProjectFile == "" {
+		return ""
+	}
+
+	project, err := readFile(filepath.Join(basePath, p.ProjectFile), pyproject.Read)
+	if err != nil {
+		return ""
+	}
+
+	return project.PythonVersion()
+}
+
+// CheckLibraryDependency returns an error wrapping
+// ErrMissingLibraryDependency, if the package of the library is not a
+// dependency in the project file, or in the requirements file.
+func (p Python) CheckLibraryDependency(basePath string) error {
+	if len(p.Library.Requirements) == 0 {
+		return nil
+	}
+	dependency := p.Library.Requirements[0]
+
+	switch {
+	case p.ProjectFile != "":
+		project, err := readFile(filepath.Join(basePath, p.ProjectFile), pyproject.Read)
+		if err != nil {
+			return err
+		}
+
+		if !project.HasDependency(dependency) {
+			return fmt.Errorf("%w: %q is not a dependency in %q", ErrMissingLibraryDependency, dependency, p.ProjectFile)
+		}
+	case p.RequirementsFile != "":
+		req, err := readFile(filepath.Join(basePath, p.RequirementsFile), requirements.Read)
+		if err != nil {
+			return err
+		}
+
+		if !req.Has(dependency) {
+			return fmt.Errorf("%w: %q is not a requirement in %q", ErrMissingLibraryDependency, dependency, p.RequirementsFile)
+		}
+	}
+
+	return nil
+}
+
+func readFile[T any](path string, read func(io.Reader) (T, error)) (T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	defer f.Close()
+
+	return read(f)
+}
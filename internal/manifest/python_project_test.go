@@ -0,0 +1,179 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"numerous.com/cli/internal/test"
+)
+
+func TestDetectPythonProject(t *testing.T) {
+	const (
+		project       = "[project]\nname = \"app\"\n"
+		poetryProject = "[tool.poetry.dependencies]\npython = \"^3.11\"\n"
+		toolsOnly     = "[tool.black]\nline-length = 88\n"
+	)
+
+	for _, tc := range []struct {
+		name            string
+		files           map[string]string
+		expectedProject string
+		expectedLock    string
+	}{
+		{name: "no project", files: map[string]string{"requirements.txt": ""}},
+		{name: "project without lock file", files: map[string]string{"pyproject.toml": project}, expectedProject: "pyproject.toml"},
+		{name: "uv project", files: map[string]string{"pyproject.toml": project, "uv.lock": ""}, expectedProject: "pyproject.toml", expectedLock: "uv.lock"},
+		{name: "poetry project", files: map[string]string{"pyproject.toml": poetryProject, "poetry.lock": ""}, expectedProject: "pyproject.toml", expectedLock: "poetry.lock"},
+		{name: "project file with only tool configuration", files: map[string]string{"pyproject.toml": toolsOnly, "uv.lock": ""}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			appDir := t.TempDir()
+			for name, content := range tc.files {
+				test.WriteFile(t, filepath.Join(appDir, name), []byte(content))
+			}
+
+			project, lock := DetectPythonProject(appDir)
+
+			assert.Equal(t, tc.expectedProject, project)
+			assert.Equal(t, tc.expectedLock, lock)
+		})
+	}
+}
+
+func TestCheckLibraryDependency(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		python   Python
+		file     string
+		content  string
+		expected error
+	}{
+		{
+			name:    "dependency in project file",
+			python:  Python{Library: LibraryPlotlyDash, ProjectFile: "pyproject.toml"},
+			file:    "pyproject.toml",
+			content: "[project]\ndependencies = [\"Dash>=2\"]\n",
+		},
+		{
+			name:     "dependency missing in project file",
+			python:   Python{Library: LibraryPlotlyDash, ProjectFile: "pyproject.toml"},
+			file:     "pyproject.toml",
+			content:  "[project]\ndependencies = [\"gunicorn\"]\n",
+			expected: ErrMissingLibraryDependency,
+		},
+		{
+			name:    "dependency in poetry project file",
+			python:  Python{Library: LibraryStreamlit, ProjectFile: "pyproject.toml"},
+			file:    "pyproject.toml",
+			content: "[tool.poetry.dependencies]\npython = \"^3.11\"\nstreamlit = \"^1.30\"\n",
+		},
+		{
+			name:    "dependency in requirements file",
+			python:  Python{Library: LibraryStreamlit, RequirementsFile: "requirements.txt"},
+			file:    "requirements.txt",
+			content: "streamlit==1.30\n",
+		},
+		{
+			name:     "dependency missing in requirements file",
+			python:   Python{Library: LibraryStreamlit, RequirementsFile: "requirements.txt"},
+			file:     "requirements.txt",
+			content:  "pandas\n",
+			expected: ErrMissingLibraryDependency,
+		},
+		{
+			name:     "missing project file",
+			python:   Python{Library: LibraryStreamlit, ProjectFile: "pyproject.toml"},
+			expected: os.ErrNotExist,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			appDir := t.TempDir()
+			if tc.file != "" {
+				test.WriteFile(t, filepath.Join(appDir, tc.file), []byte(tc.content))
+			}
+
+			err := tc.python.CheckLibraryDependency(appDir)
+
+			if tc.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.expected)
+			}
+		})
+	}
+}
+
+const tomlStreamlitProject string = `name = "Streamlit App Name"
+port = 80
+
+[python]
+library = "streamlit"
+app_file = "app.py"
+project_file = "pyproject.toml"
+lock_file = "uv.lock"
+`
+
+func TestPythonProjectManifest(t *testing.T) {
+	t.Run("loads version from project file requires-python", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.WriteFile(t, filepath.Join(appDir, ManifestFileName), []byte(tomlStreamlitProject))
+		test.WriteFile(t, filepath.Join(appDir, "pyproject.toml"), []byte("[project]\nrequires-python = \">=3.12\"\ndependencies = [\"streamlit\"]\n"))
+
+		m, err := Load(filepath.Join(appDir, ManifestFileName))
+
+		require.NoError(t, err)
+		assert.Equal(t, Python{Library: LibraryStreamlit, Version: "3.12", AppFile: "app.py", ProjectFile: "pyproject.toml", LockFile: "uv.lock"}, *m.Python)
+	})
+
+	t.Run("validation reports missing library dependency", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.WriteFile(t, filepath.Join(appDir, ManifestFileName), []byte(tomlStreamlitProject))
+		test.WriteFile(t, filepath.Join(appDir, "pyproject.toml"), []byte("[project]\ndependencies = [\"pandas\"]\n"))
+
+		result, err := Validate(filepath.Join(appDir, ManifestFileName))
+
+		require.NoError(t, err)
+		expected := []ValidationIssue{{Line: 7, Key: "python.project_file", Message: `"streamlit" is not a dependency in "pyproject.toml"`}}
+		assert.Equal(t, expected, result.Issues)
+	})
+
+	t.Run("validation reports missing project file", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.WriteFile(t, filepath.Join(appDir, ManifestFileName), []byte(tomlStreamlitProject))
+
+		result, err := Validate(filepath.Join(appDir, ManifestFileName))
+
+		require.NoError(t, err)
+		expected := []ValidationIssue{{Line: 7, Key: "python.project_file", Message: `project file "pyproject.toml" does not exist`}}
+		assert.Equal(t, expected, result.Issues)
+	})
+
+	t.Run("validation reports missing requirement in deprecated format", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.WriteFile(t, filepath.Join(appDir, ManifestFileName), []byte(v1TOMLStreamlit))
+		test.WriteFile(t, filepath.Join(appDir, "requirements.txt"), []byte("pandas\n"))
+
+		result, err := Validate(filepath.Join(appDir, ManifestFileName))
+
+		require.NoError(t, err)
+		if assert.Len(t, result.Issues, 1) {
+			assert.Equal(t, "requirements_file", result.Issues[0].Key)
+			assert.Equal(t, `"streamlit" is not a requirement in "requirements.txt"`, result.Issues[0].Message)
+		}
+	})
+
+	t.Run("bootstrap adds library requirements to project file", func(t *testing.T) {
+		appDir := t.TempDir()
+		test.WriteFile(t, filepath.Join(appDir, "pyproject.toml"), []byte("[project]\nname = \"app\" # the name\ndependencies = [\"pandas\"]\n"))
+		p := Python{Library: LibraryPlotlyDash, AppFile: "app.py", ProjectFile: "pyproject.toml"}
+
+		err := p.bootstrapFiles(appDir)
+
+		require.NoError(t, err)
+		test.AssertFileContent(t, filepath.Join(appDir, "pyproject.toml"), []byte("[project]\nname = \"app\" # the name\ndependencies = [\"pandas\", \"dash\", \"gunicorn\"]\n"))
+		assert.NoFileExists(t, filepath.Join(appDir, "requirements.txt"))
+	})
+}
@@ -0,0 +1,198 @@
+package manifest
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is a subset of the JSON Schema specification, sufficient for
+// describing the manifest structure.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Minimum              *int                   `json:"minimum,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties any                    `json:"additionalProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	OneOf                []*JSONSchema          `json:"oneOf,omitempty"`
+}
+
+// schemaAnnotations contains the information about a manifest format, which
+// cannot be derived from the Go types.
+type schemaAnnotations struct {
+	title        string
+	descriptions map[string]string
+	required     map[string][]string
+	oneOf        map[string][][]string
+}
+
+var manifestAnnotations = schemaAnnotations{
+	title: "Numerous app manifest",
+	descriptions: map[string]string{
+		"":                         "The manifest of a Numerous app, stored in numerous.toml in the app directory.",
+		"name":                     "The name of the app.",
+		"description":              "A short description of the app.",
+		"cover_image":              "Path to an image file, relative to the app directory, used as the cover image of the app.",
+		"exclude":                  "Glob patterns of files in the app directory, which are not uploaded when deploying.",
+		"port":                     "The port the app listens on.",
+		"size":                     "The size of the app deployment.",
+		"python":                   "Configuration of an app using a supported python app library.",
+		"python.library":           "The python library the app is built with.",
+		"python.version":           "The python version used to run the app. If not defined, the minimum version allowed by requires-python in the project file is used.",
+		"python.app_file":          "Path to the app entrypoint file, relative to the app directory.",
+		"python.requirements_file": "Path to the requirements file, relative to the app directory.",
+		"python.project_file":      "Path to the pyproject.toml file of a uv or Poetry project, relative to the app directory. Used instead of a requirements file.",
+		"python.lock_file":         "Path to the uv.lock or poetry.lock file of the project, relative to the app directory.",
+		"docker":                   "Configuration of an app built from a Dockerfile.",
+		"docker.dockerfile":        "Path to the Dockerfile, relative to the app directory.",
+		"docker.context":           "Path to the docker build context, relative to the app directory.",
+		"env":                      "Environment variables, which are not secret, passed to the app when it is deployed. Secrets are read from the .env file.",
+		"env.*":                    "The value of the environment variable.",
+		"tasks":                    "Tasks of the app, which can be run locally with numerous task run --local.",
+		"tasks.*":                  "A task, named by its key.",
+		"tasks.*.command":          "The command running the task in the app directory, as a list of the program and its arguments.",
+		"deploy":                   "The default deployment of the app, used when no app identifier is given to commands.",
+		"deploy.organization":      "The slug of the organization the app is deployed to.",
+		"deploy.app":               "The slug of the app in the organization.",
+		"deploy.*":                 "A deploy environment, selected with the --env flag, which overrides the default deployment.",
+		"deploy.*.organization":    "The slug of the organization the app is deployed to in this environment.",
+		"deploy.*.app":             "The slug of the app in this environment.",
+		"deploy.*.size":            "The size of the app deployment in this environment.",
+		"deploy.*.env_file":        "Path to the file with secrets for this environment, relative to the app directory.",
+	},
+	required: map[string][]string{
+		"python":  {"library", "app_file"},
+		"docker":  {"dockerfile"},
+		"tasks.*": {"command"},
+	},
+	oneOf: map[string][][]string{
+		"": {{"python"}, {"docker"}},
+	},
+}
+
+var libraryType = reflect.TypeOf(Library{})
+
+// ManifestJSONSchema returns the JSON schema describing the current manifest
+// format.
+func ManifestJSONSchema() *JSONSchema {
+	s := typeSchema(reflect.TypeOf(Manifest{}), "", manifestAnnotations)
+	s.Schema = jsonSchemaDialect
+	s.Title = manifestAnnotations.title
+
+	return s
+}
+
+// MarshalManifestJSONSchema returns the indented JSON representation of the
+// manifest JSON schema.
+func MarshalManifestJSONSchema() ([]byte, error) {
+	data, err := json.MarshalIndent(ManifestJSONSchema(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+func formatJSONSchema(f Format) *JSONSchema {
+	switch f {
+	case FormatV0:
+		return typeSchema(reflect.TypeOf(ManifestV0{}), "", schemaAnnotations{})
+	case FormatV1:
+		return typeSchema(reflect.TypeOf(ManifestV1{}), "", schemaAnnotations{})
+	default:
+		return ManifestJSONSchema()
+	}
+}
+
+func typeSchema(t reflect.Type, path string, annotations schemaAnnotations) *JSONSchema {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	s := &JSONSchema{Description: annotations.descriptions[path]}
+	if t == libraryType {
+		s.Type = "string"
+		// only the built-in libraries, so the schema does not depend on the
+		// custom library definitions of the user
+		for _, l := range builtinLibraries {
+			s.Enum = append(s.Enum, l.Key)
+		}
+
+		return s
+	}
+
+	switch t.Kind() { // nolint:exhaustive
+	case reflect.String:
+		s.Type = "string"
+	case reflect.Bool:
+		s.Type = "boolean"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := 0
+		s.Type = "integer"
+		s.Minimum = &zero
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s.Type = "integer"
+	case reflect.Float32, reflect.Float64:
+		s.Type = "number"
+	case reflect.Slice, reflect.Array:
+		s.Type = "array"
+		s.Items = typeSchema(t.Elem(), path, schemaAnnotations{})
+	case reflect.Map:
+		s.Type = "object"
+		s.AdditionalProperties = typeSchema(t.Elem(), joinSchemaPath(path, "*"), annotations)
+	case reflect.Struct:
+		s.Type = "object"
+		s.Properties = map[string]*JSONSchema{}
+		s.AdditionalProperties = false
+		addStructProperties(s, t, path, annotations)
+		s.Required = annotations.required[path]
+		for _, required := range annotations.oneOf[path] {
+			s.OneOf = append(s.OneOf, &JSONSchema{Required: required})
+		}
+	}
+
+	return s
+}
+
+func addStructProperties(s *JSONSchema, t reflect.Type, path string, annotations schemaAnnotations) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("toml"), ",")
+
+		if field.Anonymous && name == "" {
+			addStructProperties(s, field.Type, path, annotations)
+			continue
+		}
+
+		// map fields decoded from the keys not defined by other fields
+		if field.Tag.Get("schema") == "additionalProperties" {
+			s.AdditionalProperties = typeSchema(field.Type.Elem(), joinSchemaPath(path, "*"), annotations)
+			continue
+		}
+
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		s.Properties[name] = typeSchema(field.Type, joinSchemaPath(path, name), annotations)
+	}
+}
+
+func joinSchemaPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
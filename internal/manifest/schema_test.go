@@ -0,0 +1,57 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// schemaFilePath is resolved relative to this source file, since other tests
+// in the package change the working directory.
+func schemaFilePath(t *testing.T) string {
+	t.Helper()
+
+	_, file, _, ok := runtime.Caller(0)
+	require.True(t, ok)
+
+	return filepath.Join(filepath.Dir(file), "..", "..", "shared", "numerous.toml.schema.json")
+}
+
+func TestManifestJSONSchema(t *testing.T) {
+	t.Run("checked in schema is up to date", func(t *testing.T) {
+		expected, err := MarshalManifestJSONSchema()
+		require.NoError(t, err)
+
+		actual, err := os.ReadFile(schemaFilePath(t))
+		require.NoError(t, err)
+
+		assert.Equal(t, string(expected), string(actual), "regenerate the schema with: go run . manifest schema > shared/numerous.toml.schema.json")
+	})
+
+	t.Run("describes manifest structure", func(t *testing.T) {
+		schema := ManifestJSONSchema()
+
+		assert.Equal(t, jsonSchemaDialect, schema.Schema)
+		assert.Equal(t, false, schema.AdditionalProperties)
+		assert.Equal(t, "integer", schema.Properties["port"].Type)
+		assert.Equal(t, "array", schema.Properties["exclude"].Type)
+		assert.Equal(t, "string", schema.Properties["exclude"].Items.Type)
+		assert.Equal(t, []string{"streamlit", "plotly", "marimo", "panel", "gradio", "fastapi", "flask", "shiny"}, schema.Properties["python"].Properties["library"].Enum)
+		assert.Equal(t, []string{"library", "app_file"}, schema.Properties["python"].Required)
+		assert.Contains(t, schema.Properties, "deploy")
+		assert.Contains(t, schema.Properties["docker"].Properties, "context")
+	})
+
+	t.Run("does not include custom libraries", func(t *testing.T) {
+		restoreSupportedLibraries(t)
+		require.NoError(t, RegisterLibrary(Library{Name: "NiceGUI", Key: "nicegui", Port: 8080}))
+
+		schema := ManifestJSONSchema()
+
+		assert.NotContains(t, schema.Properties["python"].Properties["library"].Enum, "nicegui")
+	})
+}
@@ -0,0 +1,492 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ValidationIssue describes a single problem found in a manifest. Line is the
+// 1-based line number in the manifest file, or 0 if it is not known.
+type ValidationIssue struct {
+	Line    int
+	Key     string
+	Message string
+}
+
+type ValidationResult struct {
+	Format Format
+	Issues []ValidationIssue
+}
+
+func (r ValidationResult) Valid() bool {
+	return len(r.Issues) == 0
+}
+
+var (
+	decodeErrorWithKeyRegexp = regexp.MustCompile(`^toml: line (\d+) \(last key "([^"]*)"\): (.*)$`)
+	decodeErrorRegexp        = regexp.MustCompile(`^toml: line (\d+): (.*)$`)
+)
+
+// Validate validates the manifest at the given path against the schema of the
+// detected manifest format. Problems with the manifest are returned as issues
+// in the result, while an error is returned if the file cannot be read.
+func Validate(filePath string) (ValidationResult, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return ValidationResult{}, err
+	}
+
+	result := validate(string(content))
+	if result.Valid() {
+		result.Issues = validateDependencies(filePath, string(content))
+	}
+
+	return result, nil
+}
+
+// validateDependencies checks that the library of a python app is a
+// dependency in its project or requirements file. A missing requirements
+// file is not reported, since it is created when deploying, if it does not
+// exist.
+func validateDependencies(filePath string, content string) []ValidationIssue {
+	m, err := Load(filePath)
+	if err != nil || m.Python == nil {
+		return nil
+	}
+
+	key := toml.Key{"python", "requirements_file"}
+	if m.Python.ProjectFile != "" {
+		key = toml.Key{"python", "project_file"}
+	}
+
+	v := validator{lines: keyLines(content)}
+	if _, ok := v.lines[strings.Join(key, ".")]; !ok {
+		// deprecated formats define the requirements file at the top level
+		key = key[1:]
+	}
+
+	err = m.Python.CheckLibraryDependency(filepath.Dir(filePath))
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrMissingLibraryDependency):
+		v.addIssue(key, strings.TrimPrefix(err.Error(), ErrMissingLibraryDependency.Error()+": "))
+	case errors.Is(err, os.ErrNotExist) && m.Python.ProjectFile != "":
+		v.addIssue(key, fmt.Sprintf("project file %q does not exist", m.Python.ProjectFile))
+	case errors.Is(err, os.ErrNotExist):
+	default:
+		v.addIssue(key, err.Error())
+	}
+
+	return v.issues
+}
+
+func validate(content string) ValidationResult {
+	var values map[string]any
+
+	md, err := toml.Decode(content, &values)
+	if err != nil {
+		return ValidationResult{Format: FormatUnknown, Issues: []ValidationIssue{decodeErrorIssue(err)}}
+	}
+
+	v := validator{
+		md:     md,
+		values: values,
+		lines:  keyLines(content),
+		format: detectFormat(values),
+	}
+	v.validate()
+
+	if len(v.issues) == 0 {
+		v.validateDecoding(content)
+	}
+
+	slices.SortStableFunc(v.issues, func(a, b ValidationIssue) int {
+		switch {
+		case a.Line == b.Line:
+			return 0
+		case a.Line == 0:
+			return 1
+		case b.Line == 0:
+			return -1
+		default:
+			return a.Line - b.Line
+		}
+	})
+
+	return ValidationResult{Format: v.format, Issues: v.issues}
+}
+
+type validator struct {
+	md     toml.MetaData
+	values map[string]any
+	lines  map[string]int
+	format Format
+	issues []ValidationIssue
+}
+
+func (v *validator) validate() {
+	schema := formatJSONSchema(v.format)
+
+	var unknown []toml.Key
+	for _, key := range v.md.Keys() {
+		if slices.ContainsFunc(unknown, func(u toml.Key) bool { return hasKeyPrefix(key, u) }) {
+			continue
+		}
+
+		parent, node, additional := resolveSchema(schema, key)
+		if node == nil {
+			unknown = append(unknown, key)
+			v.addIssue(key, unknownKeyMessage(parent, key[len(key)-1]))
+
+			continue
+		}
+
+		value, ok := lookupValue(v.values, key)
+		if !ok {
+			continue
+		}
+
+		msg := checkValue(node, value)
+		switch {
+		case msg == "":
+		case additional && node.Type == "object":
+			// a value which is not a property, where only additional tables
+			// are allowed, is most likely a typo of a property name
+			unknown = append(unknown, key)
+			v.addIssue(key, unknownKeyMessage(parent, key[len(key)-1]))
+		default:
+			v.addIssue(key, msg)
+		}
+	}
+
+	v.validateRequired(schema, v.values, nil)
+}
+
+func (v *validator) validateRequired(schema *JSONSchema, values map[string]any, key toml.Key) {
+	for _, required := range schema.Required {
+		if _, ok := values[required]; !ok {
+			v.addIssue(key, fmt.Sprintf("missing required key %q", required))
+		}
+	}
+
+	if len(schema.OneOf) > 0 {
+		v.validateOneOf(schema.OneOf, values, key)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+		property := schema.Properties[name]
+		if property.Type != "object" {
+			continue
+		}
+
+		if child, ok := values[name].(map[string]any); ok {
+			v.validateRequired(property, child, append(slices.Clone(key), name))
+		}
+	}
+
+	additional, ok := schema.AdditionalProperties.(*JSONSchema)
+	if !ok || additional.Type != "object" {
+		return
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if _, isProperty := schema.Properties[name]; isProperty {
+			continue
+		}
+
+		if child, ok := values[name].(map[string]any); ok {
+			v.validateRequired(additional, child, append(slices.Clone(key), name))
+		}
+	}
+}
+
+func (v *validator) validateOneOf(alternatives []*JSONSchema, values map[string]any, key toml.Key) {
+	names := []string{}
+	satisfied := 0
+	for _, alternative := range alternatives {
+		names = append(names, fmt.Sprintf("%q", strings.Join(alternative.Required, ", ")))
+		if slices.ContainsFunc(alternative.Required, func(r string) bool { _, ok := values[r]; return !ok }) {
+			continue
+		}
+		satisfied++
+	}
+
+	joined := strings.Join(names, " or ")
+	switch {
+	case satisfied == 0:
+		v.addIssue(key, "one of "+joined+" must be defined")
+	case satisfied > 1:
+		v.addIssue(key, "only one of "+joined+" may be defined")
+	}
+}
+
+// validateDecoding decodes the manifest into the structure of the detected
+// format, to catch any problems not described by the schema.
+func (v *validator) validateDecoding(content string) {
+	switch v.format {
+	case FormatV0:
+		var m ManifestV0
+		if _, err := toml.Decode(content, &m); err != nil {
+			v.issues = append(v.issues, decodeErrorIssue(err))
+		} else if _, err := m.ToManifest(); err != nil {
+			v.addIssue(toml.Key{"port"}, fmt.Sprintf("port %q is not a valid port number", m.Port))
+		}
+	case FormatV1:
+		var m ManifestV1
+		if _, err := toml.Decode(content, &m); err != nil {
+			v.issues = append(v.issues, decodeErrorIssue(err))
+		}
+	default:
+		var m Manifest
+		if _, err := toml.Decode(content, &m); err != nil {
+			v.issues = append(v.issues, decodeErrorIssue(err))
+		}
+	}
+}
+
+func (v *validator) addIssue(key toml.Key, message string) {
+	v.issues = append(v.issues, ValidationIssue{
+		Line:    v.keyLine(key),
+		Key:     strings.Join(key, "."),
+		Message: message,
+	})
+}
+
+// keyLine returns the line of the key, or of its closest parent table, if
+// the key itself is not found.
+func (v *validator) keyLine(key toml.Key) int {
+	for i := len(key); i > 0; i-- {
+		if line, ok := v.lines[strings.Join(key[:i], ".")]; ok {
+			return line
+		}
+	}
+
+	return 0
+}
+
+func decodeErrorIssue(err error) ValidationIssue {
+	var parseErr toml.ParseError
+	if errors.As(err, &parseErr) {
+		return ValidationIssue{Line: parseErr.Position.Line, Key: parseErr.LastKey, Message: parseErr.Message}
+	}
+
+	msg := err.Error()
+	if m := decodeErrorWithKeyRegexp.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return ValidationIssue{Line: line, Key: m[2], Message: m[3]}
+	}
+
+	if m := decodeErrorRegexp.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return ValidationIssue{Line: line, Message: m[2]}
+	}
+
+	return ValidationIssue{Message: msg}
+}
+
+// resolveSchema returns the schema of the given key, and the schema of its
+// parent. If the key is not defined in the schema, the returned key schema is
+// nil. The returned boolean is true if the key is matched by the additional
+// properties schema of its parent, rather than a named property.
+func resolveSchema(schema *JSONSchema, key toml.Key) (*JSONSchema, *JSONSchema, bool) {
+	parent, node := schema, schema
+	additional := false
+	for _, part := range key {
+		if node.Type == "array" && node.Items != nil {
+			node = node.Items
+		}
+
+		parent = node
+		if property, ok := node.Properties[part]; ok {
+			node = property
+			additional = false
+		} else if additionalSchema, ok := node.AdditionalProperties.(*JSONSchema); ok {
+			node = additionalSchema
+			additional = true
+		} else {
+			return parent, nil, false
+		}
+	}
+
+	return parent, node, additional
+}
+
+func unknownKeyMessage(parent *JSONSchema, name string) string {
+	normalized := normalizeKey(name)
+	for property := range parent.Properties {
+		if normalizeKey(property) == normalized {
+			return fmt.Sprintf("unknown key %q, did you mean %q?", name, property)
+		}
+	}
+
+	return fmt.Sprintf("unknown key %q", name)
+}
+
+func normalizeKey(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "-", "_")
+}
+
+func checkValue(schema *JSONSchema, value any) string {
+	tomlType := valueTypeName(value)
+	switch schema.Type {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return "must be a string, not " + tomlType
+		}
+
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, s) {
+			return fmt.Sprintf("unsupported value %q, must be one of: %s", s, strings.Join(schema.Enum, ", "))
+		}
+	case "integer":
+		i, ok := value.(int64)
+		if !ok {
+			return "must be an integer, not " + tomlType
+		}
+
+		if schema.Minimum != nil && i < int64(*schema.Minimum) {
+			return fmt.Sprintf("must be at least %d", *schema.Minimum)
+		}
+	case "number":
+		switch value.(type) {
+		case int64, float64:
+		default:
+			return "must be a number, not " + tomlType
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return "must be a boolean, not " + tomlType
+		}
+	case "array":
+		return checkArray(schema, value)
+	case "object":
+		if _, ok := value.(map[string]any); !ok {
+			return "must be a table, not " + tomlType
+		}
+	}
+
+	return ""
+}
+
+func checkArray(schema *JSONSchema, value any) string {
+	items, ok := value.([]any)
+	if !ok {
+		if _, isTables := value.([]map[string]any); isTables && schema.Items != nil && schema.Items.Type == "object" {
+			return ""
+		}
+
+		return "must be an array, not " + valueTypeName(value)
+	}
+
+	if schema.Items == nil {
+		return ""
+	}
+
+	for i, item := range items {
+		if msg := checkValue(schema.Items, item); msg != "" {
+			return fmt.Sprintf("element %d %s", i+1, msg)
+		}
+	}
+
+	return ""
+}
+
+func valueTypeName(value any) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case int64:
+		return "integer"
+	case float64:
+		return "float"
+	case bool:
+		return "boolean"
+	case []any, []map[string]any:
+		return "array"
+	case map[string]any:
+		return "table"
+	default:
+		return "datetime"
+	}
+}
+
+func lookupValue(values map[string]any, key toml.Key) (any, bool) {
+	var current any = values
+	for _, part := range key {
+		table, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		current, ok = table[part]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+func hasKeyPrefix(key toml.Key, prefix toml.Key) bool {
+	return len(key) >= len(prefix) && slices.Equal(key[:len(prefix)], prefix)
+}
+
+// keyLines returns a map from dotted key names to the line they are first
+// defined on in the given TOML content. The TOML decoder does not expose
+// key positions, so this is a best effort scan, which handles table headers,
+// and simple and dotted keys.
+func keyLines(content string) map[string]int {
+	lines := map[string]int{}
+	var table []string
+
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "["):
+			header := strings.TrimLeft(line, "[")
+			header, _, _ = strings.Cut(header, "]")
+			table = splitKey(header)
+			for j := range table {
+				addKeyLine(lines, table[:j+1], i+1)
+			}
+		default:
+			name, _, found := strings.Cut(line, "=")
+			if !found {
+				continue
+			}
+			key := append(slices.Clone(table), splitKey(name)...)
+			for j := len(table); j < len(key); j++ {
+				addKeyLine(lines, key[:j+1], i+1)
+			}
+		}
+	}
+
+	return lines
+}
+
+func addKeyLine(lines map[string]int, key []string, line int) {
+	name := strings.Join(key, ".")
+	if _, exists := lines[name]; !exists {
+		lines[name] = line
+	}
+}
+
+func splitKey(key string) []string {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), `"'`)
+	}
+
+	return parts
+}
@@ -0,0 +1,162 @@
+package manifest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"numerous.com/cli/internal/test"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("returns no issues for valid manifests", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			content  string
+			expected Format
+		}{
+			{name: "v0", content: v0TOMLStreamlit, expected: FormatV0},
+			{name: "v1", content: v1TOMLStreamlit, expected: FormatV1},
+			{name: "python", content: tomlStreamlit, expected: FormatCurrent},
+			{name: "python with size", content: tomlStreamlitWithSize, expected: FormatCurrent},
+			{name: "docker", content: tomlDocker, expected: FormatCurrent},
+			{name: "env", content: tomlDocker + "\n[env]\nLOG_LEVEL = \"debug\"\n", expected: FormatCurrent},
+			{name: "tasks", content: tomlDocker + "\n[tasks.train]\ncommand = [\"python\", \"train.py\"]\n", expected: FormatCurrent},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				filePath := test.WriteTempFile(t, ManifestFileName, []byte(tc.content))
+				defer os.Remove(filePath)
+
+				actual, err := Validate(filePath)
+
+				require.NoError(t, err)
+				assert.Equal(t, ValidationResult{Format: tc.expected}, actual)
+				assert.True(t, actual.Valid())
+			})
+		}
+	})
+
+	t.Run("returns expected issues", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			content  string
+			expected []ValidationIssue
+		}{
+			{
+				name:     "syntax error",
+				content:  "name = \"app\"\nport == 80\n",
+				expected: []ValidationIssue{{Line: 2, Key: "port", Message: "expected value but found '=' instead"}},
+			},
+			{
+				name:    "unknown keys with suggestion",
+				content: "name = \"app\"\nauthor = \"me\"\n\n[python]\nlibrary = \"streamlit\"\napp-file = \"app.py\"\n",
+				expected: []ValidationIssue{
+					{Line: 2, Key: "author", Message: `unknown key "author"`},
+					{Line: 4, Key: "python", Message: `missing required key "app_file"`},
+					{Line: 6, Key: "python.app-file", Message: `unknown key "app-file", did you mean "app_file"?`},
+				},
+			},
+			{
+				name:    "unknown table reported once",
+				content: "[python]\nlibrary = \"streamlit\"\napp_file = \"app.py\"\n\n[extra]\na = 1\nb = 2\n",
+				expected: []ValidationIssue{
+					{Line: 5, Key: "extra", Message: `unknown key "extra"`},
+				},
+			},
+			{
+				name:    "type errors",
+				content: "port = \"80\"\nexclude = [\"venv\", 1]\n\n[docker]\ndockerfile = 1\n",
+				expected: []ValidationIssue{
+					{Line: 1, Key: "port", Message: "must be an integer, not string"},
+					{Line: 2, Key: "exclude", Message: "element 2 must be a string, not integer"},
+					{Line: 5, Key: "docker.dockerfile", Message: "must be a string, not integer"},
+				},
+			},
+			{
+				name:    "unsupported library",
+				content: "[python]\nlibrary = \"django\"\napp_file = \"app.py\"\n",
+				expected: []ValidationIssue{
+					{Line: 2, Key: "python.library", Message: `unsupported value "django", must be one of: streamlit, plotly, marimo, panel, gradio, fastapi, flask, shiny`},
+				},
+			},
+			{
+				name:    "negative port",
+				content: "port = -1\n[docker]\ndockerfile = \"Dockerfile\"\n",
+				expected: []ValidationIssue{
+					{Line: 1, Key: "port", Message: "must be at least 0"},
+				},
+			},
+			{
+				name:     "missing python and docker",
+				content:  "name = \"app\"\n",
+				expected: []ValidationIssue{{Key: "", Message: `one of "python" or "docker" must be defined`}},
+			},
+			{
+				name:     "both python and docker",
+				content:  "[python]\nlibrary = \"streamlit\"\napp_file = \"app.py\"\n[docker]\ndockerfile = \"Dockerfile\"\n",
+				expected: []ValidationIssue{{Key: "", Message: `only one of "python" or "docker" may be defined`}},
+			},
+			{
+				name:     "env value not a string",
+				content:  "[docker]\ndockerfile = \"Dockerfile\"\n\n[env]\nLOG_LEVEL = \"debug\"\nFEATURE_X = 1\n",
+				expected: []ValidationIssue{{Line: 6, Key: "env.FEATURE_X", Message: "must be a string, not integer"}},
+			},
+			{
+				name:     "task without command",
+				content:  "[docker]\ndockerfile = \"Dockerfile\"\n\n[tasks.train]\n",
+				expected: []ValidationIssue{{Line: 4, Key: "tasks.train", Message: `missing required key "command"`}},
+			},
+			{
+				name:     "v0 invalid port",
+				content:  "library = \"streamlit\"\napp_file = \"app.py\"\nport = \"eighty\"\n",
+				expected: []ValidationIssue{{Line: 3, Key: "port", Message: `port "eighty" is not a valid port number`}},
+			},
+			{
+				name:     "v1 unknown key",
+				content:  "library = \"streamlit\"\napp_file = \"app.py\"\nport = 80\n\n[deploy]\norg = \"org\"\n",
+				expected: []ValidationIssue{{Line: 6, Key: "deploy.org", Message: `unknown key "org"`}},
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				actual := validate(tc.content)
+
+				assert.Equal(t, tc.expected, actual.Issues)
+				assert.False(t, actual.Valid())
+			})
+		}
+	})
+
+	t.Run("returns error if file does not exist", func(t *testing.T) {
+		_, err := Validate("non-existing/numerous.toml")
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestKeyLines(t *testing.T) {
+	content := `name = "app" # comment
+
+[python]
+  library = "streamlit"
+  "app_file" = "app.py"
+
+[deploy.staging]
+organization = "org"
+python.version = "3.12"
+`
+
+	expected := map[string]int{
+		"name":                          1,
+		"python":                        3,
+		"python.library":                4,
+		"python.app_file":               5,
+		"deploy":                        7,
+		"deploy.staging":                7,
+		"deploy.staging.organization":   8,
+		"deploy.staging.python":         9,
+		"deploy.staging.python.version": 9,
+	}
+
+	assert.Equal(t, expected, keyLines(content))
+}
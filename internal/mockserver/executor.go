@@ -0,0 +1,271 @@
+package mockserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"numerous.com/cli/shared"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+var (
+	ErrOperationNotFound     = errors.New("operation not found")
+	ErrUnsupportedField      = errors.New("field is not supported by the mock server")
+	ErrSubscriptionFieldsLen = errors.New("subscriptions must select exactly one field")
+)
+
+// object is a resolved GraphQL object. Values are either plain values, other
+// objects, lists, or argument functions which are called with the field
+// arguments when the field is selected. The "__typename" key must be set for
+// objects resolved as a union member.
+type object = map[string]any
+
+// argumentFunc resolves a field which takes arguments.
+type argumentFunc = func(args map[string]any) any
+
+type (
+	fieldResolver        func(ctx context.Context, args map[string]any) (any, error)
+	subscriptionResolver func(ctx context.Context, args map[string]any) (<-chan any, error)
+)
+
+type graphQLError struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []graphQLError `json:"errors,omitempty"`
+}
+
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type executor struct {
+	schema        *ast.Schema
+	queries       map[string]fieldResolver
+	mutations     map[string]fieldResolver
+	subscriptions map[string]subscriptionResolver
+}
+
+func loadSchema() (*ast.Schema, error) {
+	return gqlparser.LoadSchema(&ast.Source{Name: "schema.gql", Input: shared.Schema})
+}
+
+// prepare parses and validates the request, and returns the operation with
+// the coerced variable values.
+func (e *executor) prepare(req graphQLRequest) (*ast.OperationDefinition, map[string]any, []graphQLError) {
+	doc, errs := gqlparser.LoadQuery(e.schema, req.Query)
+	if len(errs) > 0 {
+		gqlErrs := make([]graphQLError, len(errs))
+		for i, err := range errs {
+			gqlErrs[i] = graphQLError{Message: err.Message}
+		}
+
+		return nil, nil, gqlErrs
+	}
+
+	op := doc.Operations.ForName(req.OperationName)
+	if op == nil {
+		return nil, nil, []graphQLError{{Message: fmt.Sprintf("%s: %q", ErrOperationNotFound, req.OperationName)}}
+	}
+
+	vars, err := validator.VariableValues(e.schema, op, req.Variables)
+	if err != nil {
+		return nil, nil, []graphQLError{{Message: err.Error()}}
+	}
+
+	return op, vars, nil
+}
+
+// execute executes a query or mutation request.
+func (e *executor) execute(ctx context.Context, req graphQLRequest) graphQLResponse {
+	op, vars, errs := e.prepare(req)
+	if errs != nil {
+		return graphQLResponse{Errors: errs}
+	}
+
+	resolvers := e.queries
+	if op.Operation == ast.Mutation {
+		resolvers = e.mutations
+	}
+
+	resp := graphQLResponse{Data: make(map[string]any)}
+	for _, field := range collectFields(op.SelectionSet, "") {
+		if field.Name == "__typename" {
+			resp.Data[field.Alias] = field.ObjectDefinition.Name
+			continue
+		}
+
+		value, err := e.resolveRoot(ctx, resolvers, field, vars)
+		if err != nil {
+			resp.Errors = append(resp.Errors, graphQLError{Message: err.Error(), Path: []any{field.Alias}})
+			resp.Data[field.Alias] = nil
+
+			continue
+		}
+
+		resp.Data[field.Alias] = value
+	}
+
+	return resp
+}
+
+func (e *executor) resolveRoot(ctx context.Context, resolvers map[string]fieldResolver, field *ast.Field, vars map[string]any) (any, error) {
+	resolve, ok := resolvers[field.Name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedField, field.Name)
+	}
+
+	value, err := resolve(ctx, field.ArgumentMap(vars))
+	if err != nil {
+		return nil, err
+	}
+
+	return e.complete(field.Definition.Type, value, field.SelectionSet, vars), nil
+}
+
+// subscribe starts a subscription request, and returns a channel of the
+// response payloads, which is closed when the subscription ends.
+func (e *executor) subscribe(ctx context.Context, req graphQLRequest) (<-chan graphQLResponse, []graphQLError) {
+	op, vars, errs := e.prepare(req)
+	if errs != nil {
+		return nil, errs
+	}
+
+	fields := collectFields(op.SelectionSet, "")
+	if op.Operation != ast.Subscription || len(fields) != 1 {
+		return nil, []graphQLError{{Message: ErrSubscriptionFieldsLen.Error()}}
+	}
+
+	field := fields[0]
+	resolve, ok := e.subscriptions[field.Name]
+	if !ok {
+		return nil, []graphQLError{{Message: fmt.Sprintf("%s: %s", ErrUnsupportedField, field.Name), Path: []any{field.Alias}}}
+	}
+
+	values, err := resolve(ctx, field.ArgumentMap(vars))
+	if err != nil {
+		return nil, []graphQLError{{Message: err.Error(), Path: []any{field.Alias}}}
+	}
+
+	responses := make(chan graphQLResponse)
+	go func() {
+		defer close(responses)
+
+		for value := range values {
+			data := map[string]any{field.Alias: e.complete(field.Definition.Type, value, field.SelectionSet, vars)}
+			select {
+			case responses <- graphQLResponse{Data: data}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return responses, nil
+}
+
+// complete returns the value projected onto the selection set, as it should
+// be encoded in the response.
+func (e *executor) complete(typ *ast.Type, value any, selectionSet ast.SelectionSet, vars map[string]any) any {
+	if isNil(value) {
+		return nil
+	}
+
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer {
+		value = v.Elem().Interface()
+	}
+
+	if typ.Elem != nil {
+		list := reflect.ValueOf(value)
+		if list.Kind() != reflect.Slice {
+			return nil
+		}
+
+		completed := make([]any, list.Len())
+		for i := range list.Len() {
+			completed[i] = e.complete(typ.Elem, list.Index(i).Interface(), selectionSet, vars)
+		}
+
+		return completed
+	}
+
+	obj, ok := value.(object)
+	if !ok {
+		if t, ok := value.(time.Time); ok {
+			return t.Format(time.RFC3339Nano)
+		}
+
+		return value
+	}
+
+	typename := typ.NamedType
+	if name, ok := obj["__typename"].(string); ok {
+		typename = name
+	}
+
+	completed := make(map[string]any)
+	for _, field := range collectFields(selectionSet, typename) {
+		if field.Name == "__typename" {
+			completed[field.Alias] = typename
+			continue
+		}
+
+		fieldValue := obj[field.Name]
+		if resolve, ok := fieldValue.(argumentFunc); ok {
+			fieldValue = resolve(field.ArgumentMap(vars))
+		}
+
+		completed[field.Alias] = e.complete(field.Definition.Type, fieldValue, field.SelectionSet, vars)
+	}
+
+	return completed
+}
+
+// collectFields returns the fields of the selection set which apply to the
+// given type name, including fields of matching fragments. If the type name
+// is empty, all fragments match.
+func collectFields(selectionSet ast.SelectionSet, typename string) []*ast.Field {
+	var fields []*ast.Field
+
+	for _, selection := range selectionSet {
+		switch s := selection.(type) {
+		case *ast.Field:
+			fields = append(fields, s)
+		case *ast.InlineFragment:
+			if s.TypeCondition == "" || typename == "" || s.TypeCondition == typename {
+				fields = append(fields, collectFields(s.SelectionSet, typename)...)
+			}
+		case *ast.FragmentSpread:
+			if typename == "" || s.Definition.TypeCondition == typename {
+				fields = append(fields, collectFields(s.Definition.SelectionSet, typename)...)
+			}
+		}
+	}
+
+	return fields
+}
+
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
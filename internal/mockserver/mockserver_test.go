@@ -0,0 +1,244 @@
+package mockserver
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	appservice "numerous.com/cli/internal/app"
+	"numerous.com/cli/internal/appident"
+	"numerous.com/cli/internal/archive"
+
+	"github.com/hasura/go-graphql-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const timeout = 5 * time.Second
+
+func startServer(t *testing.T, opts Options) *httptest.Server {
+	t.Helper()
+
+	s, err := New(opts)
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+// newService returns an app service using the server. Each service can run
+// one subscription.
+func newService(ts *httptest.Server) *appservice.Service {
+	client := graphql.NewClient(ts.URL+queryPath, http.DefaultClient)
+	subscription := graphql.NewSubscriptionClient("ws" + strings.TrimPrefix(ts.URL, "http") + queryPath)
+
+	return appservice.New(client, subscription, http.DefaultClient)
+}
+
+func appArchive(t *testing.T) []byte {
+	t.Helper()
+
+	tarPath := filepath.Join(t.TempDir(), "app.tar")
+	require.NoError(t, archive.TarCreate("../../testdata/streamlit_app", tarPath, nil))
+
+	content, err := os.ReadFile(tarPath)
+	require.NoError(t, err)
+
+	return content
+}
+
+// deployApp creates, uploads, and deploys an app, and returns the app
+// version ID and deployment version ID.
+func deployApp(t *testing.T, ts *httptest.Server, ai appident.AppIdentifier, content []byte) (string, string) {
+	t.Helper()
+
+	ctx := context.Background()
+	service := newService(ts)
+
+	created, err := service.Create(ctx, appservice.CreateAppInput{OrganizationSlug: ai.OrganizationSlug, AppSlug: ai.AppSlug, DisplayName: "App", Description: "Description"})
+	require.NoError(t, err)
+
+	version, err := service.CreateVersion(ctx, appservice.CreateAppVersionInput{AppID: created.AppID, Version: "v1", Message: "message"})
+	require.NoError(t, err)
+
+	upload, err := service.AppVersionUploadURL(ctx, appservice.AppVersionUploadURLInput{AppVersionID: version.AppVersionID})
+	require.NoError(t, err)
+
+	err = service.UploadAppSource(ctx, upload.UploadURL, appservice.UploadArchive{Reader: bytes.NewReader(content), Size: int64(len(content))})
+	require.NoError(t, err)
+
+	deployed, err := service.DeployApp(ctx, appservice.DeployAppInput{AppVersionID: version.AppVersionID, AppRelativePath: ""})
+	require.NoError(t, err)
+
+	return version.AppVersionID, deployed.DeploymentVersionID
+}
+
+func receiveAll[T any](t *testing.T, ch chan T) []T {
+	t.Helper()
+
+	var values []T
+	for {
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				return values
+			}
+			values = append(values, v)
+		case <-time.After(timeout):
+			t.Fatal("timed out waiting for channel to close")
+		}
+	}
+}
+
+func TestDeployWorkflow(t *testing.T) {
+	ts := startServer(t, Options{})
+	ai := appident.AppIdentifier{OrganizationSlug: "organization-slug", AppSlug: "app-slug"}
+	content := appArchive(t)
+
+	versionID, deployVersionID := deployApp(t, ts, ai, content)
+
+	t.Run("streams deploy events", func(t *testing.T) {
+		var events []appservice.DeployEvent
+		err := newService(ts).DeployEvents(context.Background(), appservice.DeployEventsInput{
+			DeploymentVersionID: deployVersionID,
+			Handler: func(de appservice.DeployEvent) error {
+				events = append(events, de)
+				return nil
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, events, 4)
+		assert.Equal(t, "AppBuildMessageEvent", events[0].Typename)
+		assert.Contains(t, events[0].BuildMessage.Message, "Received app source")
+		assert.Equal(t, "RUNNING", events[3].DeploymentStatus.Status)
+	})
+
+	t.Run("reads deployed app", func(t *testing.T) {
+		read, err := newService(ts).ReadApp(context.Background(), appservice.ReadAppInput{OrganizationSlug: ai.OrganizationSlug, AppSlug: ai.AppSlug})
+
+		require.NoError(t, err)
+		assert.Equal(t, "App", read.AppDisplayName)
+	})
+
+	t.Run("returns app not found error", func(t *testing.T) {
+		_, err := newService(ts).ReadApp(context.Background(), appservice.ReadAppInput{OrganizationSlug: ai.OrganizationSlug, AppSlug: "other-app"})
+
+		assert.ErrorIs(t, err, appservice.ErrAppNotFound)
+	})
+
+	t.Run("serves uploaded app source at download URL", func(t *testing.T) {
+		current, err := newService(ts).CurrentAppVersion(context.Background(), appservice.CurrentAppVersionInput{OrganizationSlug: ai.OrganizationSlug, AppSlug: ai.AppSlug})
+		require.NoError(t, err)
+		assert.Equal(t, versionID, current.AppVersionID)
+
+		download, err := newService(ts).AppVersionDownloadURL(context.Background(), appservice.AppVersionDownloadURLInput{AppVersionID: versionID})
+		require.NoError(t, err)
+
+		resp, err := http.Get(download.DownloadURL) // nolint:noctx
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		downloaded, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, content, downloaded)
+	})
+
+	t.Run("streams existing logs without following", func(t *testing.T) {
+		entries, err := newService(ts).AppDeployLogs(ai, nil, false)
+		require.NoError(t, err)
+
+		logs := receiveAll(t, entries)
+		require.Len(t, logs, 1)
+		assert.Contains(t, logs[0].Text, "Started app version "+versionID)
+	})
+
+	t.Run("follows appended logs", func(t *testing.T) {
+		tail := 0
+		entries, err := newService(ts).AppDeployLogs(ai, &tail, true)
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			resp, err := http.Post(ts.URL+logsPath+"organization-slug/app-slug", "text/plain", strings.NewReader("line 1\nline 2\n")) // nolint:noctx
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			select {
+			case entry := <-entries:
+				return entry.Text == "line 1"
+			case <-time.After(100 * time.Millisecond):
+				return false
+			}
+		}, timeout, 10*time.Millisecond)
+	})
+}
+
+func TestDeployWithoutUploadReturnsBuildError(t *testing.T) {
+	ts := startServer(t, Options{})
+	ctx := context.Background()
+	service := newService(ts)
+
+	created, err := service.Create(ctx, appservice.CreateAppInput{OrganizationSlug: "organization-slug", AppSlug: "app-slug", DisplayName: "App"})
+	require.NoError(t, err)
+	version, err := service.CreateVersion(ctx, appservice.CreateAppVersionInput{AppID: created.AppID, Message: "message"})
+	require.NoError(t, err)
+	deployed, err := service.DeployApp(ctx, appservice.DeployAppInput{AppVersionID: version.AppVersionID})
+	require.NoError(t, err)
+
+	var events []appservice.DeployEvent
+	err = newService(ts).DeployEvents(ctx, appservice.DeployEventsInput{
+		DeploymentVersionID: deployed.DeploymentVersionID,
+		Handler: func(de appservice.DeployEvent) error {
+			events = append(events, de)
+			return nil
+		},
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+	assert.Equal(t, "AppBuildErrorEvent", events[0].Typename)
+}
+
+func TestTaskWorkflow(t *testing.T) {
+	ts := startServer(t, Options{
+		Tasks:        []TaskDefinition{{Name: "train", Command: []string{"python", "train.py"}}},
+		TaskDuration: 100 * time.Millisecond,
+	})
+	ai := appident.AppIdentifier{OrganizationSlug: "organization-slug", AppSlug: "app-slug"}
+	deployApp(t, ts, ai, appArchive(t))
+	ctx := context.Background()
+	service := newService(ts)
+
+	tasks, err := service.GetTasks(ctx, appservice.GetTasksInput{OrganizationSlug: ai.OrganizationSlug, AppSlug: ai.AppSlug})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "train", tasks[0].ID)
+
+	deployID, err := service.GetAppDeploymentID(ctx, ai.OrganizationSlug, ai.AppSlug)
+	require.NoError(t, err)
+
+	started, err := service.StartTask(ctx, appservice.StartTaskInput{OrganizationSlug: ai.OrganizationSlug, DeployID: deployID, TaskName: "train"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"python", "train.py"}, started.Command)
+
+	entries, err := newService(ts).TaskInstanceLogs(appservice.TaskInstanceLogsInput{InstanceID: started.TaskInstanceID, Follow: true})
+	require.NoError(t, err)
+	logs := receiveAll(t, entries)
+	require.Len(t, logs, 2)
+	assert.Equal(t, "Running task train: python train.py", logs[0].Text)
+	assert.Equal(t, "Task finished with exit code 0", logs[1].Text)
+
+	instance, err := service.GetTaskInstance(ctx, appservice.GetTaskInstanceInput{TaskInstanceID: started.TaskInstanceID})
+	require.NoError(t, err)
+	assert.Equal(t, "STOPPED", instance.Workload.Status)
+	require.NotNil(t, instance.Workload.ExitCode)
+	assert.Equal(t, 0, *instance.Workload.ExitCode)
+}
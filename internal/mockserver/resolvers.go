@@ -0,0 +1,689 @@
+package mockserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"numerous.com/cli/internal/archive"
+	"numerous.com/cli/internal/version"
+)
+
+var (
+	ErrAppNotFound          = errors.New("app not found")
+	ErrAppExists            = errors.New("app already exists")
+	ErrAppVersionNotFound   = errors.New("app version not found")
+	ErrDeploymentNotFound   = errors.New("deployment not found")
+	ErrTaskNotFound         = errors.New("task not found")
+	ErrTaskInstanceNotFound = errors.New("task instance not found")
+)
+
+const (
+	statusPending = "PENDING"
+	statusRunning = "RUNNING"
+	statusStopped = "STOPPED"
+	statusError   = "ERROR"
+
+	mockUserID       = "mock-user"
+	mockUserFullName = "Mock User"
+	mockUserEmail    = "mock-user@numerous.local"
+)
+
+func (s *Server) queryResolvers() map[string]fieldResolver {
+	return map[string]fieldResolver{
+		"checkVersion":  s.resolveCheckVersion,
+		"me":            s.resolveMe,
+		"organization":  s.resolveOrganization,
+		"app":           s.resolveApp,
+		"appWorkloads":  s.resolveAppWorkloads,
+		"tasks":         s.resolveTasks,
+		"taskInstances": s.resolveTaskInstances,
+		"taskInstance":  s.resolveTaskInstance,
+	}
+}
+
+func (s *Server) mutationResolvers() map[string]fieldResolver {
+	return map[string]fieldResolver{
+		"appCreate":             s.resolveAppCreate,
+		"appDelete":             s.resolveAppDelete,
+		"appVersionCreate":      s.resolveAppVersionCreate,
+		"appVersionUploadURL":   s.resolveAppVersionUploadURL,
+		"appVersionDownloadURL": s.resolveAppVersionDownloadURL,
+		"appDeploy":             s.resolveAppDeploy,
+		"appDeployShare":        s.resolveAppDeployShare,
+		"appDeployUnshare":      s.resolveAppDeployUnshare,
+		"taskStart":             s.resolveTaskStart,
+		"taskStop":              s.resolveTaskStop,
+	}
+}
+
+func (s *Server) subscriptionResolvers() map[string]subscriptionResolver {
+	return map[string]subscriptionResolver{
+		"appDeployEvents":  s.resolveAppDeployEvents,
+		"appDeployLogs":    s.resolveAppDeployLogs,
+		"taskInstanceLogs": s.resolveTaskInstanceLogs,
+	}
+}
+
+func (s *Server) resolveCheckVersion(ctx context.Context, args map[string]any) (any, error) {
+	return object{"__typename": "VersionCheckOK", "version": version.Version}, nil
+}
+
+func (s *Server) resolveMe(ctx context.Context, args map[string]any) (any, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	orgs := slices.SortedFunc(maps.Values(s.state.organizations), func(a, b *organization) int {
+		return strings.Compare(a.slug, b.slug)
+	})
+
+	memberships := []object{}
+	for _, org := range orgs {
+		memberships = append(memberships, object{
+			"id":           mockUserID + "-" + org.id,
+			"role":         "ADMIN",
+			"organization": organizationObject(org, s.taskObjects()),
+			"user":         userObject(),
+		})
+	}
+
+	me := userObject()
+	me["memberships"] = memberships
+
+	return me, nil
+}
+
+func (s *Server) resolveOrganization(ctx context.Context, args map[string]any) (any, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	org := s.state.organization(stringArg(args, "organizationSlug"))
+
+	return organizationObject(org, s.taskObjects()), nil
+}
+
+func (s *Server) resolveApp(ctx context.Context, args map[string]any) (any, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	a := s.state.findApp(stringArg(args, "organizationSlug"), stringArg(args, "appSlug"))
+	if a == nil {
+		return nil, ErrAppNotFound
+	}
+
+	return appObject(a, s.taskObjects()), nil
+}
+
+func (s *Server) resolveAppWorkloads(ctx context.Context, args map[string]any) (any, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	a, ok := s.state.apps[stringArg(args, "appID")]
+	if !ok {
+		return nil, ErrAppNotFound
+	}
+
+	if a.deployment == nil || a.deployment.current == nil {
+		return []object{}, nil
+	}
+
+	workload := object{
+		"organization":  organizationObject(a.organization, s.taskObjects()),
+		"startedAt":     a.deployment.startedAt,
+		"status":        a.deployment.current.status,
+		"logs":          logsConnection(a.logs),
+		"cpuUsage":      resourceUsageObject(),
+		"memoryUsageMB": resourceUsageObject(),
+	}
+
+	return []object{workload}, nil
+}
+
+func (s *Server) resolveTasks(ctx context.Context, args map[string]any) (any, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	_, d := s.state.findDeployment(stringArg(args, "deployID"))
+	if d == nil {
+		return nil, ErrDeploymentNotFound
+	}
+
+	return s.taskObjects(), nil
+}
+
+func (s *Server) resolveTaskInstances(ctx context.Context, args map[string]any) (any, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	deployID := stringArg(args, "deployID")
+	taskID := stringArg(args, "taskID")
+
+	taskInstances := slices.SortedFunc(maps.Values(s.state.taskInstances), func(a, b *taskInstance) int {
+		return a.createdAt.Compare(b.createdAt)
+	})
+
+	instances := []object{}
+	for _, ti := range taskInstances {
+		if ti.deployment.id == deployID && ti.task.Name == taskID {
+			instances = append(instances, taskInstanceObject(ti))
+		}
+	}
+
+	return instances, nil
+}
+
+func (s *Server) resolveTaskInstance(ctx context.Context, args map[string]any) (any, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	ti, ok := s.state.taskInstances[stringArg(args, "taskInstanceID")]
+	if !ok {
+		return nil, nil
+	}
+
+	return taskInstanceObject(ti), nil
+}
+
+func (s *Server) resolveAppCreate(ctx context.Context, args map[string]any) (any, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	org := s.state.organization(stringArg(args, "organizationSlug"))
+	appData := objectArg(args, "appData")
+	appSlug := stringArg(appData, "appSlug")
+	if s.state.findApp(org.slug, appSlug) != nil {
+		return nil, fmt.Errorf("%w: %s", ErrAppExists, appSlug)
+	}
+
+	a := &app{
+		id:           s.state.newID("app"),
+		slug:         appSlug,
+		displayName:  stringArg(appData, "displayName"),
+		description:  stringArg(appData, "description"),
+		createdAt:    time.Now(),
+		organization: org,
+		logs:         newLogStream(),
+	}
+	org.apps = append(org.apps, a)
+	s.state.apps[a.id] = a
+
+	return appObject(a, s.taskObjects()), nil
+}
+
+func (s *Server) resolveAppDelete(ctx context.Context, args map[string]any) (any, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	input := objectArg(args, "input")
+	orgSlug := stringArg(input, "organizationSlug")
+	appSlug := stringArg(input, "appSlug")
+
+	a := s.state.findApp(orgSlug, appSlug)
+	if a == nil {
+		return nil, ErrAppNotFound
+	}
+
+	for i, orgApp := range a.organization.apps {
+		if orgApp == a {
+			a.organization.apps = append(a.organization.apps[:i], a.organization.apps[i+1:]...)
+			break
+		}
+	}
+	delete(s.state.apps, a.id)
+	a.logs.Close()
+
+	return object{"appSlug": appSlug, "organizationSlug": orgSlug}, nil
+}
+
+func (s *Server) resolveAppVersionCreate(ctx context.Context, args map[string]any) (any, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	a, ok := s.state.apps[stringArg(args, "appID")]
+	if !ok {
+		return nil, ErrAppNotFound
+	}
+
+	input := objectArg(args, "input")
+	v := &appVersion{
+		id:      s.state.newID("app-version"),
+		app:     a,
+		version: stringArg(input, "version"),
+		message: stringArg(input, "message"),
+	}
+	s.state.versions[v.id] = v
+
+	return versionObject(v, s.taskObjects()), nil
+}
+
+func (s *Server) resolveAppVersionUploadURL(ctx context.Context, args map[string]any) (any, error) {
+	return s.versionURL(ctx, args, uploadPath)
+}
+
+func (s *Server) resolveAppVersionDownloadURL(ctx context.Context, args map[string]any) (any, error) {
+	return s.versionURL(ctx, args, downloadPath)
+}
+
+func (s *Server) versionURL(ctx context.Context, args map[string]any, path string) (any, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	versionID := stringArg(args, "appVersionID")
+	if _, ok := s.state.versions[versionID]; !ok {
+		return nil, ErrAppVersionNotFound
+	}
+
+	return object{"url": baseURL(ctx) + path + versionID}, nil
+}
+
+func (s *Server) resolveAppDeploy(ctx context.Context, args map[string]any) (any, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	v, ok := s.state.versions[stringArg(args, "appVersionID")]
+	if !ok {
+		return nil, ErrAppVersionNotFound
+	}
+
+	a := v.app
+	if a.deployment == nil {
+		a.deployment = &deployment{id: s.state.newID("deployment")}
+	}
+
+	dv := &deployVersion{id: s.state.newID("deploy-version"), createdAt: time.Now(), status: statusPending, version: v}
+	s.state.deployVersions[dv.id] = dv
+
+	if v.archive == nil {
+		dv.status = statusError
+	} else {
+		dv.status = statusRunning
+		a.deployment.current = dv
+		a.deployment.startedAt = time.Now()
+		a.logs.Append(fmt.Sprintf("Started app version %s of %s/%s", v.id, a.organization.slug, a.slug))
+	}
+
+	return deployVersionObject(dv, s.taskObjects()), nil
+}
+
+func (s *Server) resolveAppDeployShare(ctx context.Context, args map[string]any) (any, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	_, d := s.state.findDeployment(stringArg(args, "deployID"))
+	if d == nil {
+		return nil, ErrDeploymentNotFound
+	}
+
+	sharedURL := baseURL(ctx) + sharedPath + d.id
+	d.sharedURL = &sharedURL
+
+	return deploymentObject(d, s.taskObjects()), nil
+}
+
+func (s *Server) resolveAppDeployUnshare(ctx context.Context, args map[string]any) (any, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	_, d := s.state.findDeployment(stringArg(args, "deployID"))
+	if d == nil {
+		return nil, ErrDeploymentNotFound
+	}
+
+	d.sharedURL = nil
+
+	return deploymentObject(d, s.taskObjects()), nil
+}
+
+func (s *Server) resolveTaskStart(ctx context.Context, args map[string]any) (any, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	input := objectArg(args, "input")
+	_, d := s.state.findDeployment(stringArg(input, "deployID"))
+	if d == nil {
+		return nil, ErrDeploymentNotFound
+	}
+
+	taskName := stringArg(input, "taskName")
+	task, ok := s.findTask(taskName)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTaskNotFound, taskName)
+	}
+
+	ti := &taskInstance{
+		id:         s.state.newID("task-instance"),
+		task:       task,
+		deployment: d,
+		createdAt:  time.Now(),
+		status:     statusRunning,
+		logs:       newLogStream(),
+		stop:       make(chan struct{}),
+	}
+	if taskInput, ok := input["input"].(string); ok {
+		ti.input = &taskInput
+	}
+	s.state.taskInstances[ti.id] = ti
+
+	ti.logs.Append("Running task " + task.Name + ": " + strings.Join(task.Command, " "))
+	go s.runTaskInstance(ti)
+
+	return taskInstanceObject(ti), nil
+}
+
+// runTaskInstance simulates the task instance workload, which finishes after
+// the task duration, or when it is stopped.
+func (s *Server) runTaskInstance(ti *taskInstance) {
+	var exitCode *int
+	var message string
+
+	select {
+	case <-time.After(s.taskDuration):
+		code := 0
+		exitCode = &code
+		message = "Task finished with exit code 0"
+	case <-ti.stop:
+		message = "Task stopped"
+	}
+
+	ti.logs.Append(message)
+	ti.logs.Close()
+
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	ti.status = statusStopped
+	ti.exitCode = exitCode
+}
+
+func (s *Server) resolveTaskStop(ctx context.Context, args map[string]any) (any, error) {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	ti, ok := s.state.taskInstances[stringArg(args, "taskInstanceID")]
+	if !ok {
+		return nil, ErrTaskInstanceNotFound
+	}
+
+	if ti.status == statusRunning {
+		select {
+		case <-ti.stop:
+		default:
+			close(ti.stop)
+		}
+	}
+
+	return object{"taskInstanceID": ti.id}, nil
+}
+
+func (s *Server) resolveAppDeployEvents(ctx context.Context, args map[string]any) (<-chan any, error) {
+	s.state.mu.Lock()
+	dv, ok := s.state.deployVersions[stringArg(args, "appDeploymentVersionID")]
+	if !ok {
+		s.state.mu.Unlock()
+		return nil, ErrDeploymentNotFound
+	}
+
+	events := deployEvents(dv)
+	s.state.mu.Unlock()
+
+	return sendAll(ctx, events), nil
+}
+
+// deployEvents returns the events of building and starting the deployment
+// version. Must be called with the lock held.
+func deployEvents(dv *deployVersion) []any {
+	if dv.version.archive == nil {
+		return []any{
+			object{"__typename": "AppBuildErrorEvent", "message": "No app source was uploaded for app version " + dv.version.id},
+			object{"__typename": "AppDeploymentStatusEvent", "status": statusError},
+		}
+	}
+
+	files, err := archive.TarReadFiles(bytes.NewReader(dv.version.archive))
+	if err != nil {
+		return []any{
+			object{"__typename": "AppBuildErrorEvent", "message": "Error reading app source: " + err.Error()},
+			object{"__typename": "AppDeploymentStatusEvent", "status": statusError},
+		}
+	}
+
+	return []any{
+		object{"__typename": "AppBuildMessageEvent", "message": fmt.Sprintf("Received app source with %d files", len(files))},
+		object{"__typename": "AppBuildMessageEvent", "message": "Built app version " + dv.version.id},
+		object{"__typename": "AppDeploymentStatusEvent", "status": statusPending},
+		object{"__typename": "AppDeploymentStatusEvent", "status": dv.status},
+	}
+}
+
+func (s *Server) resolveAppDeployLogs(ctx context.Context, args map[string]any) (<-chan any, error) {
+	input := objectArg(args, "input")
+
+	s.state.mu.Lock()
+	a := s.state.findApp(stringArg(input, "organizationSlug"), stringArg(input, "appSlug"))
+	s.state.mu.Unlock()
+
+	if a == nil {
+		return nil, ErrAppNotFound
+	}
+
+	return logEntries(ctx, a.logs, input), nil
+}
+
+func (s *Server) resolveTaskInstanceLogs(ctx context.Context, args map[string]any) (<-chan any, error) {
+	input := objectArg(args, "input")
+
+	s.state.mu.Lock()
+	ti, ok := s.state.taskInstances[stringArg(input, "taskInstanceID")]
+	s.state.mu.Unlock()
+
+	if !ok {
+		return nil, ErrTaskInstanceNotFound
+	}
+
+	return logEntries(ctx, ti.logs, input), nil
+}
+
+// logEntries subscribes to the log stream with the tail and follow options
+// of the logs subscription input. Following is the default.
+func logEntries(ctx context.Context, logs *logStream, input map[string]any) <-chan any {
+	tail := -1
+	if n, ok := intArg(input, "tail"); ok {
+		tail = n
+	}
+
+	follow := true
+	if f, ok := input["follow"].(bool); ok {
+		follow = f
+	}
+
+	entries := logs.Subscribe(ctx, tail, follow)
+	values := make(chan any)
+	go func() {
+		defer close(values)
+
+		for entry := range entries {
+			select {
+			case values <- logEntryObject(entry):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return values
+}
+
+func sendAll(ctx context.Context, items []any) <-chan any {
+	values := make(chan any)
+	go func() {
+		defer close(values)
+
+		for _, item := range items {
+			select {
+			case values <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return values
+}
+
+func (s *Server) findTask(name string) (TaskDefinition, bool) {
+	for _, task := range s.state.tasks {
+		if task.Name == name {
+			return task, true
+		}
+	}
+
+	return TaskDefinition{}, false
+}
+
+func (s *Server) taskObjects() []object {
+	tasks := make([]object, len(s.state.tasks))
+	for i, task := range s.state.tasks {
+		tasks[i] = taskObject(task)
+	}
+
+	return tasks
+}
+
+func userObject() object {
+	return object{"id": mockUserID, "fullName": mockUserFullName, "email": mockUserEmail}
+}
+
+func organizationObject(org *organization, tasks []object) object {
+	apps := make([]object, len(org.apps))
+	for i, a := range org.apps {
+		apps[i] = appObject(a, tasks)
+	}
+
+	return object{
+		"__typename":         "Organization",
+		"id":                 org.id,
+		"name":               org.slug,
+		"slug":               org.slug,
+		"createdAt":          org.createdAt,
+		"apps":               apps,
+		"hasCustomerAccount": false,
+	}
+}
+
+func appObject(a *app, tasks []object) object {
+	obj := object{
+		"id":          a.id,
+		"slug":        a.slug,
+		"displayName": a.displayName,
+		"description": a.description,
+		"createdBy":   userObject(),
+		"createdAt":   a.createdAt,
+	}
+
+	if a.deployment != nil {
+		obj["defaultDeployment"] = deploymentObject(a.deployment, tasks)
+	}
+
+	return obj
+}
+
+func deploymentObject(d *deployment, tasks []object) object {
+	obj := object{"id": d.id, "name": "default", "sharedURL": d.sharedURL}
+	if d.current != nil {
+		obj["current"] = deployVersionObject(d.current, tasks)
+	}
+
+	return obj
+}
+
+func deployVersionObject(dv *deployVersion, tasks []object) object {
+	return object{
+		"id":         dv.id,
+		"createdAt":  dv.createdAt,
+		"status":     dv.status,
+		"appVersion": versionObject(dv.version, tasks),
+	}
+}
+
+func versionObject(v *appVersion, tasks []object) object {
+	return object{"id": v.id, "version": v.version, "message": v.message, "tasks": tasks}
+}
+
+func taskObject(task TaskDefinition) object {
+	return object{"id": task.Name, "command": task.Command}
+}
+
+func taskInstanceObject(ti *taskInstance) object {
+	return object{
+		"id":        ti.id,
+		"task":      taskObject(ti.task),
+		"createdAt": ti.createdAt,
+		"input":     ti.input,
+		"progress":  object{},
+		"workload": object{
+			"status":        ti.status,
+			"startedAt":     ti.createdAt,
+			"logs":          logsConnection(ti.logs),
+			"cpuUsage":      resourceUsageObject(),
+			"memoryUsageMB": resourceUsageObject(),
+			"exitCode":      ti.exitCode,
+		},
+	}
+}
+
+func resourceUsageObject() object {
+	return object{"current": 0.0, "timeseries": []object{}}
+}
+
+func logEntryObject(entry logEntry) object {
+	return object{"timestamp": entry.timestamp, "text": entry.text}
+}
+
+// logsConnection returns the argument function resolving the logs field of
+// workloads.
+func logsConnection(logs *logStream) argumentFunc {
+	return func(args map[string]any) any {
+		last := -1
+		if n, ok := intArg(args, "last"); ok {
+			last = n
+		}
+
+		entries := logs.Last(last)
+		edges := make([]object, len(entries))
+		for i, entry := range entries {
+			edges[i] = logEntryObject(entry)
+		}
+
+		return object{"edges": edges, "pageInfo": object{"hasNextPage": false}}
+	}
+}
+
+func stringArg(args map[string]any, name string) string {
+	s, _ := args[name].(string)
+
+	return s
+}
+
+func objectArg(args map[string]any, name string) map[string]any {
+	obj, _ := args[name].(map[string]any)
+
+	return obj
+}
+
+func intArg(args map[string]any, name string) (int, bool) {
+	switch n := args[name].(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
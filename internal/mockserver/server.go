@@ -0,0 +1,177 @@
+// Package mockserver implements an in-memory server for the subset of the
+// Numerous platform GraphQL API used by the CLI, for testing the CLI without
+// access to the platform.
+package mockserver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	queryPath    = "/query"
+	uploadPath   = "/upload/"
+	downloadPath = "/download/"
+	sharedPath   = "/shared/"
+	logsPath     = "/logs/"
+
+	DefaultTaskDuration = 5 * time.Second
+)
+
+type Options struct {
+	// Tasks which can be started for deployed apps.
+	Tasks []TaskDefinition
+	// TaskDuration is the time task instances run before finishing.
+	TaskDuration time.Duration
+}
+
+type Server struct {
+	state        *state
+	executor     *executor
+	taskDuration time.Duration
+	mux          *http.ServeMux
+}
+
+func New(opts Options) (*Server, error) {
+	schema, err := loadSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	taskDuration := opts.TaskDuration
+	if taskDuration == 0 {
+		taskDuration = DefaultTaskDuration
+	}
+
+	s := &Server{state: newState(opts.Tasks), taskDuration: taskDuration, mux: http.NewServeMux()}
+	s.executor = &executor{
+		schema:        schema,
+		queries:       s.queryResolvers(),
+		mutations:     s.mutationResolvers(),
+		subscriptions: s.subscriptionResolvers(),
+	}
+
+	s.mux.HandleFunc(queryPath, s.handleQuery)
+	s.mux.HandleFunc("PUT "+uploadPath+"{versionID}", s.handleUpload)
+	s.mux.HandleFunc("GET "+downloadPath+"{versionID}", s.handleDownload)
+	s.mux.HandleFunc("POST "+logsPath+"{organizationSlug}/{appSlug}", s.handleAppendLogs)
+
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	slog.Debug("mock server request", slog.String("method", r.Method), slog.String("path", r.URL.Path))
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleQuery(w http.ResponseWriter, r *http.Request) {
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		s.handleSubscriptions(w, r)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req graphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := s.executor.execute(withBaseURL(r.Context(), requestBaseURL(r)), req)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		slog.Warn("error writing response", slog.String("error", err.Error()))
+	}
+}
+
+// handleUpload stores the app source archive of an app version.
+func (s *Server) handleUpload(w http.ResponseWriter, r *http.Request) {
+	content, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "error reading upload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	v, ok := s.state.versions[r.PathValue("versionID")]
+	if !ok {
+		http.Error(w, ErrAppVersionNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	v.archive = content
+}
+
+// handleDownload returns the app source archive of an app version.
+func (s *Server) handleDownload(w http.ResponseWriter, r *http.Request) {
+	s.state.mu.Lock()
+	v, ok := s.state.versions[r.PathValue("versionID")]
+	var content []byte
+	if ok {
+		content = v.archive
+	}
+	s.state.mu.Unlock()
+
+	if content == nil {
+		http.Error(w, ErrAppVersionNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Write(content) // nolint:errcheck
+}
+
+// handleAppendLogs appends each line of the request body as an entry to the
+// logs of an app.
+func (s *Server) handleAppendLogs(w http.ResponseWriter, r *http.Request) {
+	content, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "error reading logs: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.state.mu.Lock()
+	a := s.state.findApp(r.PathValue("organizationSlug"), r.PathValue("appSlug"))
+	s.state.mu.Unlock()
+
+	if a == nil {
+		http.Error(w, ErrAppNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	a.logs.Append(strings.Split(strings.TrimRight(string(content), "\n"), "\n")...)
+}
+
+type baseURLKey struct{}
+
+func withBaseURL(ctx context.Context, url string) context.Context {
+	return context.WithValue(ctx, baseURLKey{}, url)
+}
+
+func baseURL(ctx context.Context) string {
+	url, _ := ctx.Value(baseURLKey{}).(string)
+
+	return url
+}
+
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	return scheme + "://" + r.Host
+}
@@ -0,0 +1,274 @@
+package mockserver
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const logListenerBuffer = 100
+
+type organization struct {
+	id        string
+	slug      string
+	createdAt time.Time
+	apps      []*app
+}
+
+type app struct {
+	id           string
+	slug         string
+	displayName  string
+	description  string
+	createdAt    time.Time
+	organization *organization
+	deployment   *deployment
+	logs         *logStream
+}
+
+type appVersion struct {
+	id      string
+	app     *app
+	version string
+	message string
+	archive []byte
+}
+
+type deployment struct {
+	id        string
+	current   *deployVersion
+	sharedURL *string
+	startedAt time.Time
+}
+
+type deployVersion struct {
+	id        string
+	createdAt time.Time
+	status    string
+	version   *appVersion
+}
+
+// TaskDefinition is a task which can be started for all deployed apps.
+type TaskDefinition struct {
+	Name    string
+	Command []string
+}
+
+type taskInstance struct {
+	id         string
+	task       TaskDefinition
+	deployment *deployment
+	createdAt  time.Time
+	input      *string
+	status     string
+	exitCode   *int
+	logs       *logStream
+	stop       chan struct{}
+}
+
+type state struct {
+	mu             sync.Mutex
+	nextID         int
+	organizations  map[string]*organization
+	apps           map[string]*app
+	versions       map[string]*appVersion
+	deployVersions map[string]*deployVersion
+	taskInstances  map[string]*taskInstance
+	tasks          []TaskDefinition
+}
+
+func newState(tasks []TaskDefinition) *state {
+	return &state{
+		organizations:  make(map[string]*organization),
+		apps:           make(map[string]*app),
+		versions:       make(map[string]*appVersion),
+		deployVersions: make(map[string]*deployVersion),
+		taskInstances:  make(map[string]*taskInstance),
+		tasks:          tasks,
+	}
+}
+
+// newID returns a new unique ID. Must be called with the lock held.
+func (s *state) newID(prefix string) string {
+	s.nextID++
+
+	return prefix + "-" + strconv.Itoa(s.nextID)
+}
+
+// organization returns the organization with the slug, which is created if
+// it does not exist. Must be called with the lock held.
+func (s *state) organization(slug string) *organization {
+	if org, ok := s.organizations[slug]; ok {
+		return org
+	}
+
+	org := &organization{id: s.newID("organization"), slug: slug, createdAt: time.Now()}
+	s.organizations[slug] = org
+
+	return org
+}
+
+// findApp returns the app with the slug in the organization, or nil if it
+// does not exist. Must be called with the lock held.
+func (s *state) findApp(orgSlug, appSlug string) *app {
+	org, ok := s.organizations[orgSlug]
+	if !ok {
+		return nil
+	}
+
+	for _, a := range org.apps {
+		if a.slug == appSlug {
+			return a
+		}
+	}
+
+	return nil
+}
+
+// findDeployment returns the app and deployment with the ID, or nil if it
+// does not exist. Must be called with the lock held.
+func (s *state) findDeployment(deployID string) (*app, *deployment) {
+	for _, a := range s.apps {
+		if a.deployment != nil && a.deployment.id == deployID {
+			return a, a.deployment
+		}
+	}
+
+	return nil, nil
+}
+
+type logEntry struct {
+	timestamp time.Time
+	text      string
+}
+
+// logStream stores log entries and streams them to listeners.
+type logStream struct {
+	mu        sync.Mutex
+	entries   []logEntry
+	listeners map[chan logEntry]struct{}
+	closed    bool
+}
+
+func newLogStream() *logStream {
+	return &logStream{listeners: make(map[chan logEntry]struct{})}
+}
+
+// Append adds log entries with the given texts, and sends them to all
+// listeners. Entries are dropped for listeners that do not keep up.
+func (l *logStream) Append(texts ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, text := range texts {
+		entry := logEntry{timestamp: time.Now(), text: text}
+		l.entries = append(l.entries, entry)
+
+		for listener := range l.listeners {
+			select {
+			case listener <- entry:
+			default:
+			}
+		}
+	}
+}
+
+// Close ends the stream, closing the channels of all listeners.
+func (l *logStream) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return
+	}
+
+	l.closed = true
+	for listener := range l.listeners {
+		close(listener)
+		delete(l.listeners, listener)
+	}
+}
+
+// Last returns the last n entries, or all entries if n is negative.
+func (l *logStream) Last(n int) []logEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.last(n)
+}
+
+func (l *logStream) last(n int) []logEntry {
+	if n < 0 || n > len(l.entries) {
+		n = len(l.entries)
+	}
+
+	return append([]logEntry(nil), l.entries[len(l.entries)-n:]...)
+}
+
+// Subscribe returns a channel of the last tail entries, or all entries if
+// tail is negative, followed by new entries if follow is true. The channel is
+// closed when the context is done, the stream is closed, or after the
+// existing entries, if not following.
+func (l *logStream) Subscribe(ctx context.Context, tail int, follow bool) <-chan logEntry {
+	l.mu.Lock()
+	existing := l.last(tail)
+
+	var listener chan logEntry
+	if follow && !l.closed {
+		listener = make(chan logEntry, logListenerBuffer)
+		l.listeners[listener] = struct{}{}
+	}
+	l.mu.Unlock()
+
+	entries := make(chan logEntry)
+	go func() {
+		defer close(entries)
+		defer l.unsubscribe(listener)
+
+		for _, entry := range existing {
+			select {
+			case entries <- entry:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if listener == nil {
+			return
+		}
+
+		for {
+			select {
+			case entry, ok := <-listener:
+				if !ok {
+					return
+				}
+
+				select {
+				case entries <- entry:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return entries
+}
+
+func (l *logStream) unsubscribe(listener chan logEntry) {
+	if listener == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.listeners[listener]; ok {
+		delete(l.listeners, listener)
+		close(listener)
+	}
+}
@@ -0,0 +1,190 @@
+package mockserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+// The message types of the subscriptions-transport-ws protocol, which the
+// CLI subscription client uses.
+const (
+	subprotocol = "graphql-ws"
+
+	messageConnectionInit      = "connection_init"
+	messageConnectionAck       = "connection_ack"
+	messageConnectionTerminate = "connection_terminate"
+	messageKeepAlive           = "ka"
+	messageStart               = "start"
+	messageStop                = "stop"
+	messageData                = "data"
+	messageError               = "error"
+	messageComplete            = "complete"
+
+	keepAliveInterval = 10 * time.Second
+)
+
+type operationMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type subscriptionConn struct {
+	conn     *websocket.Conn
+	executor *executor
+	baseURL  string
+
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+func (s *Server) handleSubscriptions(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{Subprotocols: []string{subprotocol}, InsecureSkipVerify: true})
+	if err != nil {
+		slog.Warn("error accepting websocket connection", slog.String("error", err.Error()))
+		return
+	}
+
+	sc := &subscriptionConn{
+		conn:     conn,
+		executor: s.executor,
+		baseURL:  requestBaseURL(r),
+		cancels:  make(map[string]context.CancelFunc),
+	}
+
+	err = sc.serve(r.Context())
+	if err != nil && websocket.CloseStatus(err) == -1 && !errors.Is(err, context.Canceled) {
+		slog.Debug("websocket connection closed", slog.String("error", err.Error()))
+	}
+}
+
+// serve reads and handles client messages until the connection is closed or
+// terminated.
+func (sc *subscriptionConn) serve(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		sc.wg.Wait()
+		sc.conn.Close(websocket.StatusNormalClosure, "") // nolint:errcheck
+	}()
+
+	for {
+		var msg operationMessage
+		if err := wsjson.Read(ctx, sc.conn, &msg); err != nil {
+			return err
+		}
+
+		switch msg.Type {
+		case messageConnectionInit:
+			if err := sc.write(ctx, operationMessage{Type: messageConnectionAck}); err != nil {
+				return err
+			}
+
+			sc.wg.Add(1)
+			go sc.keepAlive(ctx)
+		case messageStart:
+			sc.start(ctx, msg)
+		case messageStop:
+			sc.stop(msg.ID)
+		case messageConnectionTerminate:
+			return nil
+		}
+	}
+}
+
+func (sc *subscriptionConn) keepAlive(ctx context.Context) {
+	defer sc.wg.Done()
+
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := sc.write(ctx, operationMessage{Type: messageKeepAlive}); err != nil {
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// start runs the subscription of the message, sending its data until it is
+// complete, or stopped by the client.
+func (sc *subscriptionConn) start(ctx context.Context, msg operationMessage) {
+	var req graphQLRequest
+	if err := json.Unmarshal(msg.Payload, &req); err != nil {
+		sc.writeErrors(ctx, msg.ID, []graphQLError{{Message: "invalid payload: " + err.Error()}})
+		return
+	}
+
+	subCtx, cancel := context.WithCancel(withBaseURL(ctx, sc.baseURL))
+	responses, errs := sc.executor.subscribe(subCtx, req)
+	if errs != nil {
+		cancel()
+		sc.writeErrors(ctx, msg.ID, errs)
+
+		return
+	}
+
+	sc.mu.Lock()
+	sc.cancels[msg.ID] = cancel
+	sc.mu.Unlock()
+
+	sc.wg.Add(1)
+	go func() {
+		defer sc.wg.Done()
+		defer sc.stop(msg.ID)
+
+		for resp := range responses {
+			payload, err := json.Marshal(resp)
+			if err != nil {
+				slog.Warn("error encoding subscription data", slog.String("error", err.Error()))
+				continue
+			}
+
+			if err := sc.write(ctx, operationMessage{ID: msg.ID, Type: messageData, Payload: payload}); err != nil {
+				return
+			}
+		}
+
+		if subCtx.Err() == nil {
+			sc.write(ctx, operationMessage{ID: msg.ID, Type: messageComplete}) // nolint:errcheck
+		}
+	}()
+}
+
+func (sc *subscriptionConn) stop(id string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if cancel, ok := sc.cancels[id]; ok {
+		cancel()
+		delete(sc.cancels, id)
+	}
+}
+
+func (sc *subscriptionConn) writeErrors(ctx context.Context, id string, errs []graphQLError) {
+	payload, err := json.Marshal(errs)
+	if err != nil {
+		slog.Warn("error encoding subscription errors", slog.String("error", err.Error()))
+		return
+	}
+
+	sc.write(ctx, operationMessage{ID: id, Type: messageError, Payload: payload}) // nolint:errcheck
+}
+
+func (sc *subscriptionConn) write(ctx context.Context, msg operationMessage) error {
+	return wsjson.Write(ctx, sc.conn, msg)
+}
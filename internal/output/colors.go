@@ -7,6 +7,8 @@ const (
 	AnsiGreen    = "\033[32m"
 	AnsiYellow   = "\033[33m"
 	AnsiBlue     = "\033[34m"
+	AnsiMagenta  = "\033[35m"
+	AnsiCyan     = "\033[36m"
 	AnsiReset    = "\033[0m"
 	AnsiFaint    = "\033[2m"
 	AnsiCyanBold = "\033[1;36m"
@@ -2,6 +2,8 @@ package output
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -16,9 +18,15 @@ func PrintError(header, body string, args ...any) {
 }
 
 func PrintWarning(header, body string) {
+	FprintWarning(os.Stdout, header, body)
+}
+
+// Prints a warning to the given writer, e.g. to stderr when stdout is used for
+// machine readable output.
+func FprintWarning(w io.Writer, header, body string) {
 	body = addTrailingNewLine(body)
 	f := AnsiYellow + "! " + header + "\n" + body + AnsiReset
-	fmt.Print(f)
+	fmt.Fprint(w, f)
 }
 
 func addTrailingNewLine(value string) string {
@@ -89,11 +97,18 @@ func PrintErrorInvalidAppSlug(appSlug string) {
 }
 
 func PrintManifestTOMLError(err error) {
-	if !strings.HasPrefix(err.Error(), "toml:") {
+	if !IsManifestTOMLError(err) {
 		return
 	}
 
 	fmt.Println("There is a an error in your \"numerous.toml\" manifest.\n" + err.Error())
+	fmt.Println("Run " + Highlight("numerous manifest validate") + " to see all problems in the manifest.")
+}
+
+// IsManifestTOMLError returns true if the error is a TOML decoding error, which
+// is printed by PrintManifestTOMLError.
+func IsManifestTOMLError(err error) bool {
+	return strings.HasPrefix(err.Error(), "toml:")
 }
 
 func PrintErrorAccessDenied() {
@@ -0,0 +1,58 @@
+package output
+
+import (
+	"strings"
+
+	"numerous.com/cli/internal/timeseries"
+)
+
+var sparklineRunes = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders the values of the timeseries as a line of block
+// characters, scaled between the minimum and maximum value. If there are more
+// points than the width, consecutive points are averaged, so the sparkline
+// covers the whole timeseries.
+func Sparkline(t timeseries.Timeseries, width int) string {
+	if len(t) == 0 || width <= 0 {
+		return ""
+	}
+
+	values := resample(t, width)
+	minValue, maxValue := t.MinValue(), t.MaxValue()
+
+	var b strings.Builder
+	for _, v := range values {
+		i := len(sparklineRunes) / 2 // nolint:mnd
+		if maxValue > minValue {
+			i = int((v - minValue) / (maxValue - minValue) * float64(len(sparklineRunes)-1))
+		}
+		b.WriteRune(sparklineRunes[i])
+	}
+
+	return b.String()
+}
+
+// resample returns at most width values of the timeseries, averaging
+// consecutive points into buckets.
+func resample(t timeseries.Timeseries, width int) []float64 {
+	if len(t) <= width {
+		values := make([]float64, len(t))
+		for i, p := range t {
+			values[i] = p.Value
+		}
+
+		return values
+	}
+
+	values := make([]float64, width)
+	for i := range width {
+		start, end := i*len(t)/width, (i+1)*len(t)/width
+		sum := 0.0
+		for _, p := range t[start:end] {
+			sum += p.Value
+		}
+		values[i] = sum / float64(end-start)
+	}
+
+	return values
+}
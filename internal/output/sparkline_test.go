@@ -0,0 +1,38 @@
+package output
+
+import (
+	"testing"
+	"time"
+
+	"numerous.com/cli/internal/timeseries"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparkline(t *testing.T) {
+	series := func(values ...float64) timeseries.Timeseries {
+		ts := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		var t timeseries.Timeseries
+		for i, v := range values {
+			t = append(t, timeseries.TimeseriesPoint{Value: v, Timestamp: ts.Add(time.Duration(i) * time.Minute)})
+		}
+
+		return t
+	}
+
+	t.Run("scales values between minimum and maximum", func(t *testing.T) {
+		assert.Equal(t, "▁▂▃▄▅▆▇█", Sparkline(series(0, 1, 2, 3, 4, 5, 6, 7), 10))
+	})
+
+	t.Run("averages points to fit the width", func(t *testing.T) {
+		assert.Equal(t, "▁▄█", Sparkline(series(0, 0, 3, 4, 7, 7), 3))
+	})
+
+	t.Run("renders constant values in the middle", func(t *testing.T) {
+		assert.Equal(t, "▅▅▅", Sparkline(series(2, 2, 2), 10))
+	})
+
+	t.Run("renders nothing for empty timeseries", func(t *testing.T) {
+		assert.Empty(t, Sparkline(nil, 10))
+	})
+}
@@ -0,0 +1,319 @@
+// Package pyproject reads and edits pyproject.toml files, as used by uv and
+// Poetry projects, while preserving their formatting.
+package pyproject
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"numerous.com/cli/internal/requirements"
+)
+
+const FileName = "pyproject.toml"
+
+var ErrNoDependencyTable = errors.New("no [project] or [tool.poetry.dependencies] table")
+
+type pyprojectToml struct {
+	lines []string // lines of the file, without line terminators
+	crlf  bool     // detected crlf line termination
+}
+
+type projectValues struct {
+	Project struct {
+		Dependencies   []string `toml:"dependencies"`
+		RequiresPython string   `toml:"requires-python"`
+	} `toml:"project"`
+	Tool struct {
+		Poetry struct {
+			Dependencies map[string]any `toml:"dependencies"`
+		} `toml:"poetry"`
+	} `toml:"tool"`
+}
+
+func Read(r io.Reader) (*pyprojectToml, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	content := string(data)
+	crlf := strings.Contains(content, "\r\n")
+	if crlf {
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+	}
+
+	p := &pyprojectToml{lines: strings.Split(content, "\n"), crlf: crlf}
+	if _, err := p.values(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *pyprojectToml) Write(w io.Writer) error {
+	lineEnding := "\n"
+	if p.crlf {
+		lineEnding = "\r\n"
+	}
+
+	_, err := w.Write([]byte(strings.Join(p.lines, lineEnding)))
+
+	return err
+}
+
+func (p *pyprojectToml) values() (projectValues, error) {
+	var v projectValues
+	_, err := toml.Decode(strings.Join(p.lines, "\n"), &v)
+
+	return v, err
+}
+
+// Dependencies returns the names of the project dependencies, defined in
+// either the [project] table, or the [tool.poetry.dependencies] table.
+func (p *pyprojectToml) Dependencies() []string {
+	v, err := p.values()
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, dep := range v.Project.Dependencies {
+		names = append(names, requirements.RequirementName(dep))
+	}
+
+	for name := range v.Tool.Poetry.Dependencies {
+		if name != "python" {
+			names = append(names, requirements.NormalizeName(name))
+		}
+	}
+
+	return names
+}
+
+// HasDependency returns true if the named package is a project dependency.
+func (p *pyprojectToml) HasDependency(name string) bool {
+	name = requirements.NormalizeName(name)
+	for _, dep := range p.Dependencies() {
+		if dep == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PythonVersion returns the minimum python version, in the form "3.11",
+// allowed by "requires-python" in the [project] table, or by the python
+// dependency of a Poetry project. If none is defined, an empty string is
+// returned.
+func (p *pyprojectToml) PythonVersion() string {
+	v, err := p.values()
+	if err != nil {
+		return ""
+	}
+
+	if v.Project.RequiresPython != "" {
+		return MinimumPythonVersion(v.Project.RequiresPython)
+	}
+
+	if spec, ok := v.Tool.Poetry.Dependencies["python"].(string); ok {
+		return MinimumPythonVersion(spec)
+	}
+
+	return ""
+}
+
+var versionSpecRegexp = regexp.MustCompile(`(>=|>|~=|==|\^|~|^)\s*(\d+)\.(\d+)`)
+
+// MinimumPythonVersion returns the major and minor version of the lower bound
+// of a version specifier, e.g. "3.10" for ">=3.10,<4".
+func MinimumPythonVersion(spec string) string {
+	for _, clause := range strings.Split(spec, ",") {
+		if m := versionSpecRegexp.FindStringSubmatch(strings.TrimSpace(clause)); m != nil {
+			return m[2] + "." + m[3]
+		}
+	}
+
+	return ""
+}
+
+// HasDependencyTable returns true if the file has a [project] table, or a
+// [tool.poetry.dependencies] table, which dependencies can be added to. Files
+// with only tool configuration, e.g. for formatters, do not.
+func (p *pyprojectToml) HasDependencyTable() bool {
+	if _, _, ok := p.table("project"); ok {
+		return true
+	}
+
+	_, _, ok := p.table("tool.poetry.dependencies")
+
+	return ok
+}
+
+// Add adds the requirement to the dependencies of the [project] table, or to
+// the [tool.poetry.dependencies] table for Poetry projects without a
+// [project] table. Other lines of the file are kept as they are. If the
+// package is already a dependency, nothing is changed.
+func (p *pyprojectToml) Add(requirement string) error {
+	if p.HasDependency(requirements.RequirementName(requirement)) {
+		return nil
+	}
+
+	if start, end, ok := p.table("project"); ok {
+		p.addProjectDependency(start, end, requirement)
+		return nil
+	}
+
+	if start, end, ok := p.table("tool.poetry.dependencies"); ok {
+		p.insertLines(lastValueLine(p.lines, start, end)+1, requirements.RequirementName(requirement)+` = "*"`)
+		return nil
+	}
+
+	return ErrNoDependencyTable
+}
+
+// table returns the index of the header line of the named table, and the index
+// after its last line.
+func (p *pyprojectToml) table(name string) (int, int, bool) {
+	start := -1
+	for i, line := range p.lines {
+		header, isHeader := tableHeader(line)
+		switch {
+		case !isHeader:
+		case start >= 0:
+			return start, i, true
+		case header == name:
+			start = i
+		}
+	}
+
+	return start, len(p.lines), start >= 0
+}
+
+func tableHeader(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "[") {
+		return "", false
+	}
+
+	header := strings.TrimLeft(trimmed, "[")
+	header, _, _ = strings.Cut(header, "]")
+	parts := strings.Split(header, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), `"'`)
+	}
+
+	return strings.Join(parts, "."), true
+}
+
+func (p *pyprojectToml) addProjectDependency(start, end int, requirement string) {
+	quoted := `"` + requirement + `"`
+
+	for i := start + 1; i < end; i++ {
+		key, value, found := strings.Cut(p.lines[i], "=")
+		if !found || strings.TrimSpace(key) != "dependencies" {
+			continue
+		}
+
+		// single line array
+		if strings.Contains(code(value), "]") {
+			p.lines[i] = key + "=" + appendToArray(value, quoted)
+			return
+		}
+
+		closingLine := i + 1
+		for closingLine < end && !strings.Contains(code(p.lines[closingLine]), "]") {
+			closingLine++
+		}
+
+		// closing bracket after the last element
+		if closingLine < end && !strings.HasPrefix(strings.TrimSpace(p.lines[closingLine]), "]") {
+			p.lines[closingLine] = appendToArray(p.lines[closingLine], quoted)
+			return
+		}
+
+		// closing bracket on its own line, where the element is added on a new
+		// line before it
+		indent := "    "
+		last := lastValueLine(p.lines, i, closingLine)
+		if last > i {
+			line := p.lines[last]
+			indent = line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			p.lines[last] = withTrailingComma(line)
+		}
+		p.insertLines(last+1, indent+quoted+",")
+
+		return
+	}
+
+	p.insertLines(lastValueLine(p.lines, start, end)+1, "dependencies = ["+quoted+"]")
+}
+
+// appendToArray adds the element before the last closing bracket in the line.
+func appendToArray(line string, element string) string {
+	closing := strings.LastIndex(code(line), "]")
+	prefix := strings.TrimRight(line[:closing], " \t")
+
+	separator := ", "
+	if strings.HasSuffix(prefix, "[") {
+		separator = ""
+	} else if strings.HasSuffix(prefix, ",") {
+		separator = " "
+	}
+
+	return prefix + separator + element + line[closing:]
+}
+
+var stringRegexp = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'[^']*'`)
+
+// maskStrings replaces the contents of strings in the line with underscores,
+// so brackets and comment symbols in strings are not mistaken for syntax.
+func maskStrings(line string) string {
+	return stringRegexp.ReplaceAllStringFunc(line, func(s string) string {
+		return s[:1] + strings.Repeat("_", len(s)-2) + s[len(s)-1:]
+	})
+}
+
+// code returns the line with strings masked, and without any comment.
+func code(line string) string {
+	masked := maskStrings(line)
+	if i := strings.Index(masked, "#"); i >= 0 {
+		return masked[:i]
+	}
+
+	return masked
+}
+
+// lastValueLine returns the index of the last line after start and before end,
+// which is not blank or a comment, or start if there is no such line.
+func lastValueLine(lines []string, start, end int) int {
+	for i := end - 1; i > start; i-- {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			return i
+		}
+	}
+
+	return start
+}
+
+func withTrailingComma(line string) string {
+	value, comment := line, ""
+	if i := strings.Index(maskStrings(line), "#"); i >= 0 {
+		value, comment = line[:i], line[i:]
+	}
+
+	trimmed := strings.TrimRight(value, " \t")
+	if strings.HasSuffix(trimmed, ",") {
+		return line
+	}
+
+	return trimmed + "," + value[len(trimmed):] + comment
+}
+
+func (p *pyprojectToml) insertLines(at int, lines ...string) {
+	p.lines = append(p.lines[:at], append(lines, p.lines[at:]...)...)
+}
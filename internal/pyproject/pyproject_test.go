@@ -0,0 +1,138 @@
+package pyproject
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdd(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		content  string
+		added    string
+		expected string
+	}{
+		{
+			name: "multiline dependencies",
+			content: `[project]
+name = "my-app"
+dependencies = [
+    "pandas>=2",  # data frames
+    "numpy"
+]
+
+[tool.uv]
+dev-dependencies = []
+`,
+			added: "streamlit",
+			expected: `[project]
+name = "my-app"
+dependencies = [
+    "pandas>=2",  # data frames
+    "numpy",
+    "streamlit",
+]
+
+[tool.uv]
+dev-dependencies = []
+`,
+		},
+		{
+			name:     "multiline dependencies with closing bracket after last element",
+			content:  "[project]\ndependencies = [\n  \"pandas[excel]\",\n  \"numpy\"]\n",
+			added:    "streamlit",
+			expected: "[project]\ndependencies = [\n  \"pandas[excel]\",\n  \"numpy\", \"streamlit\"]\n",
+		},
+		{
+			name:     "single line dependencies",
+			content:  "[project]\nname = \"my-app\"\ndependencies = [\"pandas\"] # comment [x]\n",
+			added:    "streamlit",
+			expected: "[project]\nname = \"my-app\"\ndependencies = [\"pandas\", \"streamlit\"] # comment [x]\n",
+		},
+		{
+			name:     "empty dependencies",
+			content:  "[project]\ndependencies = []\n",
+			added:    "streamlit",
+			expected: "[project]\ndependencies = [\"streamlit\"]\n",
+		},
+		{
+			name:     "no dependencies key",
+			content:  "[project]\nname = \"my-app\"\n\n[tool.uv]\npackage = false\n",
+			added:    "streamlit",
+			expected: "[project]\nname = \"my-app\"\ndependencies = [\"streamlit\"]\n\n[tool.uv]\npackage = false\n",
+		},
+		{
+			name:     "poetry dependencies",
+			content:  "[tool.poetry]\nname = \"my-app\"\n\n[tool.poetry.dependencies]\npython = \"^3.11\"\n\n[build-system]\n",
+			added:    "streamlit",
+			expected: "[tool.poetry]\nname = \"my-app\"\n\n[tool.poetry.dependencies]\npython = \"^3.11\"\nstreamlit = \"*\"\n\n[build-system]\n",
+		},
+		{
+			name:     "existing dependency with other spelling",
+			content:  "[project]\ndependencies = [\"Plotly_Dash>=2\"]\n",
+			added:    "plotly-dash",
+			expected: "[project]\ndependencies = [\"Plotly_Dash>=2\"]\n",
+		},
+		{
+			name:     "crlf line endings",
+			content:  "[project]\r\ndependencies = [\r\n  \"numpy\",\r\n]\r\n",
+			added:    "streamlit",
+			expected: "[project]\r\ndependencies = [\r\n  \"numpy\",\r\n  \"streamlit\",\r\n]\r\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := Read(strings.NewReader(tc.content))
+			require.NoError(t, err)
+
+			err = p.Add(tc.added)
+			require.NoError(t, err)
+
+			buf := new(bytes.Buffer)
+			require.NoError(t, p.Write(buf))
+			assert.Equal(t, tc.expected, buf.String())
+			assert.True(t, p.HasDependency(tc.added))
+		})
+	}
+
+	t.Run("returns error without dependency table", func(t *testing.T) {
+		p, err := Read(strings.NewReader("[tool.black]\nline-length = 100\n"))
+		require.NoError(t, err)
+
+		err = p.Add("streamlit")
+
+		assert.ErrorIs(t, err, ErrNoDependencyTable)
+	})
+}
+
+func TestRead(t *testing.T) {
+	t.Run("returns error for invalid TOML", func(t *testing.T) {
+		_, err := Read(strings.NewReader("[project\n"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPythonVersion(t *testing.T) {
+	for _, tc := range []struct {
+		content  string
+		expected string
+	}{
+		{content: "[project]\nrequires-python = \">=3.10\"\n", expected: "3.10"},
+		{content: "[project]\nrequires-python = \">=3.11,<4\"\n", expected: "3.11"},
+		{content: "[project]\nrequires-python = \"<4, ~=3.12\"\n", expected: "3.12"},
+		{content: "[project]\nrequires-python = \"==3.9.*\"\n", expected: "3.9"},
+		{content: "[tool.poetry.dependencies]\npython = \"^3.11\"\n", expected: "3.11"},
+		{content: "[project]\nname = \"my-app\"\n", expected: ""},
+	} {
+		t.Run(tc.content, func(t *testing.T) {
+			p, err := Read(strings.NewReader(tc.content))
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, p.PythonVersion())
+		})
+	}
+}
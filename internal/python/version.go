@@ -34,6 +34,12 @@ func PythonVersion() string {
 	return fallbackVersion
 }
 
+// LocalVersion returns the version, in the form "3.11", of the python
+// interpreter in the environment.
+func LocalVersion() (string, error) {
+	return getPythonVersion()
+}
+
 func getPythonVersion() (string, error) {
 	p, err := execPythonVersionCommand()
 	if err != nil {
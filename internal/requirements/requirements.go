@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"io"
+	"regexp"
 	"strings"
 
 	"golang.org/x/text/encoding"
@@ -80,6 +81,39 @@ func (r *requirementsTxt) Add(added string) {
 	}
 }
 
+// Has returns true if the named package is required.
+func (r *requirementsTxt) Has(name string) bool {
+	name = NormalizeName(name)
+	for _, l := range r.lines {
+		if RequirementName(l) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+var (
+	requirementNameRegexp = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)`)
+	nameSeparatorRegexp   = regexp.MustCompile(`[-_.]+`)
+)
+
+// RequirementName returns the normalized package name of a requirement
+// specifier, e.g. "streamlit" for "Streamlit[charts]>=1.30".
+func RequirementName(requirement string) string {
+	m := requirementNameRegexp.FindStringSubmatch(requirement)
+	if m == nil {
+		return ""
+	}
+
+	return NormalizeName(m[1])
+}
+
+// NormalizeName normalizes a python package name, as described in PEP 503.
+func NormalizeName(name string) string {
+	return nameSeparatorRegexp.ReplaceAllString(strings.ToLower(name), "-")
+}
+
 // dropCR drops a terminal \r from the data, and return true.
 func dropCR(data []byte) ([]byte, bool) {
 	if bytes.HasSuffix(data, []byte{'\r'}) {
@@ -134,3 +134,25 @@ func TestAdd(t *testing.T) {
 		})
 	}
 }
+
+func TestRequirementName(t *testing.T) {
+	for requirement, expected := range map[string]string{
+		"streamlit":                      "streamlit",
+		"Streamlit[charts]>=1.30":        "streamlit",
+		"plotly_dash ; python_version>3": "plotly-dash",
+		"zope.interface==6":              "zope-interface",
+		"":                               "",
+	} {
+		assert.Equal(t, expected, RequirementName(requirement), requirement)
+	}
+}
+
+func TestHas(t *testing.T) {
+	req, err := Read(strings.NewReader("# comment\nStreamlit[charts]>=1.30\npandas\n"))
+	require.NoError(t, err)
+
+	assert.True(t, req.Has("streamlit"))
+	assert.True(t, req.Has("Pandas"))
+	assert.False(t, req.Has("numpy"))
+	assert.False(t, req.Has("comment"))
+}
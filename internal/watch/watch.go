@@ -0,0 +1,131 @@
+// Package watch detects changes to the files of a directory tree, by polling
+// the files that would be included in an app archive.
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"numerous.com/cli/internal/archive"
+)
+
+type Options struct {
+	// Dir is the root of the watched directory tree.
+	Dir string
+
+	// Exclude are the patterns of files that are not watched, using the same
+	// rules as when creating an app archive.
+	Exclude []string
+
+	// Interval is the time between polling the files for changes.
+	Interval time.Duration
+
+	// Debounce is the time without further changes that must pass, before
+	// changes are reported.
+	Debounce time.Duration
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+type snapshot map[string]fileState
+
+// Changes watches the directory tree, and sends the sorted, slash separated
+// paths of the files that have been added, modified or removed, once no
+// further changes have been detected for the debounce period. The channel is
+// closed when the context is done.
+func Changes(ctx context.Context, opts Options) (<-chan []string, error) {
+	previous, err := takeSnapshot(opts.Dir, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan []string)
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+
+		pending := make(map[string]struct{})
+		var lastChange time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				// files may be removed while walking the tree, in which case
+				// the changes are detected at the next tick
+				current, err := takeSnapshot(opts.Dir, opts.Exclude)
+				if err != nil {
+					continue
+				}
+
+				for _, changed := range changedFiles(previous, current) {
+					pending[changed] = struct{}{}
+					lastChange = now
+				}
+				previous = current
+
+				if len(pending) == 0 || now.Sub(lastChange) < opts.Debounce {
+					continue
+				}
+
+				paths := make([]string, 0, len(pending))
+				for p := range pending {
+					paths = append(paths, p)
+				}
+				slices.Sort(paths)
+				clear(pending)
+
+				select {
+				case ch <- paths:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func takeSnapshot(dir string, exclude []string) (snapshot, error) {
+	files, err := archive.ListFiles(dir, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	s := make(snapshot, len(files))
+	for _, f := range files {
+		info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(f)))
+		if err != nil {
+			return nil, err
+		}
+		s[f] = fileState{modTime: info.ModTime(), size: info.Size()}
+	}
+
+	return s, nil
+}
+
+func changedFiles(previous, current snapshot) []string {
+	var changed []string
+	for p, state := range current {
+		if prev, ok := previous[p]; !ok || prev != state {
+			changed = append(changed, p)
+		}
+	}
+
+	for p := range previous {
+		if _, ok := current[p]; !ok {
+			changed = append(changed, p)
+		}
+	}
+
+	return changed
+}
@@ -0,0 +1,86 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"numerous.com/cli/internal/test"
+)
+
+func TestChanges(t *testing.T) {
+	const interval = 10 * time.Millisecond
+	const debounce = 50 * time.Millisecond
+
+	setup := func(t *testing.T) (string, <-chan []string) {
+		t.Helper()
+
+		dir := t.TempDir()
+		test.WriteFile(t, filepath.Join(dir, "app.py"), []byte("print('hello')"))
+		test.WriteFile(t, filepath.Join(dir, "old.py"), []byte("print('old')"))
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "venv"), 0o755))
+		test.WriteFile(t, filepath.Join(dir, "venv", "lib.py"), []byte("print('lib')"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		changes, err := Changes(ctx, Options{Dir: dir, Exclude: []string{"venv"}, Interval: interval, Debounce: debounce})
+		require.NoError(t, err)
+
+		return dir, changes
+	}
+
+	t.Run("reports added, modified and removed files together", func(t *testing.T) {
+		dir, changes := setup(t)
+
+		test.WriteFile(t, filepath.Join(dir, "app.py"), []byte("print('hello, world')"))
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "pages"), 0o755))
+		test.WriteFile(t, filepath.Join(dir, "pages", "page.py"), []byte("print('page')"))
+		require.NoError(t, os.Remove(filepath.Join(dir, "old.py")))
+
+		select {
+		case paths := <-changes:
+			assert.Equal(t, []string{"app.py", "old.py", "pages/page.py"}, paths)
+		case <-time.After(time.Second):
+			t.Fatal("expected changes to be reported")
+		}
+	})
+
+	t.Run("ignores excluded files", func(t *testing.T) {
+		dir, changes := setup(t)
+
+		test.WriteFile(t, filepath.Join(dir, "venv", "lib.py"), []byte("print('changed')"))
+
+		select {
+		case paths := <-changes:
+			t.Fatalf("expected no changes, got %v", paths)
+		case <-time.After(5 * debounce):
+		}
+	})
+
+	t.Run("closes channel when context is done", func(t *testing.T) {
+		dir := t.TempDir()
+		ctx, cancel := context.WithCancel(context.Background())
+
+		changes, err := Changes(ctx, Options{Dir: dir, Interval: interval, Debounce: debounce})
+		require.NoError(t, err)
+		cancel()
+
+		select {
+		case _, ok := <-changes:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("expected channel to be closed")
+		}
+	})
+
+	t.Run("returns error for missing directory", func(t *testing.T) {
+		_, err := Changes(context.Background(), Options{Dir: filepath.Join(t.TempDir(), "missing"), Interval: interval, Debounce: debounce})
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
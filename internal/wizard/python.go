@@ -13,6 +13,8 @@ type PythonAnswers struct {
 	Library          manifest.Library
 	AppFile          string
 	RequirementsFile string
+	ProjectFile      string
+	LockFile         string
 }
 
 func (p PythonAnswers) ToManifest() *manifest.Python {
@@ -24,6 +26,8 @@ func (p PythonAnswers) ToManifest() *manifest.Python {
 		Library:          p.Library,
 		AppFile:          strings.Trim(p.AppFile, " 	"),
 		RequirementsFile: strings.Trim(p.RequirementsFile, " 	"),
+		ProjectFile:      p.ProjectFile,
+		LockFile:         p.LockFile,
 	}
 }
 
@@ -40,7 +44,7 @@ func pythonWizard(asker Asker, libraryName string, ps PythonAnswers) (PythonAnsw
 		qs = append(qs, getFileQuestion("AppFile", "Provide the path to your app:", "app.py", ".py"))
 	}
 
-	if ps.RequirementsFile == "" {
+	if ps.RequirementsFile == "" && ps.ProjectFile == "" {
 		q := getFileQuestion("RequirementsFile", "Provide the path to your requirements file:", "requirements.txt", ".txt")
 		qs = append(qs, q)
 	}
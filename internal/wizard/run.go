@@ -14,7 +14,7 @@ func questionsNeeded(params RunWizardParams) bool {
 		return true
 	}
 
-	if params.Python.Library.Key != "" && params.Python.Library.Key != manifest.DockerfileLibraryKey && params.Python.AppFile != "" && params.Python.RequirementsFile != "" {
+	if params.Python.Library.Key != "" && params.Python.Library.Key != manifest.DockerfileLibraryKey && params.Python.AppFile != "" && (params.Python.RequirementsFile != "" || params.Python.ProjectFile != "") {
 		return false
 	}
 
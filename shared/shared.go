@@ -0,0 +1,8 @@
+package shared
+
+import _ "embed"
+
+// Schema is the GraphQL schema of the Numerous platform API.
+//
+//go:embed schema.gql
+var Schema string